@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
@@ -17,6 +20,10 @@ var (
 	agentDryRun       bool
 	agentValidateOnly bool
 	agentBuildAndPush bool
+	agentWait         bool
+	agentWaitTimeout  time.Duration
+	agentParallel     int
+	agentFailFast     bool
 )
 
 // deployCmd represents the deploy command
@@ -27,7 +34,7 @@ var deployCmd = &cobra.Command{
 
 Команда позволяет развертывать агентов из YAML файла с поддержкой:
 • Включения других файлов через !include
-• Валидации конфигурации по JSON схеме
+• Валидации конфигурации по JSON схеме и семантическим правилам
 • Привязки MCP серверов к агентам
 • Режима предварительного просмотра (dry-run)
 • Только валидации без развертывания
@@ -35,10 +42,15 @@ var deployCmd = &cobra.Command{
 Примеры использования:
   ai-agents-cli agents deploy agents.yaml
   ai-agents-cli agents deploy --file agents.yaml --dry-run
+  ai-agents-cli agents deploy agents.yaml --wait
+  ai-agents-cli agents deploy agents.yaml --parallel 5 --fail-fast
   ai-agents-cli agents deploy --validate-only`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		// Ctrl-C отменяет развертывание: ресурсы, которые еще не начали
+		// развертываться, пропускаются
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
 		// Определяем файл конфигурации
 		configFile := agentDeployFile
@@ -75,6 +87,9 @@ var deployCmd = &cobra.Command{
 		// Создаем деплойер
 		agentDeployer := deployer.NewAgentDeployer(apiClient)
 
+		// Независимые ресурсы развертываются параллельно (--parallel или BULK_OPERATIONS_CONCURRENCY)
+		agentDeployer.SetParallel(deployer.ResolveParallelOptions(agentParallel, agentFailFast, container.GetConfig))
+
 		// Валидация конфигурации
 		fmt.Println(ui.FormatInfo("Validating configuration..."))
 		if err := agentDeployer.ValidateAgents(configFile); err != nil {
@@ -100,6 +115,16 @@ var deployCmd = &cobra.Command{
 
 		// Показываем результаты
 		deployer.ShowDeployResults(results)
+
+		// Ожидаем запуска развернутых ресурсов
+		if agentWait && !agentDryRun {
+			fmt.Println(ui.FormatInfo("Waiting for resources to become ready..."))
+			waitResults := deployer.NewWaiter(apiClient).Wait(ctx, results, deployer.WaitOptions{Timeout: agentWaitTimeout})
+			deployer.ShowWaitResults(os.Stdout, waitResults)
+			if deployer.HasWaitFailures(waitResults) {
+				os.Exit(1)
+			}
+		}
 	},
 }
 
@@ -110,4 +135,8 @@ func init() {
 	deployCmd.Flags().BoolVarP(&agentDryRun, "dry-run", "d", false, "Режим предварительного просмотра без создания ресурсов")
 	deployCmd.Flags().BoolVar(&agentValidateOnly, "validate-only", false, "Только валидация конфигурации без развертывания")
 	deployCmd.Flags().BoolVarP(&agentBuildAndPush, "build-image", "b", false, "Автоматическая сборка и загрузка Docker образов в Artifact Registry")
+	deployCmd.Flags().BoolVar(&agentWait, "wait", false, "Ожидать запуска развернутых ресурсов")
+	deployCmd.Flags().DurationVar(&agentWaitTimeout, "timeout", deployer.DefaultWaitTimeout, "Максимальное время ожидания запуска каждого ресурса")
+	deployCmd.Flags().IntVar(&agentParallel, "parallel", 0, "Максимальное количество ресурсов, развертываемых одновременно (по умолчанию BULK_OPERATIONS_CONCURRENCY)")
+	deployCmd.Flags().BoolVar(&agentFailFast, "fail-fast", false, "Не начинать развертывание остальных ресурсов после первой ошибки (начатые завершаются)")
 }
@@ -4,14 +4,24 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/errors"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	listLimit  int
-	listOffset int
+	listLimit       int
+	listOffset      int
+	listName        string
+	listStatuses    []string
+	listNotStatuses []string
+	listSearch      string
+	listCreatedBy   string
+	listUsesMCP     []string
+	listAll         bool
 )
 
 // listCmd represents the list command
@@ -30,16 +40,33 @@ var listCmd = &cobra.Command{
 • Дата последнего обновления
 
 Поддерживает постраничную навигацию с помощью флагов --limit и --offset.
+Флаг --all загружает все страницы и показывает их одной таблицей, --limit
+при этом не учитывается, а --offset не допускается.
+
+Фильтры применяются на сервере. Флаги --status, --not-status и --uses-mcp
+можно повторять или перечислять значения через запятую. Статус указывается
+без префикса AGENT_STATUS_, MCP сервер — по имени или ID.
 
 Примеры использования:
   ai-agents-cli agents list
   ai-agents-cli agents list --limit 10
-  ai-agents-cli agents list --offset 20 --limit 5`,
+  ai-agents-cli agents list --offset 20 --limit 5
+  ai-agents-cli agents list --all
+  ai-agents-cli agents list --status RUNNING --uses-mcp my-tools --search support
+  ai-agents-cli agents list --not-status deleted,cooled --created-by user-id`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
+		filter, err := listFilter(ctx)
+		if err != nil {
+			errorHandler := errors.NewHandler()
+			appErr := errorHandler.WrapUserError(err, "AGENTS_LIST_FILTER_INVALID", "Некорректный фильтр списка агентов")
+			fmt.Println(errorHandler.HandlePlain(appErr))
+			return
+		}
+
 		// Показываем таблицу агентов
-		if err := ui.ShowAgentsListFromAPI(ctx, listLimit, listOffset); err != nil {
+		if err := ui.ShowAgentsListFromAPI(ctx, filter, listAll); err != nil {
 			// Создаем обработчик ошибок
 			errorHandler := errors.NewHandler()
 			appErr := errorHandler.WrapAPIError(err, "AGENTS_LIST_FAILED", "Ошибка получения списка агентов")
@@ -60,7 +87,53 @@ var listCmd = &cobra.Command{
 	},
 }
 
+// listFilter собирает параметры поиска агентов из флагов команды
+func listFilter(ctx context.Context) (api.SearchAgentParams, error) {
+	filter := api.SearchAgentParams{
+		Limit:     listLimit,
+		Offset:    listOffset,
+		Name:      listName,
+		Search:    listSearch,
+		CreatedBy: listCreatedBy,
+	}
+
+	var err error
+	if filter.Statuses, err = api.ParseEnumValues(api.AgentStatusValues, listStatuses); err != nil {
+		return filter, fmt.Errorf("invalid --status: %w", err)
+	}
+	if filter.NotInStatuses, err = api.ParseEnumValues(api.AgentStatusValues, listNotStatuses); err != nil {
+		return filter, fmt.Errorf("invalid --not-status: %w", err)
+	}
+
+	if len(listUsesMCP) > 0 {
+		apiClient, err := di.GetContainer().GetAPI()
+		if err != nil {
+			return filter, fmt.Errorf("failed to get API client: %w", err)
+		}
+
+		// Фильтр принимает только ID, поэтому имена MCP серверов разрешаем заранее
+		registry := deployer.NewRegistry(apiClient)
+		for _, ref := range listUsesMCP {
+			id, err := registry.Resolve(ctx, deployer.KindMCPServer, ref)
+			if err != nil {
+				return filter, fmt.Errorf("invalid --uses-mcp: %w", err)
+			}
+			filter.UsesMCPServersIDs = append(filter.UsesMCPServersIDs, id)
+		}
+	}
+
+	return filter, nil
+}
+
 func init() {
 	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 20, "Количество записей для отображения")
 	listCmd.Flags().IntVarP(&listOffset, "offset", "o", 0, "Смещение для постраничной навигации")
+	listCmd.Flags().StringVar(&listName, "name", "", "Фильтр по названию агента")
+	listCmd.Flags().StringSliceVar(&listStatuses, "status", nil, "Показать агентов только в указанных статусах")
+	listCmd.Flags().StringSliceVar(&listNotStatuses, "not-status", nil, "Исключить агентов в указанных статусах")
+	listCmd.Flags().StringVar(&listSearch, "search", "", "Полнотекстовый поиск по агентам")
+	listCmd.Flags().StringVar(&listCreatedBy, "created-by", "", "Фильтр по ID создателя агента")
+	listCmd.Flags().StringSliceVar(&listUsesMCP, "uses-mcp", nil, "Показать агентов, использующих MCP сервер (имя или ID)")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "Загрузить все страницы списка")
+	listCmd.MarkFlagsMutuallyExclusive("all", "offset")
 }
@@ -40,18 +40,22 @@ var marketplaceCmd = &cobra.Command{
 	}
 
 		// Формируем запрос поиска
-		searchReq := &api.MarketplaceSearchRequest{
+		searchParams := &api.SearchPredefinedAgentParams{
 			Limit:      marketplaceLimit,
 			Offset:     marketplaceOffset,
 			Name:       marketplaceName,
 			Tags:       marketplaceTags,
 			Categories: marketplaceCategories,
-			Statuses:   marketplaceStatuses,
-			Types:      marketplaceTypes,
+		}
+		for _, status := range marketplaceStatuses {
+			searchParams.Statuses = append(searchParams.Statuses, api.AgentPredefinedStatus(status))
+		}
+		for _, agentType := range marketplaceTypes {
+			searchParams.Types = append(searchParams.Types, api.AgentPredefinedType(agentType))
 		}
 
 		// Ищем агентов в маркетплейсе
-		result, err := apiClient.Agents.SearchMarketplace(ctx, searchReq)
+		result, err := apiClient.Agents.SearchPredefinedAgent(ctx, searchParams)
 		if err != nil {
 			log.Fatal("Failed to search marketplace", "error", err)
 		}
@@ -85,7 +89,7 @@ var marketplaceCmd = &cobra.Command{
 
 		for _, agent := range result.Data {
 			// Статус
-			status := agent.Status
+			status := string(agent.Status)
 			switch status {
 			case "AGENT_PREDEFINED_STATUS_AVAILABLE":
 				status = statusStyle.Copy().Foreground(lipgloss.Color("2")).Render("🟢 Доступен")
@@ -96,7 +100,7 @@ var marketplaceCmd = &cobra.Command{
 			}
 
 			// Тип
-			agentType := agent.Type
+			agentType := string(agent.Type)
 			switch agentType {
 			case "AGENT_PREDEFINED_TYPE_FREE_TIER":
 				agentType = typeStyle.Copy().Foreground(lipgloss.Color("2")).Render("🆓 Бесплатный")
@@ -108,8 +112,8 @@ var marketplaceCmd = &cobra.Command{
 				agentType = typeStyle.Copy().Foreground(lipgloss.Color("8")).Render("⚪ " + agentType)
 			}
 
-			// Категории и теги
-			categories := strings.Join(agent.Categories, ", ")
+			// Категория и теги
+			categories := agent.Category
 			if len(categories) > 30 {
 				categories = categories[:30] + "..."
 			}
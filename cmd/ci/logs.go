@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -102,12 +103,12 @@ func showMCPServerLogs(ctx context.Context, serverID string) {
 		log.Fatal("Failed to get API client", "error", err)
 	}
 
-	_, err = apiClient.MCPServers.GetHistory(ctx, serverID)
+	history, err := apiClient.MCPServers.GetHistory(ctx, serverID)
 	if err != nil {
 		log.Fatal("Failed to get MCP server history", "error", err, "server_id", serverID)
 	}
 
-	// printLogs(history.Data) // TODO: исправить типы
+	printLogs(mcpServerLogs(history))
 }
 
 func showAgentLogs(ctx context.Context, agentID string) {
@@ -117,12 +118,12 @@ func showAgentLogs(ctx context.Context, agentID string) {
 		log.Fatal("Failed to get API client", "error", err)
 	}
 
-	_, err = apiClient.Agents.GetHistory(ctx, agentID)
+	history, err := apiClient.Agents.GetHistory(ctx, agentID)
 	if err != nil {
 		log.Fatal("Failed to get agent history", "error", err, "agent_id", agentID)
 	}
 
-	// printLogs(history.Data) // TODO: исправить типы
+	printLogs(agentLogs(history))
 }
 
 func showAgentSystemLogs(ctx context.Context, systemID string) {
@@ -132,12 +133,12 @@ func showAgentSystemLogs(ctx context.Context, systemID string) {
 		log.Fatal("Failed to get API client", "error", err)
 	}
 
-	_, err = apiClient.AgentSystems.GetHistory(ctx, systemID, 100, 0)
+	history, err := apiClient.AgentSystems.GetHistory(ctx, systemID, 100, 0)
 	if err != nil {
 		log.Fatal("Failed to get agent system history", "error", err, "system_id", systemID)
 	}
 
-	// printLogs(history.Data) // TODO: исправить типы
+	printLogs(agentSystemLogs(history))
 }
 
 func showSystemLogs(ctx context.Context) {
@@ -148,45 +149,45 @@ func showSystemLogs(ctx context.Context) {
 	}
 
 	// Получаем логи всех ресурсов
-	servers, err := apiClient.MCPServers.List(ctx, 50, 0)
+	servers, err := api.Collect(apiClient.MCPServers.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list MCP servers", "error", err)
 	}
 
-	agents, err := apiClient.Agents.List(ctx, 50, 0)
+	agents, err := api.Collect(apiClient.Agents.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list agents", "error", err)
 	}
 
-	systems, err := apiClient.AgentSystems.List(ctx, 50, 0)
+	systems, err := api.Collect(apiClient.AgentSystems.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list agent systems", "error", err)
 	}
 
 	// Собираем все логи
-	var allLogs []api.HistoryEntry
+	var allLogs []logEntry
 
 	// Логи MCP серверов
-	for _, server := range servers.Data {
+	for _, server := range servers {
 		history, err := apiClient.MCPServers.GetHistory(ctx, server.ID)
 		if err == nil {
-			allLogs = append(allLogs, history.Data...)
+			allLogs = append(allLogs, mcpServerLogs(history)...)
 		}
 	}
 
 	// Логи агентов
-	for _, agent := range agents.Data {
+	for _, agent := range agents {
 		history, err := apiClient.Agents.GetHistory(ctx, agent.ID)
 		if err == nil {
-			allLogs = append(allLogs, history.Data...)
+			allLogs = append(allLogs, agentLogs(history)...)
 		}
 	}
 
 	// Логи систем
-	for _, system := range systems.Data {
-		_, err := apiClient.AgentSystems.GetHistory(ctx, system.ID, 100, 0)
+	for _, system := range systems {
+		history, err := apiClient.AgentSystems.GetHistory(ctx, system.ID, 100, 0)
 		if err == nil {
-			// allLogs = append(allLogs, history.Data...) // TODO: исправить типы
+			allLogs = append(allLogs, agentSystemLogs(history)...)
 		}
 	}
 
@@ -201,7 +202,61 @@ func showSystemLogs(ctx context.Context) {
 	printLogs(allLogs)
 }
 
-func printLogs(logs []api.HistoryEntry) {
+// logEntry - событие истории ресурса в общем для всех типов ресурсов виде
+type logEntry struct {
+	Time   time.Time
+	Name   string
+	Event  api.HistoryEventType
+	Status string
+	Reason string
+}
+
+// mcpServerLogs преобразует историю MCP сервера в записи логов
+func mcpServerLogs(history *api.SearchMCPServerHistoryResponse) []logEntry {
+	logs := make([]logEntry, 0, len(history.Data))
+	for _, event := range history.Data {
+		logs = append(logs, logEntry{
+			Time:   event.Version.Time,
+			Name:   event.After.Name,
+			Event:  event.EventType,
+			Status: string(event.After.Status),
+			Reason: event.After.StatusReason.Message,
+		})
+	}
+	return logs
+}
+
+// agentLogs преобразует историю агента в записи логов
+func agentLogs(history *api.SearchAgentHistoryResponse) []logEntry {
+	logs := make([]logEntry, 0, len(history.Data))
+	for _, event := range history.Data {
+		logs = append(logs, logEntry{
+			Time:   event.Version.Time,
+			Name:   event.After.Name,
+			Event:  event.EventType,
+			Status: string(event.After.Status),
+			Reason: event.After.StatusReason.Message,
+		})
+	}
+	return logs
+}
+
+// agentSystemLogs преобразует историю системы агентов в записи логов
+func agentSystemLogs(history *api.SearchAgentSystemHistoryResponse) []logEntry {
+	logs := make([]logEntry, 0, len(history.Data))
+	for _, event := range history.Data {
+		logs = append(logs, logEntry{
+			Time:   event.Version.Time,
+			Name:   event.After.Name,
+			Event:  event.EventType,
+			Status: string(event.After.Status),
+			Reason: event.After.StatusReason.Message,
+		})
+	}
+	return logs
+}
+
+func printLogs(logs []logEntry) {
 	if len(logs) == 0 {
 		fmt.Println("🔍 Логи не найдены")
 		return
@@ -222,26 +277,29 @@ func printLogs(logs []api.HistoryEntry) {
 	// Выводим логи
 	for _, entry := range logs {
 		// Время
-		timeStr := timeStyle.Render(entry.CreatedAt.Format("15:04:05"))
+		timeStr := timeStyle.Render(entry.Time.Format("15:04:05"))
 
-		// Действие
-		actionStr := actionStyle.Render(entry.Action)
+		// Событие
+		actionStr := actionStyle.Render(strings.TrimPrefix(string(entry.Event), "HISTORY_EVENT_TYPE_"))
 
-		// Статус
+		// Статус после события
 		var statusStr string
-		switch entry.Status {
-		case "SUCCESS":
+		switch {
+		case strings.HasSuffix(entry.Status, "_RUNNING"), strings.HasSuffix(entry.Status, "_AVAILABLE"):
 			statusStr = statusStyle.Copy().Foreground(lipgloss.Color("2")).Render("✅")
-		case "ERROR":
+		case strings.HasSuffix(entry.Status, "_FAILED"), strings.HasSuffix(entry.Status, "_UNAVAILABLE"):
 			statusStr = statusStyle.Copy().Foreground(lipgloss.Color("1")).Render("❌")
-		case "PENDING":
+		case strings.HasSuffix(entry.Status, "_PULLING"), strings.HasSuffix(entry.Status, "_RESOURCE_ALLOCATION"):
 			statusStr = statusStyle.Copy().Foreground(lipgloss.Color("3")).Render("⏳")
 		default:
 			statusStr = statusStyle.Copy().Foreground(lipgloss.Color("8")).Render("⚪")
 		}
 
-		// Сообщение
-		messageStr := entry.Message
+		// Ресурс и причина статуса
+		messageStr := entry.Name
+		if entry.Reason != "" {
+			messageStr += ": " + entry.Reason
+		}
 		if len(messageStr) > 100 {
 			messageStr = messageStr[:100] + "..."
 		}
@@ -281,11 +339,11 @@ func monitorLogs(ctx context.Context) {
 	}
 }
 
-func sortLogsByTime(logs []api.HistoryEntry) {
+func sortLogsByTime(logs []logEntry) {
 	// Простая сортировка пузырьком по времени (новые сначала)
 	for i := 0; i < len(logs)-1; i++ {
 		for j := 0; j < len(logs)-i-1; j++ {
-			if logs[j].CreatedAt.Before(logs[j+1].CreatedAt) {
+			if logs[j].Time.Before(logs[j+1].Time) {
 				logs[j], logs[j+1] = logs[j+1], logs[j]
 			}
 		}
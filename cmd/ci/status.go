@@ -9,6 +9,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
 	"github.com/spf13/cobra"
 )
@@ -79,7 +80,7 @@ func checkMCPServerStatus(ctx context.Context, serverID string) {
 		log.Fatal("Failed to get MCP server", "error", err, "server_id", serverID)
 	}
 
-	printResourceStatus("MCP Server", serverID, server.Status, server.UpdatedAt.Time)
+	printResourceStatus("MCP Server", serverID, string(server.Status), server.UpdatedAt.Time)
 }
 
 func checkAgentStatus(ctx context.Context, agentID string) {
@@ -94,7 +95,7 @@ func checkAgentStatus(ctx context.Context, agentID string) {
 		log.Fatal("Failed to get agent", "error", err, "agent_id", agentID)
 	}
 
-	printResourceStatus("Agent", agentID, agent.Status, agent.UpdatedAt.Time)
+	printResourceStatus("Agent", agentID, string(agent.Status), agent.UpdatedAt.Time)
 }
 
 func checkAgentSystemStatus(ctx context.Context, systemID string) {
@@ -109,7 +110,7 @@ func checkAgentSystemStatus(ctx context.Context, systemID string) {
 		log.Fatal("Failed to get agent system", "error", err, "system_id", systemID)
 	}
 
-	printResourceStatus("Agent System", systemID, system.Status, system.UpdatedAt)
+	printResourceStatus("Agent System", systemID, string(system.Status), system.UpdatedAt.Time)
 }
 
 func checkAllMCPServersStatus(ctx context.Context) {
@@ -119,12 +120,12 @@ func checkAllMCPServersStatus(ctx context.Context) {
 		log.Fatal("Failed to get API client", "error", err)
 	}
 
-	servers, err := apiClient.MCPServers.List(ctx, 100, 0)
+	servers, err := api.Collect(apiClient.MCPServers.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list MCP servers", "error", err)
 	}
 
-	printResourcesStatus("MCP Servers", servers.Data)
+	printResourcesStatus("MCP Servers", servers)
 }
 
 func checkAllAgentsStatus(ctx context.Context) {
@@ -134,12 +135,12 @@ func checkAllAgentsStatus(ctx context.Context) {
 		log.Fatal("Failed to get API client", "error", err)
 	}
 
-	agents, err := apiClient.Agents.List(ctx, 100, 0)
+	agents, err := api.Collect(apiClient.Agents.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list agents", "error", err)
 	}
 
-	printResourcesStatus("Agents", agents.Data)
+	printResourcesStatus("Agents", agents)
 }
 
 func checkAllAgentSystemsStatus(ctx context.Context) {
@@ -149,12 +150,12 @@ func checkAllAgentSystemsStatus(ctx context.Context) {
 		log.Fatal("Failed to get API client", "error", err)
 	}
 
-	systems, err := apiClient.AgentSystems.List(ctx, 100, 0)
+	systems, err := api.Collect(apiClient.AgentSystems.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list agent systems", "error", err)
 	}
 
-	printResourcesStatus("Agent Systems", systems.Data)
+	printResourcesStatus("Agent Systems", systems)
 }
 
 func checkOverallStatus(ctx context.Context) {
@@ -165,17 +166,17 @@ func checkOverallStatus(ctx context.Context) {
 	}
 
 	// Получаем статус всех ресурсов
-	servers, err := apiClient.MCPServers.List(ctx, 100, 0)
+	servers, err := api.Collect(apiClient.MCPServers.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list MCP servers", "error", err)
 	}
 
-	agents, err := apiClient.Agents.List(ctx, 100, 0)
+	agents, err := api.Collect(apiClient.Agents.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list agents", "error", err)
 	}
 
-	systems, err := apiClient.AgentSystems.List(ctx, 100, 0)
+	systems, err := api.Collect(apiClient.AgentSystems.All(ctx, nil))
 	if err != nil {
 		log.Fatal("Failed to list agent systems", "error", err)
 	}
@@ -194,7 +195,7 @@ func checkOverallStatus(ctx context.Context) {
 	// Статистика MCP серверов
 	activeServers := 0
 	errorServers := 0
-	for _, server := range servers.Data {
+	for _, server := range servers {
 		switch server.Status {
 		case "ACTIVE":
 			activeServers++
@@ -206,7 +207,7 @@ func checkOverallStatus(ctx context.Context) {
 	// Статистика агентов
 	activeAgents := 0
 	errorAgents := 0
-	for _, agent := range agents.Data {
+	for _, agent := range agents {
 		switch agent.Status {
 		case "ACTIVE":
 			activeAgents++
@@ -218,7 +219,7 @@ func checkOverallStatus(ctx context.Context) {
 	// Статистика систем
 	activeSystems := 0
 	errorSystems := 0
-	for _, system := range systems.Data {
+	for _, system := range systems {
 		switch system.Status {
 		case "ACTIVE":
 			activeSystems++
@@ -240,7 +241,7 @@ func checkOverallStatus(ctx context.Context) {
 		status = "⚪ NO DATA"
 	}
 	fmt.Fprintf(w, "MCP Servers\t%d\t%d\t%d\t%s\n",
-		len(servers.Data), activeServers, errorServers, status)
+		len(servers), activeServers, errorServers, status)
 
 	// Агенты
 	status = "🟢 OK"
@@ -250,7 +251,7 @@ func checkOverallStatus(ctx context.Context) {
 		status = "⚪ NO DATA"
 	}
 	fmt.Fprintf(w, "Agents\t%d\t%d\t%d\t%s\n",
-		len(agents.Data), activeAgents, errorAgents, status)
+		len(agents), activeAgents, errorAgents, status)
 
 	// Системы
 	status = "🟢 OK"
@@ -260,7 +261,7 @@ func checkOverallStatus(ctx context.Context) {
 		status = "⚪ NO DATA"
 	}
 	fmt.Fprintf(w, "Agent Systems\t%d\t%d\t%d\t%s\n",
-		len(systems.Data), activeSystems, errorSystems, status)
+		len(systems), activeSystems, errorSystems, status)
 
 	w.Flush()
 
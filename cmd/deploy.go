@@ -2,48 +2,100 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var (
 	deployFile         string
 	deployDryRun       bool
 	deployValidateOnly bool
+	deployPlan         bool
+	deployWait         bool
+	deployAtomic       bool
+	deployWaitTimeout  time.Duration
+	deployStateFile    string
+	deployPrune        bool
+	deployPrunePrefix  string
+	deployProtected    []string
+	deployParallel     int
+	deployFailFast     bool
+	deployEnv          string
 )
 
+// defaultConfigFiles - файлы конфигурации, которые ищутся, если файл не указан
+var defaultConfigFiles = []string{
+	"ai-agents.yaml",
+	"ai-agents.yml",
+	"deploy.yaml",
+	"deploy.yml",
+	"config.yaml",
+	"config.yml",
+}
+
 // deployCmd represents the deploy command
 var deployCmd = &cobra.Command{
 	Use:   "deploy [config-file]",
 	Short: "Универсальное развертывание из YAML конфигурации",
 	Long: `Универсальное развертывание всех типов ресурсов из YAML конфигурации.
 
-Команда автоматически определяет тип конфигурации и развертывает ресурсы в правильном порядке:
-1. MCP серверы
-2. Агенты (с привязкой к MCP серверам)
-3. Системы агентов (с привязкой к агентам)
+Команда автоматически определяет тип конфигурации и развертывает ресурсы в порядке
+зависимостей между ними:
+• MCP серверы - до агентов, которые ссылаются на них в mcpServers
+• Агенты - до систем агентов, в которые они входят
+
+Ссылки задаются по имени и разрешаются в ID, в том числе для ресурсов,
+созданных в этом же запуске. Циклические зависимости и ссылки на
+несуществующие ресурсы приводят к ошибке до начала развертывания.
 
 Поддерживает:
 • Включения других файлов через !include
-• Валидации конфигурации по JSON схемам
+• Оверлеев окружений overlays/<env>.yaml поверх базовой конфигурации (--env)
+• Валидации конфигурации по JSON схемам и семантическим правилам
 • Автоматическое разрешение зависимостей
 • Режим предварительного просмотра (dry-run)
+• План изменений относительно текущего состояния проекта (--plan)
+• Атомарного развертывания с откатом при ошибке (--atomic)
+• Файла состояния с ID развернутых ресурсов (--state, по умолчанию .ai-agents/state.json)
+• Удаления ресурсов, исключенных из конфигурации (--prune)
+• Параллельного развертывания независимых ресурсов (--parallel, по умолчанию
+  BULK_OPERATIONS_CONCURRENCY); ошибка одного ресурса не прерывает развертывание
+  остальных, если не указан --fail-fast
 • Только валидации без развертывания
 
 Примеры использования:
   ai-agents-cli deploy config.yaml
   ai-agents-cli deploy --file config.yaml --dry-run
+  ai-agents-cli deploy config.yaml --plan
+  ai-agents-cli deploy ai-agents.yaml --env prod
+  ai-agents-cli deploy config.yaml --wait --timeout 15m
+  ai-agents-cli deploy config.yaml --atomic
+  ai-agents-cli deploy config.yaml --parallel 5 --fail-fast
+  ai-agents-cli deploy config.yaml --prune --protect shared-mcp
+  ai-agents-cli deploy config.yaml --prune --prune-prefix shop- --dry-run
   ai-agents-cli deploy --validate-only`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		// Ctrl-C отменяет развертывание: ресурсы, которые еще не начали
+		// развертываться, пропускаются, состояние и откат сохраняются
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
 		// Определяем файл конфигурации
 		configFile := deployFile
@@ -53,16 +105,7 @@ var deployCmd = &cobra.Command{
 
 		if configFile == "" {
 			// Ищем файл конфигурации по умолчанию
-			defaultFiles := []string{
-				"ai-agents.yaml",
-				"ai-agents.yml",
-				"deploy.yaml",
-				"deploy.yml",
-				"config.yaml",
-				"config.yml",
-			}
-
-			for _, file := range defaultFiles {
+			for _, file := range defaultConfigFiles {
 				if _, err := os.Stat(file); err == nil {
 					configFile = file
 					fmt.Printf("📁 Using configuration file: %s\n", file)
@@ -72,7 +115,7 @@ var deployCmd = &cobra.Command{
 
 			if configFile == "" {
 				fmt.Println("❌ No configuration file found. Looking for:")
-				for _, file := range defaultFiles {
+				for _, file := range defaultConfigFiles {
 					fmt.Printf("   - %s\n", file)
 				}
 				fmt.Println("\n💡 Create one of these files or specify with: ai-agents-cli deploy <file>")
@@ -87,123 +130,262 @@ var deployCmd = &cobra.Command{
 		log.Fatal("Failed to get API client", "error", err)
 	}
 
-		// Создаем деплойеры
-		mcpDeployer := deployer.NewMCPDeployer(apiClient)
-		agentDeployer := deployer.NewAgentDeployer(apiClient)
-		systemDeployer := deployer.NewSystemDeployer(apiClient)
-
-		// Валидация конфигурации
+		// Валидация итоговой конфигурации после includes и оверлея окружения
 		fmt.Println(ui.FormatInfo("Validating configuration..."))
+		processedConfig, err := loadDeployConfig(configFile, deployEnv)
+		if err != nil {
+			log.Error("Configuration validation failed", "env", deployEnv, "error", err)
+			// Нарушения семантических правил уже выведены с фрагментами файлов
+			var semanticErr *validator.SemanticError
+			if errors.As(err, &semanticErr) {
+				fmt.Println(ui.FormatError("Configuration violates semantic rules, see the errors above"))
+			} else {
+				fmt.Println(ui.CheckAndDisplayError(err))
+			}
+			os.Exit(1)
+		}
+
+		fmt.Println(ui.FormatSuccess("Configuration is valid"))
+
+		if deployValidateOnly {
+			fmt.Println(ui.FormatInfo("Validation completed successfully"))
+			return
+		}
 
-		// Определяем тип конфигурации и валидируем
-		configType, err := detectConfigType(configFile)
+		// Строим типизированную модель; устаревшие ключи переносятся с предупреждением
+		m, warnings, err := manifest.FromMap(processedConfig.Config)
+		for _, warning := range warnings {
+			fmt.Println(ui.FormatWarning(warning))
+		}
 		if err != nil {
-			log.Error("Failed to detect configuration type", "error", err)
+			log.Error("Failed to decode configuration", "error", err)
 			fmt.Println(ui.CheckAndDisplayError(err))
 			return
 		}
 
-		// Валидируем в зависимости от типа
-		switch configType {
-		case "mcp":
-			if err := mcpDeployer.ValidateMCPServers(configFile); err != nil {
-				log.Error("MCP configuration validation failed", "error", err)
-				fmt.Println(ui.CheckAndDisplayError(err))
-				return
-			}
-		case "agent":
-			if err := agentDeployer.ValidateAgents(configFile); err != nil {
-				log.Error("Agent configuration validation failed", "error", err)
-				fmt.Println(ui.CheckAndDisplayError(err))
-				return
-			}
-		case "system":
-			if err := systemDeployer.ValidateSystems(configFile); err != nil {
-				log.Error("System configuration validation failed", "error", err)
+		// Файл состояния связывает ресурсы конфигурации с их ID в проекте
+		st, err := state.Load(stateFilePath(cmd, deployStateFile, deployEnv))
+		if err != nil {
+			log.Error("Failed to load state", "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
+			return
+		}
+
+		if deployPlan {
+			fmt.Println(ui.FormatInfo("Comparing configuration with the project..."))
+			plan, err := deployer.BuildPlan(ctx, apiClient, m, st)
+			if err != nil {
+				log.Error("Failed to build plan", "error", err)
 				fmt.Println(ui.CheckAndDisplayError(err))
 				return
 			}
-		case "mixed":
-			// Для смешанных конфигураций валидируем все секции
-			if err := mcpDeployer.ValidateMCPServers(configFile); err != nil {
-				log.Warn("MCP section validation failed", "error", err)
-			}
-			if err := agentDeployer.ValidateAgents(configFile); err != nil {
-				log.Warn("Agent section validation failed", "error", err)
+
+			fmt.Println()
+			fmt.Print(plan.Render())
+			if !plan.HasChanges() {
+				fmt.Println(ui.FormatSuccess("No changes. The project matches the configuration."))
 			}
-			if err := systemDeployer.ValidateSystems(configFile); err != nil {
-				log.Warn("System section validation failed", "error", err)
+			if deployPrune {
+				showPrunePlan(ctx, apiClient, m, st)
 			}
+			return
 		}
 
-		fmt.Println(ui.FormatSuccess("Configuration is valid"))
+		// Развертывание в порядке зависимостей между ресурсами
+		fmt.Println(ui.FormatInfo("Starting deployment..."))
 
-		if deployValidateOnly {
-			fmt.Println(ui.FormatInfo("Validation completed successfully"))
+		orchestrator := deployer.NewOrchestrator(apiClient)
+		allResults, err := orchestrator.Deploy(ctx, m, deployer.DeployOptions{
+			DryRun:     deployDryRun,
+			Atomic:     deployAtomic,
+			ProjectDir: filepath.Dir(configFile),
+			State:      st,
+			Parallel:   deployParallelOptions(container),
+		})
+		if err != nil {
+			log.Error("Deployment failed", "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
 			return
 		}
 
-		// Развертывание в правильном порядке
-		fmt.Println(ui.FormatInfo("Starting deployment..."))
+		// Ошибки ресурсов указывают на их определения в исходных файлах
+		deployer.AnnotateSources(allResults, processedConfig.Sources)
 
-		var allResults []deployer.DeployResult
+		// Показываем общие результаты
+		fmt.Println(ui.FormatInfo("Deployment completed!"))
+		deployer.ShowDeployResults(allResults)
 
-		// 1. Развертываем MCP серверы
-		if configType == "mcp" || configType == "mixed" {
-			fmt.Println(ui.FormatInfo("Deploying MCP servers..."))
-			mcpResults, err := mcpDeployer.DeployMCPServers(ctx, configFile, deployDryRun)
-			if err != nil {
-				log.Error("MCP deployment failed", "error", err)
-				fmt.Println(ui.FormatError("MCP deployment failed: " + err.Error()))
-			} else {
-				allResults = append(allResults, mcpResults...)
+		// Удаляем ресурсы, исключенные из конфигурации, после создания и обновления
+		pruneFailed := false
+		if deployPrune {
+			switch {
+			case ctx.Err() != nil:
+				fmt.Println(ui.FormatWarning("Skipping prune: deployment was interrupted"))
+			case hasDeployFailures(allResults):
+				fmt.Println(ui.FormatWarning("Skipping prune: deployment finished with errors"))
+			case deployDryRun:
+				showPrunePlan(ctx, apiClient, m, st)
+			default:
+				pruneFailed = !pruneResources(ctx, apiClient, m, st)
 			}
 		}
 
-		// 2. Развертываем агентов
-		if configType == "agent" || configType == "mixed" {
-			fmt.Println(ui.FormatInfo("Deploying agents..."))
-			agentResults, err := agentDeployer.DeployAgents(ctx, configFile, deployDryRun, false)
-			if err != nil {
-				log.Error("Agent deployment failed", "error", err)
-				fmt.Println(ui.FormatError("Agent deployment failed: " + err.Error()))
-			} else {
-				allResults = append(allResults, agentResults...)
+		if !deployDryRun {
+			if err := st.Save(); err != nil {
+				log.Error("Failed to save state", "error", err)
+				fmt.Println(ui.FormatWarning(fmt.Sprintf("Failed to save state to %s: %v", st.Path(), err)))
 			}
 		}
 
-		// 3. Развертываем системы агентов
-		if configType == "system" || configType == "mixed" {
-			fmt.Println(ui.FormatInfo("Deploying agent systems..."))
-			systemResults, err := systemDeployer.DeploySystems(ctx, configFile, deployDryRun)
-			if err != nil {
-				log.Error("System deployment failed", "error", err)
-				fmt.Println(ui.FormatError("System deployment failed: " + err.Error()))
-			} else {
-				allResults = append(allResults, systemResults...)
+		if ctx.Err() != nil {
+			fmt.Println(ui.FormatError("Deployment was interrupted"))
+			os.Exit(1)
+		}
+
+		// Атомарное развертывание откачено - ресурсы не ожидаем
+		if deployer.RolledBack(allResults) {
+			fmt.Println(ui.FormatError("Deployment failed and was rolled back"))
+			os.Exit(1)
+		}
+
+		// Ожидаем запуска развернутых ресурсов
+		if deployWait && !deployDryRun {
+			fmt.Println(ui.FormatInfo("Waiting for resources to become ready..."))
+			waitResults := deployer.NewWaiter(apiClient).Wait(ctx, allResults, deployer.WaitOptions{Timeout: deployWaitTimeout})
+			deployer.ShowWaitResults(os.Stdout, waitResults)
+			if deployer.HasWaitFailures(waitResults) {
+				os.Exit(1)
 			}
 		}
 
-		// Показываем общие результаты
-		fmt.Println(ui.FormatInfo("Deployment completed!"))
-		deployer.ShowDeployResults(allResults)
+		if pruneFailed {
+			os.Exit(1)
+		}
 	},
 }
 
-// detectConfigType определяет тип конфигурации
-func detectConfigType(configFile string) (string, error) {
-	// Читаем файл
-	data, err := os.ReadFile(configFile)
+// stateFilePath возвращает путь к файлу состояния: значение --state, а если
+// флаг не задан - файл состояния окружения
+func stateFilePath(cmd *cobra.Command, path, env string) string {
+	if cmd.Flags().Changed("state") {
+		return path
+	}
+	return state.PathForEnv(env)
+}
+
+// deployParallelOptions возвращает параметры параллельного развертывания:
+// --parallel, а если флаг не задан - BULK_OPERATIONS_CONCURRENCY
+func deployParallelOptions(container *di.Container) deployer.ParallelOptions {
+	return deployer.ResolveParallelOptions(deployParallel, deployFailFast, container.GetConfig)
+}
+
+// pruneOptions возвращает параметры удаления ресурсов из флагов deploy
+func pruneOptions(st *state.State) deployer.PruneOptions {
+	return deployer.PruneOptions{
+		State:     st,
+		Prefix:    deployPrunePrefix,
+		Protected: deployProtected,
+	}
+}
+
+// showPrunePlan выводит отдельным списком ресурсы, которые будут удалены с --prune
+func showPrunePlan(ctx context.Context, apiClient *api.API, m *manifest.Manifest, st *state.State) {
+	plan, err := deployer.BuildPrunePlan(ctx, apiClient, m, pruneOptions(st))
 	if err != nil {
-		return "", fmt.Errorf("failed to read config file: %w", err)
+		log.Error("Failed to build prune plan", "error", err)
+		fmt.Println(ui.CheckAndDisplayError(err))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.FormatInfo("Resources to prune:"))
+	showProtected(plan)
+	if !plan.HasChanges() {
+		fmt.Println(ui.FormatSuccess("Nothing to prune."))
+		return
 	}
+	fmt.Print(plan.Render())
+}
 
-	// Парсим YAML
-	var config map[string]interface{}
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return "", fmt.Errorf("failed to parse YAML: %w", err)
+// pruneResources удаляет ресурсы, исключенные из конфигурации, и сообщает,
+// удалось ли удалить все ресурсы. Удаление каждой группы ожидается не дольше
+// --timeout, иначе следующая группа может упереться в еще не удаленные
+// ссылающиеся на нее ресурсы
+func pruneResources(ctx context.Context, apiClient *api.API, m *manifest.Manifest, st *state.State) bool {
+	fmt.Println(ui.FormatInfo("Looking up resources to prune..."))
+	plan, err := deployer.BuildPrunePlan(ctx, apiClient, m, pruneOptions(st))
+	if err != nil {
+		log.Error("Failed to build prune plan", "error", err)
+		fmt.Println(ui.CheckAndDisplayError(err))
+		return false
 	}
 
+	showProtected(plan)
+	if !plan.HasChanges() {
+		fmt.Println(ui.FormatSuccess("Nothing to prune."))
+		return true
+	}
+
+	results := deployer.NewDestroyer(apiClient).Destroy(ctx, &plan.Plan, deployer.DestroyOptions{
+		Wait:    true,
+		Timeout: deployWaitTimeout,
+	})
+	deployer.ForgetDeleted(st, results)
+	deployer.ShowDestroyResults(results)
+	return !hasDeployFailures(results)
+}
+
+// showProtected выводит защищенные ресурсы, которые не будут удалены
+func showProtected(plan *deployer.PrunePlan) {
+	for _, item := range plan.Protected {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("%s %s is protected and will not be pruned", item.Kind, item.Name)))
+	}
+}
+
+// hasDeployFailures проверяет, есть ли среди результатов ошибки
+func hasDeployFailures(results []deployer.DeployResult) bool {
+	for _, result := range results {
+		if !result.Success {
+			return true
+		}
+	}
+	return false
+}
+
+// loadDeployConfig обрабатывает файл конфигурации с includes, накладывает
+// оверлей окружения и проверяет итоговый документ по схеме и семантическим
+// правилам, как validate. Нарушения правил выводятся, ошибки правил
+// возвращаются как *validator.SemanticError.
+func loadDeployConfig(configFile, env string) (*parser.Document, error) {
+	doc, err := parser.ProcessYAMLFileWithOverlay(configFile, env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process configuration: %w", err)
+	}
+
+	// Секции ищутся в итоговом документе: корневой файл может состоять только из includes
+	configType, err := detectConfigType(doc.Config)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Configuration type detected", "file", configFile, "type", configType)
+
+	if err := validator.ValidateDocument(doc); err != nil {
+		return nil, err
+	}
+
+	findings, err := validator.CheckSemantics(doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	printFindings(findings)
+	if err := validator.FindingsError(findings); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// detectConfigType определяет тип итоговой конфигурации по ее секциям
+func detectConfigType(config map[string]interface{}) (string, error) {
 	// Определяем тип по наличию секций
 	hasMCP := config["mcp-servers"] != nil
 	hasAgents := config["agents"] != nil
@@ -245,4 +427,15 @@ func init() {
 	deployCmd.Flags().StringVarP(&deployFile, "file", "f", "", "Путь к файлу конфигурации")
 	deployCmd.Flags().BoolVarP(&deployDryRun, "dry-run", "d", false, "Режим предварительного просмотра без создания ресурсов")
 	deployCmd.Flags().BoolVar(&deployValidateOnly, "validate-only", false, "Только валидация конфигурации без развертывания")
+	deployCmd.Flags().BoolVar(&deployPlan, "plan", false, "Показать план изменений относительно проекта без развертывания")
+	deployCmd.Flags().BoolVar(&deployAtomic, "atomic", false, "Откатить все изменения, если развертывание любого ресурса завершилось ошибкой")
+	deployCmd.Flags().BoolVar(&deployWait, "wait", false, "Ожидать запуска развернутых ресурсов")
+	deployCmd.Flags().DurationVar(&deployWaitTimeout, "timeout", deployer.DefaultWaitTimeout, "Максимальное время ожидания запуска или удаления (--prune) каждого ресурса")
+	deployCmd.Flags().StringVar(&deployStateFile, "state", state.DefaultPath, "Путь к файлу состояния развертывания (для --env - .ai-agents/state.<env>.json)")
+	deployCmd.Flags().StringVar(&deployEnv, "env", "", "Окружение: наложить оверлей overlays/<env>.yaml на конфигурацию")
+	deployCmd.Flags().IntVar(&deployParallel, "parallel", 0, "Максимальное количество ресурсов, развертываемых одновременно (по умолчанию BULK_OPERATIONS_CONCURRENCY)")
+	deployCmd.Flags().BoolVar(&deployFailFast, "fail-fast", false, "Не начинать развертывание остальных ресурсов после первой ошибки (начатые завершаются)")
+	deployCmd.Flags().BoolVar(&deployPrune, "prune", false, "Удалить ресурсы, которые принадлежали конфигурации, но исключены из нее")
+	deployCmd.Flags().StringVar(&deployPrunePrefix, "prune-prefix", "", "Считать ресурсы проекта с этим префиксом имени принадлежащими конфигурации при --prune")
+	deployCmd.Flags().StringSliceVar(&deployProtected, "protect", nil, "Ресурсы, которые не удаляются при --prune (имя или <kind>/<имя>)")
 }
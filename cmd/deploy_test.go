@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
+)
+
+func TestLoadDeployConfig_IncludesOnly(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"ai-agents.yaml":        "includes:\n  - mcp-servers/*.yaml\n  - agents/*.yaml\n",
+		"mcp-servers/tool.yaml": "mcp-servers:\n  - name: tools\n",
+		"agents/support.yaml":   "agents:\n  - name: support\n    mcpServers:\n      - tools\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	doc, err := loadDeployConfig(filepath.Join(dir, "ai-agents.yaml"), "")
+	if err != nil {
+		t.Fatalf("loadDeployConfig() error = %v", err)
+	}
+	if _, ok := doc.Config["agents"]; !ok {
+		t.Errorf("agents from includes are missing: %v", doc.Config)
+	}
+
+	if _, err := loadDeployConfig(filepath.Join("..", "examples", "ai-agents.yaml"), ""); err != nil {
+		t.Errorf("loadDeployConfig(examples/ai-agents.yaml) error = %v", err)
+	}
+}
+
+func TestLoadDeployConfig_SemanticErrors(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "ai-agents.yaml")
+	content := "mcp-servers:\n  - name: tools\n    options:\n      timeout: 30\n"
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := loadDeployConfig(configFile, "")
+	var semanticErr *validator.SemanticError
+	if !errors.As(err, &semanticErr) {
+		t.Fatalf("loadDeployConfig() error = %v, want *validator.SemanticError", err)
+	}
+	if len(semanticErr.Findings) != 1 || semanticErr.Findings[0].Rule != validator.RuleUnsupportedOption {
+		t.Errorf("findings = %v, want %s", semanticErr.Findings, validator.RuleUnsupportedOption.ID)
+	}
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	destroyFile        string
+	destroyYes         bool
+	destroyWait        bool
+	destroyWaitTimeout time.Duration
+	destroyEnv         string
+	destroyStateFile   string
+)
+
+// destroyCmd represents the destroy command
+var destroyCmd = &cobra.Command{
+	Use:   "destroy [config-file]",
+	Short: "Удаление всех ресурсов, описанных в YAML конфигурации",
+	Long: `Удаление систем агентов, агентов и MCP серверов, описанных в YAML конфигурации.
+
+Конфигурация обрабатывается так же, как в deploy (включая !include), ресурсы
+ищутся в проекте по ID из файла состояния, а если его там нет - по имени, и
+удаляются в порядке, обратном порядку развертывания: сначала системы агентов,
+затем агенты, затем MCP серверы. Ресурсы одного типа удаляются одним запросом
+массового удаления. Удаленные ресурсы убираются из файла состояния.
+
+Перед удалением выводится план и запрашивается подтверждение.
+
+Примеры использования:
+  ai-agents-cli destroy ai-agents.yaml
+  ai-agents-cli destroy ai-agents.yaml --yes
+  ai-agents-cli destroy ai-agents.yaml --env dev
+  ai-agents-cli destroy ai-agents.yaml --wait --timeout 5m`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		// Определяем файл конфигурации
+		configFile := destroyFile
+		if len(args) > 0 {
+			configFile = args[0]
+		}
+
+		if configFile == "" {
+			// Ищем файл конфигурации по умолчанию
+			for _, file := range defaultConfigFiles {
+				if _, err := os.Stat(file); err == nil {
+					configFile = file
+					fmt.Printf("📁 Using configuration file: %s\n", file)
+					break
+				}
+			}
+
+			if configFile == "" {
+				fmt.Println("❌ No configuration file found. Looking for:")
+				for _, file := range defaultConfigFiles {
+					fmt.Printf("   - %s\n", file)
+				}
+				fmt.Println("\n💡 Create one of these files or specify with: ai-agents-cli destroy <file>")
+				os.Exit(1)
+			}
+		}
+
+		// Получаем API клиент из DI контейнера
+		container := di.GetContainer()
+		apiClient, err := container.GetAPI()
+		if err != nil {
+			log.Fatal("Failed to get API client", "error", err)
+		}
+
+		processedConfig, err := parser.ProcessYAMLFileWithOverlay(configFile, destroyEnv)
+		if err != nil {
+			log.Error("Failed to process configuration", "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
+			return
+		}
+
+		m, warnings, err := manifest.FromMap(processedConfig.Config)
+		for _, warning := range warnings {
+			fmt.Println(ui.FormatWarning(warning))
+		}
+		if err != nil {
+			log.Error("Failed to decode configuration", "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
+			return
+		}
+
+		// Файл состояния связывает ресурсы конфигурации с их ID в проекте
+		st, err := state.Load(stateFilePath(cmd, destroyStateFile, destroyEnv))
+		if err != nil {
+			log.Error("Failed to load state", "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
+			return
+		}
+
+		fmt.Println(ui.FormatInfo("Looking up resources in the project..."))
+		plan, err := deployer.BuildDestroyPlan(ctx, apiClient, m, st)
+		if err != nil {
+			log.Error("Failed to build destroy plan", "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
+			return
+		}
+
+		if !plan.HasChanges() {
+			fmt.Println(ui.FormatSuccess("Nothing to destroy. None of the configured resources exist in the project."))
+			return
+		}
+
+		fmt.Println()
+		fmt.Print(plan.Render())
+
+		if !destroyYes {
+			// Спрашиваем подтверждение
+			fmt.Printf("Вы уверены, что хотите удалить %d ресурсов? (y/N): ", len(plan.Items))
+			var response string
+			fmt.Scanln(&response)
+
+			if response != "y" && response != "Y" && response != "yes" && response != "Yes" {
+				fmt.Println("❌ Операция отменена")
+				return
+			}
+		}
+
+		results := deployer.NewDestroyer(apiClient).Destroy(ctx, plan, deployer.DestroyOptions{
+			Wait:    destroyWait,
+			Timeout: destroyWaitTimeout,
+		})
+		deployer.ForgetDeleted(st, results)
+		deployer.ShowDestroyResults(results)
+
+		if err := st.Save(); err != nil {
+			log.Error("Failed to save state", "error", err)
+			fmt.Println(ui.FormatWarning(fmt.Sprintf("Failed to save state to %s: %v", st.Path(), err)))
+		}
+
+		for _, result := range results {
+			if !result.Success {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	RootCMD.AddCommand(destroyCmd)
+
+	destroyCmd.Flags().StringVarP(&destroyFile, "file", "f", "", "Путь к файлу конфигурации")
+	destroyCmd.Flags().BoolVarP(&destroyYes, "yes", "y", false, "Удалить без подтверждения")
+	destroyCmd.Flags().BoolVar(&destroyWait, "wait", false, "Ожидать завершения удаления ресурсов")
+	destroyCmd.Flags().StringVar(&destroyEnv, "env", "", "Окружение: наложить оверлей overlays/<env>.yaml на конфигурацию")
+	destroyCmd.Flags().StringVar(&destroyStateFile, "state", state.DefaultPath, "Путь к файлу состояния развертывания (для --env - .ai-agents/state.<env>.json)")
+	destroyCmd.Flags().DurationVar(&destroyWaitTimeout, "timeout", deployer.DefaultWaitTimeout, "Максимальное время ожидания удаления каждого ресурса")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Коды завершения команды drift
+const (
+	driftExitOK    = 0
+	driftExitError = 1
+	driftExitDrift = 2
+)
+
+var (
+	driftFormat    string
+	driftStateFile string
+	driftEnv       string
+)
+
+// driftCmd represents the drift command
+var driftCmd = &cobra.Command{
+	Use:   "drift <config-file>",
+	Short: "Проверка расхождений между YAML конфигурацией и проектом",
+	Long: `Сравнение YAML конфигурации (с учетом !include) с ресурсами проекта поле за полем.
+
+В отчет попадают:
+• Ресурсы, измененные в проекте (например, через консоль)
+• Ресурсы из конфигурации, которых нет в проекте
+• Ресурсы проекта, которых нет в конфигурации, но имя которых начинается
+  с того же префикса (до первого '-', '_' или '.'), что и у управляемых
+
+Команда предназначена для запуска в CI по расписанию. Коды завершения:
+  0 - расхождений нет
+  1 - ошибка проверки
+  2 - найдены расхождения
+
+Форматы вывода: text, json, junit.
+
+Примеры использования:
+  ai-agents-cli drift ai-agents.yaml
+  ai-agents-cli drift ai-agents.yaml --output json
+  ai-agents-cli drift ai-agents.yaml --env prod
+  ai-agents-cli drift ai-agents.yaml -o junit > drift.xml`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		configFile := args[0]
+
+		if driftFormat != "text" && driftFormat != "json" && driftFormat != "junit" {
+			fmt.Fprintln(os.Stderr, ui.FormatError(fmt.Sprintf("Unknown format '%s', expected one of: text, json, junit", driftFormat)))
+			os.Exit(driftExitError)
+		}
+
+		// Получаем API клиент из DI контейнера
+		container := di.GetContainer()
+		apiClient, err := container.GetAPI()
+		if err != nil {
+			log.Error("Failed to get API client", "error", err)
+			os.Exit(driftExitError)
+		}
+
+		processedConfig, err := parser.ProcessYAMLFileWithOverlay(configFile, driftEnv)
+		if err != nil {
+			log.Error("Failed to process configuration", "error", err)
+			fmt.Fprintln(os.Stderr, ui.CheckAndDisplayError(err))
+			os.Exit(driftExitError)
+		}
+
+		m, warnings, err := manifest.FromMap(processedConfig.Config)
+		// Предупреждения выводятся в stderr, чтобы не попасть в отчет
+		for _, warning := range warnings {
+			fmt.Fprintln(os.Stderr, ui.FormatWarning(warning))
+		}
+		if err != nil {
+			log.Error("Failed to decode configuration", "error", err)
+			fmt.Fprintln(os.Stderr, ui.CheckAndDisplayError(err))
+			os.Exit(driftExitError)
+		}
+
+		st, err := state.Load(stateFilePath(cmd, driftStateFile, driftEnv))
+		if err != nil {
+			log.Error("Failed to load state", "error", err)
+			fmt.Fprintln(os.Stderr, ui.CheckAndDisplayError(err))
+			os.Exit(driftExitError)
+		}
+
+		report, err := deployer.DetectDrift(ctx, apiClient, m, st)
+		if err != nil {
+			log.Error("Failed to detect drift", "error", err)
+			fmt.Fprintln(os.Stderr, ui.CheckAndDisplayError(err))
+			os.Exit(driftExitError)
+		}
+
+		switch driftFormat {
+		case "json":
+			err = report.WriteJSON(os.Stdout)
+		case "junit":
+			err = report.WriteJUnit(os.Stdout)
+		default:
+			fmt.Print(report.Render())
+		}
+		if err != nil {
+			log.Error("Failed to write drift report", "error", err)
+			os.Exit(driftExitError)
+		}
+
+		if report.HasDrift() {
+			os.Exit(driftExitDrift)
+		}
+		os.Exit(driftExitOK)
+	},
+}
+
+func init() {
+	RootCMD.AddCommand(driftCmd)
+
+	driftCmd.Flags().StringVarP(&driftFormat, "output", "o", "text", "Формат вывода (text, json, junit)")
+	driftCmd.Flags().StringVar(&driftStateFile, "state", state.DefaultPath, "Путь к файлу состояния развертывания (для --env - .ai-agents/state.<env>.json)")
+	driftCmd.Flags().StringVar(&driftEnv, "env", "", "Окружение: наложить оверлей overlays/<env>.yaml на конфигурацию")
+}
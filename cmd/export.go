@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportKinds     []string
+	exportSplitDir  string
+	exportOutput    string
+	exportStateFile string
+	exportEnv       string
+)
+
+// exportKindNames - значения флага --kind и соответствующие типы ресурсов
+var exportKindNames = map[string]deployer.ResourceKind{
+	"mcp-servers":   deployer.KindMCPServer,
+	"agents":        deployer.KindAgent,
+	"agent-systems": deployer.KindAgentSystem,
+}
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Экспорт ресурсов проекта в YAML конфигурацию",
+	Long: `Экспорт MCP серверов, агентов и систем агентов проекта в YAML конфигурацию,
+которую можно развернуть командой deploy.
+
+Серверные поля (ID, статусы, даты создания и авторы) не экспортируются,
+ссылки агентов на MCP серверы и систем на агентов записываются по имени.
+После экспорта deploy --plan для полученной конфигурации не показывает изменений.
+
+По умолчанию конфигурация выводится в stdout. С флагом --split-dir каждый
+ресурс записывается в отдельный файл, а корневой ai-agents.yaml подключает
+их через !include.
+
+Значения, которые при развертывании были взяты из секретов (secret://...),
+записываются ссылками на секреты по файлу состояния (--state).
+
+Примеры использования:
+  ai-agents-cli export > ai-agents.yaml
+  ai-agents-cli export --output ai-agents.yaml
+  ai-agents-cli export --kind agents,mcp-servers
+  ai-agents-cli export --split-dir ./deploy`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		kinds, err := parseExportKinds(exportKinds)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+
+		// Получаем API клиент из DI контейнера
+		container := di.GetContainer()
+		apiClient, err := container.GetAPI()
+		if err != nil {
+			log.Fatal("Failed to get API client", "error", err)
+		}
+
+		st, err := state.Load(stateFilePath(cmd, exportStateFile, exportEnv))
+		if err != nil {
+			log.Error("Failed to load state", "error", err)
+			fmt.Fprintln(os.Stderr, ui.CheckAndDisplayError(err))
+			os.Exit(1)
+		}
+
+		m, warnings, err := deployer.Export(ctx, apiClient, kinds, st)
+		if err != nil {
+			log.Error("Failed to export resources", "error", err)
+			fmt.Fprintln(os.Stderr, ui.CheckAndDisplayError(err))
+			os.Exit(1)
+		}
+		// Предупреждения выводятся в stderr, чтобы не попасть в YAML
+		for _, warning := range warnings {
+			fmt.Fprintln(os.Stderr, ui.FormatWarning(warning))
+		}
+
+		if exportSplitDir != "" {
+			files, err := manifest.WriteSplit(exportSplitDir, m)
+			if err != nil {
+				log.Error("Failed to write configuration", "error", err)
+				fmt.Println(ui.CheckAndDisplayError(err))
+				os.Exit(1)
+			}
+			fmt.Println(ui.FormatSuccess(fmt.Sprintf("Exported %d MCP servers, %d agents and %d agent systems to %d files in %s",
+				len(m.MCPServers), len(m.Agents), len(m.AgentSystems), len(files), exportSplitDir)))
+			return
+		}
+
+		data, err := manifest.Marshal(m)
+		if err != nil {
+			log.Error("Failed to marshal configuration", "error", err)
+			fmt.Fprintln(os.Stderr, ui.CheckAndDisplayError(err))
+			os.Exit(1)
+		}
+
+		if exportOutput == "" {
+			os.Stdout.Write(data)
+			return
+		}
+
+		if err := os.WriteFile(exportOutput, data, 0644); err != nil {
+			log.Error("Failed to write configuration", "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
+			os.Exit(1)
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Exported %d MCP servers, %d agents and %d agent systems to %s",
+			len(m.MCPServers), len(m.Agents), len(m.AgentSystems), exportOutput)))
+	},
+}
+
+// parseExportKinds преобразует значения флага --kind в типы ресурсов.
+// Пустой список означает все типы.
+func parseExportKinds(values []string) ([]deployer.ResourceKind, error) {
+	if len(values) == 0 {
+		return deployer.AllKinds, nil
+	}
+
+	var kinds []deployer.ResourceKind
+	for _, value := range values {
+		kind, ok := exportKindNames[strings.TrimSpace(value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown kind '%s', expected one of: agents, mcp-servers, agent-systems", value)
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+func init() {
+	RootCMD.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringSliceVar(&exportKinds, "kind", nil, "Типы экспортируемых ресурсов: agents, mcp-servers, agent-systems (по умолчанию все)")
+	exportCmd.Flags().StringVar(&exportSplitDir, "split-dir", "", "Записать каждый ресурс в отдельный файл в указанной директории")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Путь к файлу для записи конфигурации (по умолчанию stdout)")
+	exportCmd.Flags().StringVar(&exportStateFile, "state", state.DefaultPath, "Путь к файлу состояния со ссылками на секреты (для --env - .ai-agents/state.<env>.json)")
+	exportCmd.Flags().StringVar(&exportEnv, "env", "", "Окружение, файл состояния которого используется")
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/cloud-ru/evo-ai-agents-cli/cmd/mcp_server"
 	"github.com/cloud-ru/evo-ai-agents-cli/cmd/prompt"
 	registryCmd "github.com/cloud-ru/evo-ai-agents-cli/cmd/registry"
+	schemaCmd "github.com/cloud-ru/evo-ai-agents-cli/cmd/schema"
+	secretsCmd "github.com/cloud-ru/evo-ai-agents-cli/cmd/secrets"
+	stateCmd "github.com/cloud-ru/evo-ai-agents-cli/cmd/state"
 	"github.com/cloud-ru/evo-ai-agents-cli/cmd/system"
 	"github.com/cloud-ru/evo-ai-agents-cli/cmd/trigger"
 )
@@ -19,6 +22,9 @@ func init() {
 		mcp_server.RootCMD,
 		prompt.RootCMD,
 		registryCmd.RootCMD,
+		schemaCmd.RootCMD,
+		secretsCmd.RootCMD,
+		stateCmd.RootCMD,
 		system.RootCMD,
 		trigger.RootCMD,
 	)
@@ -27,7 +27,7 @@ var createCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
-		var req *api.MCPServerCreateRequest
+		var req *api.CreateMCPServerRequest
 
 		if configFile != "" {
 			// Загружаем конфигурацию из файла
@@ -36,31 +36,20 @@ var createCmd = &cobra.Command{
 				log.Fatal("Failed to read config file", "error", err, "file", configFile)
 			}
 
-			var config struct {
-				Name        string                 `json:"name"`
-				Description string                 `json:"description"`
-				Options     map[string]interface{} `json:"options"`
-			}
-
-			if err := json.Unmarshal(data, &config); err != nil {
+			// Файл содержит тело запроса создания в формате API
+			req = &api.CreateMCPServerRequest{}
+			if err := json.Unmarshal(data, req); err != nil {
 				log.Fatal("Failed to parse config file", "error", err)
 			}
-
-			req = &api.MCPServerCreateRequest{
-				Name:        config.Name,
-				Description: config.Description,
-				Options:     config.Options,
-			}
 		} else {
 			// Используем параметры командной строки
 			if name == "" {
 				log.Fatal("Name is required. Use --name flag or --config file")
 			}
 
-			req = &api.MCPServerCreateRequest{
+			req = &api.CreateMCPServerRequest{
 				Name:        name,
 				Description: description,
-				Options:     make(map[string]interface{}),
 			}
 		}
 
@@ -72,11 +61,17 @@ var createCmd = &cobra.Command{
 	}
 
 		// Создаем MCP сервер
-		server, err := apiClient.MCPServers.Create(ctx, req)
+		created, err := apiClient.MCPServers.CreateMCPServer(ctx, req)
 		if err != nil {
 			log.Fatal("Failed to create MCP server", "error", err)
 		}
 
+		// API возвращает только ID, подробности получаем отдельным запросом
+		server, err := apiClient.MCPServers.Get(ctx, created.MCPServerID)
+		if err != nil {
+			log.Fatal("Failed to get created MCP server", "error", err, "id", created.MCPServerID)
+		}
+
 		// Создаем стили для вывода
 		successStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -101,7 +96,7 @@ var createCmd = &cobra.Command{
 			fmt.Printf("%s: %s\n", labelStyle.Render("Описание"), valueStyle.Render(server.Description))
 		}
 
-		fmt.Printf("%s: %s\n", labelStyle.Render("Статус"), valueStyle.Render(server.Status))
+		fmt.Printf("%s: %s\n", labelStyle.Render("Статус"), valueStyle.Render(string(server.Status)))
 		fmt.Printf("%s: %s\n", labelStyle.Render("Создан"), valueStyle.Render(server.CreatedAt.Time.Format("02.01.2006 15:04:05")))
 	},
 }
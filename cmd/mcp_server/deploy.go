@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
@@ -14,9 +17,13 @@ import (
 )
 
 var (
-	deployFile   string
-	dryRun       bool
-	validateOnly bool
+	deployFile        string
+	dryRun            bool
+	validateOnly      bool
+	deployWait        bool
+	deployWaitTimeout time.Duration
+	deployParallel    int
+	deployFailFast    bool
 )
 
 // deployCmd represents the deploy command
@@ -26,7 +33,10 @@ var deployCmd = &cobra.Command{
 	Long:  localizations.Localization.Get("deploy_long"),
 	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		// Ctrl-C отменяет развертывание: ресурсы, которые еще не начали
+		// развертываться, пропускаются
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
 		// Определяем файл конфигурации
 		configFile := deployFile
@@ -63,6 +73,9 @@ var deployCmd = &cobra.Command{
 		// Создаем деплойер
 		mcpDeployer := deployer.NewMCPDeployer(apiClient)
 
+		// Независимые ресурсы развертываются параллельно (--parallel или BULK_OPERATIONS_CONCURRENCY)
+		mcpDeployer.SetParallel(deployer.ResolveParallelOptions(deployParallel, deployFailFast, container.GetConfig))
+
 		// Валидация конфигурации
 		fmt.Println(ui.FormatInfo("Validating configuration..."))
 		if err := mcpDeployer.ValidateMCPServers(configFile); err != nil {
@@ -88,6 +101,16 @@ var deployCmd = &cobra.Command{
 
 		// Показываем результаты
 		deployer.ShowDeployResults(results)
+
+		// Ожидаем запуска развернутых ресурсов
+		if deployWait && !dryRun {
+			fmt.Println(ui.FormatInfo("Waiting for resources to become ready..."))
+			waitResults := deployer.NewWaiter(apiClient).Wait(ctx, results, deployer.WaitOptions{Timeout: deployWaitTimeout})
+			deployer.ShowWaitResults(os.Stdout, waitResults)
+			if deployer.HasWaitFailures(waitResults) {
+				os.Exit(1)
+			}
+		}
 	},
 }
 
@@ -97,4 +120,8 @@ func init() {
 	deployCmd.Flags().StringVarP(&deployFile, "file", "f", "", "Путь к файлу конфигурации")
 	deployCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Режим предварительного просмотра без создания ресурсов")
 	deployCmd.Flags().BoolVar(&validateOnly, "validate-only", false, "Только валидация конфигурации без развертывания")
+	deployCmd.Flags().BoolVar(&deployWait, "wait", false, "Ожидать запуска развернутых ресурсов")
+	deployCmd.Flags().DurationVar(&deployWaitTimeout, "timeout", deployer.DefaultWaitTimeout, "Максимальное время ожидания запуска каждого ресурса")
+	deployCmd.Flags().IntVar(&deployParallel, "parallel", 0, "Максимальное количество ресурсов, развертываемых одновременно (по умолчанию BULK_OPERATIONS_CONCURRENCY)")
+	deployCmd.Flags().BoolVar(&deployFailFast, "fail-fast", false, "Не начинать развертывание остальных ресурсов после первой ошибки (начатые завершаются)")
 }
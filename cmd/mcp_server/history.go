@@ -8,6 +8,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
 	"github.com/spf13/cobra"
 )
@@ -56,27 +57,31 @@ var historyCmd = &cobra.Command{
 
 		// Создаем таблицу
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "Время\tДействие\tСтатус\tСообщение")
-		fmt.Fprintln(w, "-----\t--------\t------\t--------")
+		fmt.Fprintln(w, "Время\tСобытие\tСтатус\tАвтор")
+		fmt.Fprintln(w, "-----\t-------\t------\t-----")
 
 		for _, entry := range history.Data {
-			status := entry.Status
-			switch status {
-			case "SUCCESS":
-				status = statusStyle.Copy().Foreground(lipgloss.Color("2")).Render("✅ Успех")
-			case "ERROR":
-				status = statusStyle.Copy().Foreground(lipgloss.Color("1")).Render("❌ Ошибка")
-			case "PENDING":
-				status = statusStyle.Copy().Foreground(lipgloss.Color("3")).Render("⏳ В процессе")
+			var event string
+			switch entry.EventType {
+			case api.HistoryEventTypeCreation:
+				event = statusStyle.Copy().Foreground(lipgloss.Color("2")).Render("✨ Создание")
+			case api.HistoryEventTypeChanged:
+				event = statusStyle.Copy().Foreground(lipgloss.Color("4")).Render("✏️ Изменение")
+			case api.HistoryEventTypeDeleted:
+				event = statusStyle.Copy().Foreground(lipgloss.Color("1")).Render("🗑️ Удаление")
+			case api.HistoryEventTypeSuspended:
+				event = statusStyle.Copy().Foreground(lipgloss.Color("3")).Render("⏸️ Остановка")
+			case api.HistoryEventTypeResumed:
+				event = statusStyle.Copy().Foreground(lipgloss.Color("2")).Render("▶️ Запуск")
 			default:
-				status = statusStyle.Copy().Foreground(lipgloss.Color("8")).Render("⚪ " + status)
+				event = statusStyle.Copy().Foreground(lipgloss.Color("8")).Render("⚪ " + string(entry.EventType))
 			}
 
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-				entry.CreatedAt.Format("02.01.2006 15:04:05"),
-				entry.Action,
-				status,
-				entry.Message,
+				entry.Version.Format("02.01.2006 15:04:05"),
+				event,
+				entry.After.Status,
+				entry.AuthorID,
 			)
 		}
 
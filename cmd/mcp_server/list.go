@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/errors"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	limit  int
-	offset int
+	limit           int
+	offset          int
+	listName        string
+	listStatuses    []string
+	listNotStatuses []string
+	listSearch      string
+	listCreatedBy   string
+	listAll         bool
 )
 
 // listCmd represents the list command
@@ -28,11 +35,20 @@ var listCmd = &cobra.Command{
 • Дата последнего обновления
 
 Поддерживает постраничную навигацию с помощью флагов --limit и --offset.
+Флаг --all загружает все страницы и показывает их одной таблицей, --limit
+при этом не учитывается, а --offset не допускается.
+
+Фильтры применяются на сервере. Флаги --status и --not-status можно
+повторять или перечислять значения через запятую. Статус указывается
+без префикса MCP_SERVER_STATUS_.
 
 Примеры использования:
   ai-agents-cli mcp-servers list
   ai-agents-cli mcp-servers list --limit 10
-  ai-agents-cli mcp-servers list --offset 20 --limit 5`,
+  ai-agents-cli mcp-servers list --offset 20 --limit 5
+  ai-agents-cli mcp-servers list --all
+  ai-agents-cli mcp-servers list --status RUNNING --search tools
+  ai-agents-cli mcp-servers list --not-status deleted --created-by user-id`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
@@ -44,8 +60,16 @@ var listCmd = &cobra.Command{
 			return
 		}
 
+		filter, err := listFilter()
+		if err != nil {
+			errorHandler := errors.NewHandler()
+			appErr := errorHandler.WrapUserError(err, "MCP_SERVERS_LIST_FILTER_INVALID", "Некорректный фильтр списка MCP серверов")
+			fmt.Println(errorHandler.HandlePlain(appErr))
+			return
+		}
+
 		// Показываем таблицу MCP серверов
-		if err := ui.ShowMCPServersListFromAPI(ctx, limit, offset); err != nil {
+		if err := ui.ShowMCPServersListFromAPI(ctx, filter, listAll); err != nil {
 			errorHandler := errors.NewHandler()
 			appErr := errorHandler.WrapAPIError(err, "MCP_SERVERS_LIST_FAILED", "Ошибка получения списка MCP серверов")
 			appErr = appErr.WithSuggestions(
@@ -61,9 +85,37 @@ var listCmd = &cobra.Command{
 	},
 }
 
+// listFilter собирает параметры поиска MCP серверов из флагов команды
+func listFilter() (api.SearchMCPServerParams, error) {
+	filter := api.SearchMCPServerParams{
+		Limit:     limit,
+		Offset:    offset,
+		Name:      listName,
+		Search:    listSearch,
+		CreatedBy: listCreatedBy,
+	}
+
+	var err error
+	if filter.Statuses, err = api.ParseEnumValues(api.MCPServerStatusValues, listStatuses); err != nil {
+		return filter, fmt.Errorf("invalid --status: %w", err)
+	}
+	if filter.NotInStatuses, err = api.ParseEnumValues(api.MCPServerStatusValues, listNotStatuses); err != nil {
+		return filter, fmt.Errorf("invalid --not-status: %w", err)
+	}
+
+	return filter, nil
+}
+
 func init() {
 	RootCMD.AddCommand(listCmd)
 
 	listCmd.Flags().IntVarP(&limit, "limit", "l", 20, "Количество записей для отображения")
 	listCmd.Flags().IntVarP(&offset, "offset", "o", 0, "Смещение для постраничной навигации")
+	listCmd.Flags().StringVar(&listName, "name", "", "Фильтр по названию MCP сервера")
+	listCmd.Flags().StringSliceVar(&listStatuses, "status", nil, "Показать MCP серверы только в указанных статусах")
+	listCmd.Flags().StringSliceVar(&listNotStatuses, "not-status", nil, "Исключить MCP серверы в указанных статусах")
+	listCmd.Flags().StringVar(&listSearch, "search", "", "Полнотекстовый поиск по MCP серверам")
+	listCmd.Flags().StringVar(&listCreatedBy, "created-by", "", "Фильтр по ID создателя MCP сервера")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "Загрузить все страницы списка")
+	listCmd.MarkFlagsMutuallyExclusive("all", "offset")
 }
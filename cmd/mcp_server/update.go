@@ -29,7 +29,7 @@ var updateCmd = &cobra.Command{
 		ctx := context.Background()
 		serverID := args[0]
 
-		var req *api.MCPServerUpdateRequest
+		var req *api.UpdateMCPServerRequest
 
 		if updateConfigFile != "" {
 			// Загружаем конфигурацию из файла
@@ -38,24 +38,14 @@ var updateCmd = &cobra.Command{
 				log.Fatal("Failed to read config file", "error", err, "file", updateConfigFile)
 			}
 
-			var config struct {
-				Name        string                 `json:"name"`
-				Description string                 `json:"description"`
-				Options     map[string]interface{} `json:"options"`
-			}
-
-			if err := json.Unmarshal(data, &config); err != nil {
+			// Файл содержит тело запроса обновления в формате API
+			req = &api.UpdateMCPServerRequest{}
+			if err := json.Unmarshal(data, req); err != nil {
 				log.Fatal("Failed to parse config file", "error", err)
 			}
-
-			req = &api.MCPServerUpdateRequest{
-				Name:        config.Name,
-				Description: config.Description,
-				Options:     config.Options,
-			}
 		} else {
 			// Используем параметры командной строки
-			req = &api.MCPServerUpdateRequest{}
+			req = &api.UpdateMCPServerRequest{}
 
 			if updateName != "" {
 				req.Name = updateName
@@ -73,11 +63,16 @@ var updateCmd = &cobra.Command{
 	}
 
 		// Обновляем MCP сервер
-		server, err := apiClient.MCPServers.Update(ctx, serverID, req)
-		if err != nil {
+		if _, err := apiClient.MCPServers.UpdateMCPServer(ctx, serverID, req); err != nil {
 			log.Fatal("Failed to update MCP server", "error", err, "server_id", serverID)
 		}
 
+		// API не возвращает сервер в ответе на обновление
+		server, err := apiClient.MCPServers.Get(ctx, serverID)
+		if err != nil {
+			log.Fatal("Failed to get updated MCP server", "error", err, "server_id", serverID)
+		}
+
 		// Создаем стили для вывода
 		successStyle := lipgloss.NewStyle().
 			Bold(true).
@@ -102,7 +97,7 @@ var updateCmd = &cobra.Command{
 			fmt.Printf("%s: %s\n", labelStyle.Render("Описание"), valueStyle.Render(server.Description))
 		}
 
-		fmt.Printf("%s: %s\n", labelStyle.Render("Статус"), valueStyle.Render(server.Status))
+		fmt.Printf("%s: %s\n", labelStyle.Render("Статус"), valueStyle.Render(string(server.Status)))
 		fmt.Printf("%s: %s\n", labelStyle.Render("Обновлен"), valueStyle.Render(server.UpdatedAt.Time.Format("02.01.2006 15:04:05")))
 	},
 }
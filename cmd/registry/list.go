@@ -12,15 +12,24 @@ import (
 )
 
 var (
-	listLimit  int
-	listOffset int
+	listLimit     int
+	listPageToken string
+	listAll       bool
 )
 
 // listCmd represents the list command
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Показать список реестров",
-	Long:  "Выводит список всех реестров в проекте",
+	Long: `Выводит список всех реестров в проекте.
+
+По умолчанию выводится одна страница. Следующую страницу можно получить
+по токену --page-token, а флаг --all загружает все страницы.
+
+Примеры использования:
+  ai-agents-cli registry list
+  ai-agents-cli registry list --limit 20 --page-token <token>
+  ai-agents-cli registry list --all`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
@@ -32,9 +41,18 @@ var listCmd = &cobra.Command{
 		}
 
 		// Получаем список реестров
-		response, err := apiClient.Registries.List(ctx, listLimit, listOffset)
-		if err != nil {
-			log.Fatal("Failed to list registries", "error", err)
+		var response *api.RegistryListResponse
+		if listAll {
+			registries, err := api.Collect(apiClient.Registries.All(ctx, listLimit))
+			if err != nil {
+				log.Fatal("Failed to list registries", "error", err)
+			}
+			response = &api.RegistryListResponse{Registries: registries}
+		} else {
+			response, err = apiClient.Registries.List(ctx, listLimit, listPageToken)
+			if err != nil {
+				log.Fatal("Failed to list registries", "error", err)
+			}
 		}
 
 		// Создаем стили для вывода
@@ -78,12 +96,14 @@ var listCmd = &cobra.Command{
 		if response.NextPageToken != "" {
 			fmt.Println()
 			fmt.Println("💡 Для загрузки следующей страницы используйте:")
-			fmt.Printf("  ai-agents-cli registry list --offset %s\n", response.NextPageToken)
+			fmt.Printf("  ai-agents-cli registry list --page-token %s\n", response.NextPageToken)
+			fmt.Println("  или загрузите все страницы с флагом --all")
 		}
 	},
 }
 
 func init() {
 	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 100, "Лимит количества результатов")
-	listCmd.Flags().IntVarP(&listOffset, "offset", "o", 0, "Смещение для постраничной навигации")
+	listCmd.Flags().StringVar(&listPageToken, "page-token", "", "Токен страницы из предыдущего ответа")
+	listCmd.Flags().BoolVar(&listAll, "all", false, "Загрузить все страницы списка")
 }
@@ -8,11 +8,16 @@ import (
 	"github.com/charmbracelet/log"
 	authCmd "github.com/cloud-ru/evo-ai-agents-cli/cmd/auth"
 	"github.com/cloud-ru/evo-ai-agents-cli/cmd/create"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
 	"github.com/spf13/cobra"
 )
 
 var (
 	isVerbose bool
+	varPairs  []string
+	varFiles  []string
+	schemaArg string
 )
 
 // RootCMD represents the base command when called without any subcommands
@@ -51,6 +56,16 @@ var RootCMD = &cobra.Command{
 
 		log.SetDefault(logger)
 		log.Debug("AI Agents CLI запущен", "version", "1.0.0", "verbose", verbose)
+
+		// Переменные для подстановки ${...} в YAML конфигурациях
+		vars, err := parser.LoadVariables(varPairs, varFiles)
+		if err != nil {
+			log.Fatal("Failed to load variables", "error", err)
+		}
+		parser.SetVariables(vars)
+
+		// Файл схемы вместо встроенной в бинарный файл
+		validator.SetSchemaFile(schemaArg)
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Показываем красивый help если нет аргументов
@@ -65,6 +80,12 @@ var RootCMD = &cobra.Command{
 func init() {
 	RootCMD.PersistentFlags().
 		BoolVarP(&isVerbose, "verbose", "v", false, "Детализация процесса")
+	RootCMD.PersistentFlags().
+		StringArrayVar(&varPairs, "var", nil, "Переменная для подстановки ${KEY} в конфигурации (KEY=VALUE, можно повторять)")
+	RootCMD.PersistentFlags().
+		StringArrayVar(&varFiles, "var-file", nil, "Файл с переменными для подстановки в формате .env (можно повторять)")
+	RootCMD.PersistentFlags().
+		StringVar(&schemaArg, "schema", "", "Файл JSON схемы конфигурации вместо встроенной")
 
 	// Set custom help function
 	RootCMD.SetHelpFunc(func(cmd *cobra.Command, args []string) {
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/schemas"
+	"github.com/spf13/cobra"
+)
+
+// printCmd represents the print command
+var printCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Вывести встроенную JSON схему",
+	Long: `Выводит JSON схему конфигурации, встроенную в бинарный файл.
+
+Примеры использования:
+  ai-agents-cli schema print
+  ai-agents-cli schema print > schema.json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := os.Stdout.Write(schemas.Schema); err != nil {
+			log.Fatal("Failed to print schema", "error", err)
+		}
+	},
+}
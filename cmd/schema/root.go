@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+// RootCMD represents the base command when called without any subcommands
+var RootCMD = &cobra.Command{
+	Use:   "schema",
+	Short: "JSON схема конфигурации",
+	Long: `Команды для работы с JSON схемой конфигурации.
+
+Схема встроена в бинарный файл, поэтому validate и deploy работают из любой
+директории. Флаг --schema задает файл схемы вместо встроенной.
+
+Доступные операции:
+• print - Вывести встроенную схему
+• version - Показать версию встроенной схемы
+• write-vscode - Подключить схему к YAML файлам проекта в VS Code
+
+Примеры использования:
+  ai-agents-cli schema print > schema.json
+  ai-agents-cli schema version
+  ai-agents-cli schema write-vscode`,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Debug("Команда schema вызвана без подкоманды")
+		// Показываем справку если нет подкоманд
+		cmd.Help()
+	},
+	Args: cobra.ArbitraryArgs,
+}
+
+func init() {
+	log.Debug("Инициализация команды schema")
+
+	// Добавляем подкоманды
+	RootCMD.AddCommand(printCmd)
+	RootCMD.AddCommand(versionCmd)
+	RootCMD.AddCommand(writeVSCodeCmd)
+}
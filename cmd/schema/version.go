@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/schemas"
+	"github.com/spf13/cobra"
+)
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Показать версию встроенной JSON схемы",
+	Long: `Показывает версию JSON схемы, встроенной в бинарный файл, и ее SHA-256.
+
+Примеры использования:
+  ai-agents-cli schema version`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("%s (sha256:%s)\n", schemas.Version(), schemas.Digest())
+	},
+}
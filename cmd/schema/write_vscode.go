@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/cloud-ru/evo-ai-agents-cli/schemas"
+	"github.com/spf13/cobra"
+)
+
+// yamlSchemasKey - настройка расширения YAML (redhat.vscode-yaml), связывающая
+// схемы с файлами
+const yamlSchemasKey = "yaml.schemas"
+
+var (
+	vscodeDir        string
+	vscodeSchemaFile string
+	vscodeGlobs      []string
+)
+
+// writeVSCodeCmd represents the write-vscode command
+var writeVSCodeCmd = &cobra.Command{
+	Use:   "write-vscode",
+	Short: "Подключить схему к YAML файлам проекта в VS Code",
+	Long: `Записывает встроенную JSON схему в проект и связывает ее с файлами
+конфигурации в .vscode/settings.json (настройка yaml.schemas расширения YAML).
+Остальные настройки файла сохраняются. Повторный запуск обновляет схему.
+
+Вместо настройки можно указать схему в первой строке файла:
+  # yaml-language-server: $schema=./.vscode/ai-agents.schema.json
+
+Примеры использования:
+  ai-agents-cli schema write-vscode
+  ai-agents-cli schema write-vscode --glob "agents/*.yaml" --glob "ai-agents.yaml"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		settingsFile, err := writeVSCode(vscodeDir, vscodeSchemaFile, vscodeGlobs)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Schema %s is associated with %v in %s", vscodeSchemaFile, vscodeGlobs, settingsFile)))
+	},
+}
+
+// writeVSCode записывает схему в проект и добавляет ее в yaml.schemas
+// настроек VS Code. Возвращает путь к файлу настроек.
+func writeVSCode(dir, schemaFile string, globs []string) (string, error) {
+	if filepath.IsAbs(schemaFile) {
+		return "", fmt.Errorf("schema file must be relative to the project directory: %s", schemaFile)
+	}
+
+	schemaPath := filepath.Join(dir, schemaFile)
+	if err := os.MkdirAll(filepath.Dir(schemaPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for schema: %w", err)
+	}
+	if err := os.WriteFile(schemaPath, schemas.Schema, 0644); err != nil {
+		return "", fmt.Errorf("failed to write schema: %w", err)
+	}
+
+	settingsFile := filepath.Join(dir, ".vscode", "settings.json")
+	settings := make(map[string]interface{})
+	data, err := os.ReadFile(settingsFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return "", fmt.Errorf("failed to read %s: %w", settingsFile, err)
+	default:
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return "", fmt.Errorf("failed to parse %s (comments are not supported, add %q to %s manually): %w",
+				settingsFile, "./"+filepath.ToSlash(schemaFile), yamlSchemasKey, err)
+		}
+	}
+
+	associations, _ := settings[yamlSchemasKey].(map[string]interface{})
+	if associations == nil {
+		associations = make(map[string]interface{})
+	}
+	associations["./"+filepath.ToSlash(schemaFile)] = globs
+	settings[yamlSchemasKey] = associations
+
+	data, err = json.MarshalIndent(settings, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(settingsFile), 0755); err != nil {
+		return "", fmt.Errorf("failed to create .vscode directory: %w", err)
+	}
+	if err := os.WriteFile(settingsFile, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", settingsFile, err)
+	}
+	return settingsFile, nil
+}
+
+func init() {
+	writeVSCodeCmd.Flags().StringVar(&vscodeDir, "dir", ".", "Директория проекта")
+	writeVSCodeCmd.Flags().StringVar(&vscodeSchemaFile, "schema-file", filepath.Join(".vscode", "ai-agents.schema.json"), "Путь к файлу схемы относительно директории проекта")
+	writeVSCodeCmd.Flags().StringArrayVar(&vscodeGlobs, "glob", []string{"ai-agents.yaml", "ai-agents.yml", "overlays/*.yaml"}, "Шаблон файлов конфигурации (можно повторять)")
+}
@@ -0,0 +1,46 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var decryptPaths []string
+
+// decryptCmd represents the decrypt command
+var decryptCmd = &cobra.Command{
+	Use:   "decrypt <file>",
+	Short: "Расшифровать значения в YAML файле",
+	Long: `Расшифровывает значения YAML файла и записывает файл на место.
+Без --path расшифровываются все зашифрованные значения.
+
+Для deploy и validate расшифровывать файл не нужно: значения
+расшифровываются в памяти.
+
+Примеры использования:
+  ai-agents-cli secrets decrypt ai-agents.yaml
+  ai-agents-cli secrets decrypt ai-agents.yaml --path agents[0].options.env.rawEnvs.API_KEY`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		identities, err := secrets.LoadIdentities(keyFile)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+
+		count, err := secrets.DecryptFile(args[0], decryptPaths, identities)
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Failed to decrypt %s: %v", args[0], err)))
+			os.Exit(1)
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Decrypted %d values in %s", count, args[0])))
+	},
+}
+
+func init() {
+	decryptCmd.Flags().StringArrayVar(&decryptPaths, "path", nil, "Путь к значению (по умолчанию все зашифрованные значения)")
+}
@@ -0,0 +1,59 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	encryptPaths      []string
+	encryptRecipients []string
+)
+
+// encryptCmd represents the encrypt command
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt <file>",
+	Short: "Зашифровать значения в YAML файле",
+	Long: `Шифрует значения YAML файла по путям и записывает файл на место.
+
+Путь задается через точку, элементы списков - индексом в квадратных скобках.
+Значения шифруются для получателей --recipient, а если они не заданы -
+для открытого ключа локального ключа age.
+
+Примеры использования:
+  ai-agents-cli secrets encrypt ai-agents.yaml --path agents[0].options.env.rawEnvs.API_KEY
+  ai-agents-cli secrets encrypt ai-agents.yaml --path mcp-servers[0].environmentOptions.rawEnvs.TOKEN --recipient age1...`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		recipients, err := secrets.ParseRecipients(encryptRecipients)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+		if len(recipients) == 0 {
+			identities, err := secrets.LoadIdentities(keyFile)
+			if err != nil {
+				fmt.Println(ui.FormatError(err.Error()))
+				os.Exit(1)
+			}
+			recipients = secrets.RecipientsOf(identities)
+		}
+
+		count, err := secrets.EncryptFile(args[0], encryptPaths, recipients)
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Failed to encrypt %s: %v", args[0], err)))
+			os.Exit(1)
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Encrypted %d values in %s", count, args[0])))
+	},
+}
+
+func init() {
+	encryptCmd.Flags().StringArrayVar(&encryptPaths, "path", nil, "Путь к значению, например agents[0].options.env.rawEnvs.API_KEY (можно указать несколько раз)")
+	encryptCmd.Flags().StringArrayVar(&encryptRecipients, "recipient", nil, "Открытый ключ age получателя (age1...), можно указать несколько раз")
+	encryptCmd.MarkFlagRequired("path")
+}
@@ -0,0 +1,56 @@
+package secrets
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/spf13/cobra"
+)
+
+var keyFile string
+
+// RootCMD represents the base command when called without any subcommands
+var RootCMD = &cobra.Command{
+	Use:   "secrets",
+	Short: "Шифрование значений в конфигурационных файлах",
+	Long: `Команды для шифрования значений YAML конфигурации ключами age (X25519).
+
+Зашифрованные значения хранятся в файле в виде ENC[age,data:...] или как
+скаляры с тегом !encrypted и расшифровываются только в памяти при deploy
+и validate. Файл редактируется на месте, комментарии и порядок ключей
+сохраняются.
+
+Ключ age ищется в порядке:
+• --key-file
+• переменная окружения AI_AGENTS_AGE_KEY (AGE-SECRET-KEY-...)
+• файл из переменной окружения AI_AGENTS_AGE_KEY_FILE
+• ~/.ai-agents-cli/age.key
+
+Ключ можно создать командой age-keygen -o ~/.ai-agents-cli/age.key.
+
+Доступные операции:
+• encrypt - Зашифровать значения по путям
+• decrypt - Расшифровать значения
+• rotate-key - Перешифровать значения для новых ключей
+
+Примеры использования:
+  ai-agents-cli secrets encrypt ai-agents.yaml --path agents[0].options.env.rawEnvs.API_KEY
+  ai-agents-cli secrets decrypt ai-agents.yaml
+  ai-agents-cli secrets rotate-key ai-agents.yaml --recipient age1...`,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Debug("Команда secrets вызвана без подкоманды")
+		// Показываем справку если нет подкоманд
+		cmd.Help()
+	},
+	Args: cobra.ArbitraryArgs,
+}
+
+func init() {
+	log.Debug("Инициализация команды secrets")
+
+	RootCMD.PersistentFlags().StringVar(&keyFile, "key-file", "", "Путь к файлу ключа age (по умолчанию "+secrets.KeyEnv+", "+secrets.KeyFileEnv+" или ~/.ai-agents-cli/age.key)")
+
+	// Добавляем подкоманды
+	RootCMD.AddCommand(encryptCmd)
+	RootCMD.AddCommand(decryptCmd)
+	RootCMD.AddCommand(rotateKeyCmd)
+}
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rotateRecipients []string
+	rotateNewKeyFile string
+)
+
+// rotateKeyCmd represents the rotate-key command
+var rotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key <file>",
+	Short: "Перешифровать значения для новых ключей",
+	Long: `Расшифровывает все зашифрованные значения YAML файла текущим ключом
+и шифрует их для новых получателей: открытых ключей --recipient и/или
+ключа из файла --new-key-file. Файл записывается на место.
+
+Примеры использования:
+  ai-agents-cli secrets rotate-key ai-agents.yaml --recipient age1...
+  ai-agents-cli secrets rotate-key ai-agents.yaml --new-key-file ./new-age.key`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		recipients, err := secrets.ParseRecipients(rotateRecipients)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+		if rotateNewKeyFile != "" {
+			newIdentities, err := secrets.LoadIdentities(rotateNewKeyFile)
+			if err != nil {
+				fmt.Println(ui.FormatError(err.Error()))
+				os.Exit(1)
+			}
+			recipients = append(recipients, secrets.RecipientsOf(newIdentities)...)
+		}
+		if len(recipients) == 0 {
+			fmt.Println(ui.FormatError("Specify new keys with --recipient or --new-key-file"))
+			os.Exit(1)
+		}
+
+		identities, err := secrets.LoadIdentities(keyFile)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+
+		count, err := secrets.RotateFile(args[0], identities, recipients)
+		if err != nil {
+			fmt.Println(ui.FormatError(fmt.Sprintf("Failed to rotate keys in %s: %v", args[0], err)))
+			os.Exit(1)
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Re-encrypted %d values in %s for %d recipients", count, args[0], len(recipients))))
+	},
+}
+
+func init() {
+	rotateKeyCmd.Flags().StringArrayVar(&rotateRecipients, "recipient", nil, "Открытый ключ age нового получателя (age1...), можно указать несколько раз")
+	rotateKeyCmd.Flags().StringVar(&rotateNewKeyFile, "new-key-file", "", "Файл нового ключа age, для открытого ключа которого шифруются значения")
+}
@@ -0,0 +1,93 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <kind> <name> <id>",
+	Short: "Добавить существующий ресурс проекта в файл состояния",
+	Long: `Связывает ресурс конфигурации с существующим ресурсом проекта.
+
+<name> - ключ ресурса в конфигурации (поле key или имя), <id> - ID ресурса
+в проекте. После импорта deploy обновляет этот ресурс, а не создает новый,
+даже если его имя в проекте отличается от имени в конфигурации.
+
+Примеры использования:
+  ai-agents-cli state import agent my-agent 3f2a1b4c-...
+  ai-agents-cli state import mcp-server my-mcp 9e8d7c6b-...`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		kind, err := parseKind(args[0])
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+		key, id := args[1], args[2]
+
+		// Получаем API клиент из DI контейнера
+		container := di.GetContainer()
+		apiClient, err := container.GetAPI()
+		if err != nil {
+			log.Fatal("Failed to get API client", "error", err)
+		}
+
+		// Проверяем, что ресурс существует, и берем его текущее имя
+		var name string
+		switch kind {
+		case deployer.KindMCPServer:
+			server, getErr := apiClient.MCPServers.Get(ctx, id)
+			if getErr == nil {
+				name = server.Name
+			}
+			err = getErr
+		case deployer.KindAgent:
+			agent, getErr := apiClient.Agents.Get(ctx, id)
+			if getErr == nil {
+				name = agent.Name
+			}
+			err = getErr
+		case deployer.KindAgentSystem:
+			system, getErr := apiClient.AgentSystems.Get(ctx, id)
+			if getErr == nil {
+				name = system.Name
+			}
+			err = getErr
+		}
+		if err != nil {
+			log.Error("Failed to get resource", "kind", kind, "id", id, "error", err)
+			fmt.Println(ui.CheckAndDisplayError(err))
+			os.Exit(1)
+		}
+
+		st := loadState(cmd)
+		if existing, ok := st.Get(string(kind), key); ok && existing.ID != id {
+			fmt.Println(ui.FormatWarning(fmt.Sprintf("Replacing %s '%s' (ID: %s) in state", kind, key, existing.ID)))
+		}
+		st.Set(state.Resource{
+			Kind:      string(kind),
+			Key:       key,
+			Name:      name,
+			ID:        id,
+			AppliedAt: time.Now().UTC(),
+		})
+
+		if err := st.Save(); err != nil {
+			log.Fatal("Failed to save state", "error", err)
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Imported %s '%s' (name: %s, ID: %s) into %s", kind, key, name, id, st.Path())))
+	},
+}
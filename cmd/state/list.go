@@ -0,0 +1,39 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Показать ресурсы в файле состояния",
+	Long:  "Выводит все ресурсы, записанные в файл состояния развертывания",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		st := loadState(cmd)
+
+		resources := st.List()
+		if len(resources) == 0 {
+			fmt.Printf("Файл состояния %s пуст. Ресурсы добавляются командами:\n", st.Path())
+			fmt.Println("  ai-agents-cli deploy <file>")
+			fmt.Println("  ai-agents-cli state import <kind> <name> <id>")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KIND\tKEY\tNAME\tID\tAPPLIED")
+		for _, resource := range resources {
+			applied := "-"
+			if !resource.AppliedAt.IsZero() {
+				applied = resource.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", resource.Kind, resource.Key, resource.Name, resource.ID, applied)
+		}
+		w.Flush()
+	},
+}
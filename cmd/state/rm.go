@@ -0,0 +1,40 @@
+package state
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// rmCmd represents the rm command
+var rmCmd = &cobra.Command{
+	Use:   "rm <kind> <key>",
+	Short: "Удалить запись о ресурсе из файла состояния",
+	Long: `Удаляет запись о ресурсе из файла состояния. Сам ресурс в проекте
+не удаляется: при следующем развертывании он будет найден по имени.
+
+Примеры использования:
+  ai-agents-cli state rm agent my-agent`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		kind, err := parseKind(args[0])
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+
+		st := loadState(cmd)
+		if !st.Remove(string(kind), args[1]) {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%s '%s' not found in state %s", kind, args[1], st.Path())))
+			os.Exit(1)
+		}
+
+		if err := st.Save(); err != nil {
+			log.Fatal("Failed to save state", "error", err)
+		}
+		fmt.Println(ui.FormatSuccess(fmt.Sprintf("Removed %s '%s' from state", kind, args[1])))
+	},
+}
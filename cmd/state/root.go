@@ -0,0 +1,92 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateFile string
+	stateEnv  string
+)
+
+// kindNames - допустимые обозначения типов ресурсов в аргументах команд
+var kindNames = map[string]deployer.ResourceKind{
+	"mcp-server":    deployer.KindMCPServer,
+	"mcp-servers":   deployer.KindMCPServer,
+	"agent":         deployer.KindAgent,
+	"agents":        deployer.KindAgent,
+	"agent-system":  deployer.KindAgentSystem,
+	"agent-systems": deployer.KindAgentSystem,
+}
+
+// RootCMD represents the base command when called without any subcommands
+var RootCMD = &cobra.Command{
+	Use:   "state",
+	Short: "Управление файлом состояния развертывания",
+	Long: `Команды для работы с локальным файлом состояния развертывания.
+
+Файл состояния (по умолчанию .ai-agents/state.json) хранит для каждого ресурса
+конфигурации его ID в проекте, хеш последней примененной спецификации и время
+развертывания. deploy ищет ресурсы по ID из файла состояния, поэтому
+переименование ресурса с сохранением поля key выполняется обновлением.
+
+Доступные операции:
+• list - Показать ресурсы в файле состояния
+• show - Показать запись о ресурсе
+• rm - Удалить запись о ресурсе (сам ресурс не удаляется)
+• import - Добавить существующий ресурс проекта в файл состояния
+
+Примеры использования:
+  ai-agents-cli state list
+  ai-agents-cli state list --env prod
+  ai-agents-cli state show agent my-agent
+  ai-agents-cli state rm agent my-agent
+  ai-agents-cli state import agent my-agent 3f2a...`,
+	Run: func(cmd *cobra.Command, args []string) {
+		log.Debug("Команда state вызвана без подкоманды")
+		// Показываем справку если нет подкоманд
+		cmd.Help()
+	},
+	Args: cobra.ArbitraryArgs,
+}
+
+// parseKind преобразует аргумент команды в тип ресурса
+func parseKind(value string) (deployer.ResourceKind, error) {
+	kind, ok := kindNames[value]
+	if !ok {
+		return "", fmt.Errorf("unknown kind '%s', expected one of: mcp-server, agent, agent-system", value)
+	}
+	return kind, nil
+}
+
+// loadState читает файл состояния: из пути, заданного флагом --state, а если
+// флаг не задан - файл состояния окружения --env
+func loadState(cmd *cobra.Command) *state.State {
+	path := stateFile
+	if !cmd.Flags().Changed("state") {
+		path = state.PathForEnv(stateEnv)
+	}
+	st, err := state.Load(path)
+	if err != nil {
+		log.Fatal("Failed to load state", "error", err)
+	}
+	return st
+}
+
+func init() {
+	log.Debug("Инициализация команды state")
+
+	RootCMD.PersistentFlags().StringVar(&stateFile, "state", state.DefaultPath, "Путь к файлу состояния развертывания")
+	RootCMD.PersistentFlags().StringVar(&stateEnv, "env", "", "Окружение, файл состояния которого используется")
+
+	// Добавляем подкоманды
+	RootCMD.AddCommand(listCmd)
+	RootCMD.AddCommand(showCmd)
+	RootCMD.AddCommand(rmCmd)
+	RootCMD.AddCommand(importCmd)
+}
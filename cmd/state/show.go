@@ -0,0 +1,47 @@
+package state
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:   "show <kind> <key>",
+	Short: "Показать запись о ресурсе",
+	Long: `Выводит запись о ресурсе из файла состояния: ID, имя, хеш последней
+примененной спецификации и время развертывания.
+
+Примеры использования:
+  ai-agents-cli state show agent my-agent
+  ai-agents-cli state show mcp-server my-mcp`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		kind, err := parseKind(args[0])
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+			os.Exit(1)
+		}
+
+		st := loadState(cmd)
+		resource, ok := st.Get(string(kind), args[1])
+		if !ok {
+			fmt.Println(ui.FormatError(fmt.Sprintf("%s '%s' not found in state %s", kind, args[1], st.Path())))
+			os.Exit(1)
+		}
+
+		fmt.Printf("Kind:      %s\n", resource.Kind)
+		fmt.Printf("Key:       %s\n", resource.Key)
+		fmt.Printf("Name:      %s\n", resource.Name)
+		fmt.Printf("ID:        %s\n", resource.ID)
+		if resource.SpecHash != "" {
+			fmt.Printf("Spec hash: %s\n", resource.SpecHash)
+		}
+		if !resource.AppliedAt.IsZero() {
+			fmt.Printf("Applied:   %s\n", resource.AppliedAt.Local().Format("2006-01-02 15:04:05"))
+		}
+	},
+}
@@ -34,7 +34,7 @@ var createCmd = &cobra.Command{
 	}
 
 		// Парсим опции из JSON
-		var options map[string]interface{}
+		var options api.AgentSystemOptions
 		if systemCreateOptions != "" {
 			if err := json.Unmarshal([]byte(systemCreateOptions), &options); err != nil {
 				log.Fatal("Failed to parse options JSON", "error", err)
@@ -42,19 +42,30 @@ var createCmd = &cobra.Command{
 		}
 
 		// Создаем запрос
-		req := &api.AgentSystemCreateRequest{
+		req := &api.CreateAgentSystemRequest{
 			Name:        systemCreateName,
 			Description: systemCreateDescription,
-			Agents:      systemCreateAgents,
 			Options:     options,
 		}
 
 		// Создаем систему
-		system, err := apiClient.AgentSystems.Create(ctx, req)
+		created, err := apiClient.AgentSystems.CreateAgentSystem(ctx, req)
 		if err != nil {
 			log.Fatal("Failed to create system", "error", err)
 		}
 
+		// При создании API не принимает агентов, добавляем их по одному
+		for _, agentID := range systemCreateAgents {
+			if err := apiClient.AgentSystems.AddAgent(ctx, created.AgentSystemID, agentID); err != nil {
+				log.Fatal("Failed to add agent to system", "error", err, "system_id", created.AgentSystemID, "agent_id", agentID)
+			}
+		}
+
+		system, err := apiClient.AgentSystems.Get(ctx, created.AgentSystemID)
+		if err != nil {
+			log.Fatal("Failed to get created system", "error", err, "system_id", created.AgentSystemID)
+		}
+
 		fmt.Printf("✅ Система агентов создана успешно!\n")
 		fmt.Printf("ID: %s\n", system.ID)
 		fmt.Printf("Название: %s\n", system.Name)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/charmbracelet/log"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
@@ -16,6 +18,8 @@ var (
 	systemDeployFile   string
 	systemDryRun       bool
 	systemValidateOnly bool
+	systemParallel     int
+	systemFailFast     bool
 )
 
 // deployCmd represents the deploy command
@@ -26,7 +30,7 @@ var deployCmd = &cobra.Command{
 
 Команда позволяет развертывать системы агентов из YAML файла с поддержкой:
 • Включения других файлов через !include
-• Валидации конфигурации по JSON схеме
+• Валидации конфигурации по JSON схеме и семантическим правилам
 • Привязки агентов к системам
 • Режима предварительного просмотра (dry-run)
 • Только валидации без развертывания
@@ -34,10 +38,14 @@ var deployCmd = &cobra.Command{
 Примеры использования:
   ai-agents-cli system deploy systems.yaml
   ai-agents-cli system deploy --file systems.yaml --dry-run
+  ai-agents-cli system deploy systems.yaml --parallel 5 --fail-fast
   ai-agents-cli system deploy --validate-only`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
+		// Ctrl-C отменяет развертывание: ресурсы, которые еще не начали
+		// развертываться, пропускаются
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
 		// Определяем файл конфигурации
 		configFile := systemDeployFile
@@ -76,6 +84,9 @@ var deployCmd = &cobra.Command{
 		// Создаем деплойер
 		systemDeployer := deployer.NewSystemDeployer(apiClient)
 
+		// Независимые ресурсы развертываются параллельно (--parallel или BULK_OPERATIONS_CONCURRENCY)
+		systemDeployer.SetParallel(deployer.ResolveParallelOptions(systemParallel, systemFailFast, container.GetConfig))
+
 		// Валидация конфигурации
 		fmt.Println(ui.FormatInfo("Validating configuration..."))
 		if err := systemDeployer.ValidateSystems(configFile); err != nil {
@@ -110,4 +121,6 @@ func init() {
 	deployCmd.Flags().StringVarP(&systemDeployFile, "file", "f", "", "Путь к файлу конфигурации")
 	deployCmd.Flags().BoolVarP(&systemDryRun, "dry-run", "d", false, "Режим предварительного просмотра без создания ресурсов")
 	deployCmd.Flags().BoolVar(&systemValidateOnly, "validate-only", false, "Только валидация конфигурации без развертывания")
+	deployCmd.Flags().IntVar(&systemParallel, "parallel", 0, "Максимальное количество ресурсов, развертываемых одновременно (по умолчанию BULK_OPERATIONS_CONCURRENCY)")
+	deployCmd.Flags().BoolVar(&systemFailFast, "fail-fast", false, "Не начинать развертывание остальных ресурсов после первой ошибки (начатые завершаются)")
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/errors"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
@@ -15,13 +17,32 @@ var (
 	systemOutputFormat string
 	systemLimit        int
 	systemOffset       int
+	systemName         string
+	systemStatuses     []string
+	systemNotStatuses  []string
+	systemSearch       string
+	systemCreatedBy    string
+	systemUsesAgents   []string
+	systemAll          bool
 )
 
 // listCmd represents the list command
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Просмотр списка систем агентов",
-	Long:  "Показывает список всех агентных систем с возможностью пагинации",
+	Long: `Показывает список всех агентных систем с возможностью пагинации.
+Флаг --all загружает все страницы списка, --limit при этом не учитывается,
+а --offset не допускается.
+
+Фильтры применяются на сервере. Флаги --status, --not-status и --uses-agent
+можно повторять или перечислять значения через запятую. Статус указывается
+без префикса AGENT_SYSTEM_STATUS_, агент — по имени или ID.
+
+Примеры использования:
+  ai-agents-cli system list
+  ai-agents-cli system list --status RUNNING --search support
+  ai-agents-cli system list --uses-agent my-agent --output json
+  ai-agents-cli system list --all --output json`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
@@ -44,9 +65,26 @@ var listCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		filter, err := systemListFilter(ctx, apiClient)
+		if err != nil {
+			appErr := errorHandler.WrapUserError(err, "SYSTEMS_LIST_FILTER_INVALID", "Некорректный фильтр списка систем")
+			fmt.Println(errorHandler.HandlePlain(appErr))
+			os.Exit(1)
+		}
+
 		if systemOutputFormat == "json" {
 			// Выводим в JSON формате
-			systems, err := apiClient.AgentSystems.List(ctx, systemLimit, systemOffset)
+			var systems *api.SearchAgentSystemResponse
+			if systemAll {
+				// Все страницы обходятся с начала страницами api.DefaultPageSize
+				params := filter
+				params.Limit, params.Offset = 0, 0
+				var data []api.AgentSystemPreview
+				data, err = api.Collect(apiClient.AgentSystems.All(ctx, &params))
+				systems = &api.SearchAgentSystemResponse{Data: data, Total: len(data)}
+			} else {
+				systems, err = apiClient.AgentSystems.SearchAgentSystem(ctx, &filter)
+			}
 			if err != nil {
 				appErr := errorHandler.WrapAPIError(err, "SYSTEMS_LIST_FAILED", "Ошибка получения списка систем")
 				appErr = appErr.WithSuggestions(
@@ -66,7 +104,7 @@ var listCmd = &cobra.Command{
 		}
 
 		// Показываем интерактивную таблицу
-		if err = ui.ShowAgentSystemsListFromAPI(ctx, systemLimit, systemOffset); err != nil {
+		if err = ui.ShowAgentSystemsListFromAPI(ctx, filter, systemAll); err != nil {
 			appErr := errorHandler.WrapAPIError(err, "SYSTEMS_TABLE_ERROR", "Ошибка отображения таблицы систем")
 			appErr = appErr.WithSuggestions(
 				"Проверьте переменные окружения: IAM_KEY_ID, IAM_SECRET_KEY, IAM_ENDPOINT",
@@ -81,10 +119,49 @@ var listCmd = &cobra.Command{
 	},
 }
 
+// systemListFilter собирает параметры поиска систем агентов из флагов команды
+func systemListFilter(ctx context.Context, apiClient *api.API) (api.SearchAgentSystemParams, error) {
+	filter := api.SearchAgentSystemParams{
+		Limit:     systemLimit,
+		Offset:    systemOffset,
+		Name:      systemName,
+		Search:    systemSearch,
+		CreatedBy: systemCreatedBy,
+	}
+
+	var err error
+	if filter.Statuses, err = api.ParseEnumValues(api.AgentSystemStatusValues, systemStatuses); err != nil {
+		return filter, fmt.Errorf("invalid --status: %w", err)
+	}
+	if filter.NotInStatuses, err = api.ParseEnumValues(api.AgentSystemStatusValues, systemNotStatuses); err != nil {
+		return filter, fmt.Errorf("invalid --not-status: %w", err)
+	}
+
+	// Фильтр принимает только ID, поэтому имена агентов разрешаем заранее
+	registry := deployer.NewRegistry(apiClient)
+	for _, ref := range systemUsesAgents {
+		id, err := registry.Resolve(ctx, deployer.KindAgent, ref)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --uses-agent: %w", err)
+		}
+		filter.UsesAgentIDs = append(filter.UsesAgentIDs, id)
+	}
+
+	return filter, nil
+}
+
 func init() {
 	RootCMD.AddCommand(listCmd)
 
 	listCmd.Flags().StringVarP(&systemOutputFormat, "output", "o", "table", "Формат вывода (table, json)")
 	listCmd.Flags().IntVarP(&systemLimit, "limit", "l", 20, "Количество систем на странице")
 	listCmd.Flags().IntVarP(&systemOffset, "offset", "", 0, "Смещение для пагинации")
+	listCmd.Flags().StringVar(&systemName, "name", "", "Фильтр по названию системы")
+	listCmd.Flags().StringSliceVar(&systemStatuses, "status", nil, "Показать системы только в указанных статусах")
+	listCmd.Flags().StringSliceVar(&systemNotStatuses, "not-status", nil, "Исключить системы в указанных статусах")
+	listCmd.Flags().StringVar(&systemSearch, "search", "", "Полнотекстовый поиск по системам")
+	listCmd.Flags().StringVar(&systemCreatedBy, "created-by", "", "Фильтр по ID создателя системы")
+	listCmd.Flags().StringSliceVar(&systemUsesAgents, "uses-agent", nil, "Показать системы, использующие агента (имя или ID)")
+	listCmd.Flags().BoolVar(&systemAll, "all", false, "Загрузить все страницы списка")
+	listCmd.MarkFlagsMutuallyExclusive("all", "offset")
 }
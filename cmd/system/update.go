@@ -36,7 +36,7 @@ var updateCmd = &cobra.Command{
 	}
 
 		// Парсим опции из JSON
-		var options map[string]interface{}
+		var options api.AgentSystemOptions
 		if systemUpdateOptions != "" {
 			if err := json.Unmarshal([]byte(systemUpdateOptions), &options); err != nil {
 				log.Fatal("Failed to parse options JSON", "error", err)
@@ -44,19 +44,29 @@ var updateCmd = &cobra.Command{
 		}
 
 		// Создаем запрос
-		req := &api.AgentSystemUpdateRequest{
+		var agents []api.AgentSystemAgent
+		for _, agentID := range systemUpdateAgents {
+			agents = append(agents, api.AgentSystemAgent{AgentID: agentID})
+		}
+
+		req := &api.UpdateAgentSystemRequest{
 			Name:        systemUpdateName,
 			Description: systemUpdateDescription,
-			Agents:      systemUpdateAgents,
+			Agents:      agents,
 			Options:     options,
 		}
 
 		// Обновляем систему
-		system, err := apiClient.AgentSystems.Update(ctx, systemID, req)
-		if err != nil {
+		if _, err := apiClient.AgentSystems.UpdateAgentSystem(ctx, systemID, req); err != nil {
 			log.Fatal("Failed to update system", "error", err, "system_id", systemID)
 		}
 
+		// API не возвращает систему в ответе на обновление
+		system, err := apiClient.AgentSystems.Get(ctx, systemID)
+		if err != nil {
+			log.Fatal("Failed to get updated system", "error", err, "system_id", systemID)
+		}
+
 		fmt.Printf("✅ Система агентов обновлена успешно!\n")
 		fmt.Printf("ID: %s\n", system.ID)
 		fmt.Printf("Название: %s\n", system.Name)
@@ -1,19 +1,30 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/deployer"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/di"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
 	"github.com/spf13/cobra"
 )
 
 var (
-	validateFile string
-	validateDir  string
+	validateFile         string
+	validateDir          string
+	validateShowResolved bool
+	validateOnline       bool
 )
 
 // validateCmd represents the validate command
@@ -34,28 +45,34 @@ var validateCmd = &cobra.Command{
 • MCP серверы (mcp-servers) 
 • Системы агентов (agent-systems)
 
+Если рядом с файлом конфигурации есть директория overlays, проверяется также
+результат наложения каждого оверлея окружения (overlays/<env>.yaml). При проверке
+директории оверлеи накладываются на файл, который выбрал бы deploy (ai-agents.yaml и др.).
+
+Значения вида ${VAR}, ${env:VAR:-default} и ${file:./prompt.md} подставляются
+после обработки !include; неопределенные переменные выводятся с файлом и строкой.
+С --show-resolved выводится итоговый документ после подстановки.
+
+После проверки схемы выполняются семантические проверки: ссылки агентов на
+MCP серверы и систем на агентов, уникальность имен, количество rawEnvs,
+согласованность orchestratorOptions с агентами системы. У каждого правила есть
+ID (AIA001...) и важность: ошибки делают конфигурацию невалидной, предупреждения
+только выводятся. С --online ссылки на ресурсы вне конфигурации проверяются в проекте.
+Ошибки схемы выводятся с файлом, строкой и столбцом значения, в том числе
+во включаемых файлах, и строкой файла с отметкой под значением.
+
 Примеры использования:
   ai-agents-cli validate examples/agents.yaml
+  ai-agents-cli validate ai-agents.yaml --var IMAGE_TAG=1.2.0 --show-resolved
   ai-agents-cli validate examples/
   ai-agents-cli validate --file config.yaml`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Info("Запуск валидации конфигурационных файлов")
 
-		// Создаем валидатор
-		configValidator := validator.NewConfigValidator()
-		log.Debug("Валидатор создан")
-
-		// Загружаем схемы
-		log.Info("Загрузка схем валидации")
-		if err := loadSchemas(configValidator); err != nil {
-			log.Warn("Failed to load schemas", "error", err)
-		} else {
-			log.Info("Схемы валидации загружены успешно")
-		}
-
-		// Определяем файлы для валидации
+		// Определяем файлы для валидации и базовый файл для оверлеев
 		var files []string
+		var overlayBase string
 
 		if len(args) > 0 {
 			// Проверяем, является ли аргумент директорией
@@ -65,11 +82,14 @@ var validateCmd = &cobra.Command{
 				if err != nil {
 					log.Fatal("Failed to find config files", "error", err, "dir", args[0])
 				}
+				overlayBase = defaultConfigFile(args[0])
 			} else {
 				files = []string{args[0]}
+				overlayBase = args[0]
 			}
 		} else if validateFile != "" {
 			files = []string{validateFile}
+			overlayBase = validateFile
 		} else if validateDir != "" {
 			// Находим все конфигурационные файлы в директории
 			var err error
@@ -77,6 +97,7 @@ var validateCmd = &cobra.Command{
 			if err != nil {
 				log.Fatal("Failed to find config files", "error", err, "dir", validateDir)
 			}
+			overlayBase = defaultConfigFile(validateDir)
 		} else {
 			// Ищем файлы в текущей директории
 			var err error
@@ -84,6 +105,7 @@ var validateCmd = &cobra.Command{
 			if err != nil {
 				log.Fatal("Failed to find config files", "error", err)
 			}
+			overlayBase = defaultConfigFile(".")
 		}
 
 		if len(files) == 0 {
@@ -101,6 +123,12 @@ var validateCmd = &cobra.Command{
 			Border(lipgloss.RoundedBorder()).
 			Padding(0, 1)
 
+		// С --online ссылки на ресурсы вне конфигурации проверяются в проекте
+		var project validator.Project
+		if validateOnline {
+			project = loadProjectIndex()
+		}
+
 		fmt.Println(headerStyle.Render("🔍 Валидация конфигурационных файлов"))
 		fmt.Println()
 
@@ -108,23 +136,36 @@ var validateCmd = &cobra.Command{
 			fmt.Printf("Проверка %s...\n", file)
 			log.Debug("Валидация файла", "file", file)
 
-			result, err := configValidator.ValidateFile(file)
+			// Обрабатываем includes и подставляем переменные
+			resolved, err := parser.ProcessYAMLDocument(file)
 			if err != nil {
-				log.Error("Ошибка при валидации файла", "file", file, "error", err)
+				log.Error("Ошибка при обработке файла", "file", file, "error", err)
+				fmt.Println(ui.FormatError(err.Error()))
 				allValid = false
+				fmt.Println()
 				continue
 			}
+			if validateShowResolved {
+				if err := printResolved(resolved.Config); err != nil {
+					log.Error("Не удалось вывести итоговый документ", "file", file, "error", err)
+				}
+			}
 
-			if !result.Valid {
-				log.Warn("Файл не прошел валидацию", "file", file, "errors", len(result.Errors))
-				allValid = false
-				configValidator.PrintErrors(result)
-			} else {
+			// Проверяем итоговый документ; ошибки указывают на строки исходных файлов
+			if validateDocument(resolved, project) {
 				log.Info("Файл валиден", "file", file)
+			} else {
+				log.Warn("Файл не прошел валидацию", "file", file)
+				allValid = false
 			}
 			fmt.Println()
 		}
 
+		// Проверяем результат наложения каждого оверлея окружения
+		if overlayBase != "" && !validateOverlays(overlayBase, project) {
+			allValid = false
+		}
+
 		// Выводим итоговый результат
 		if allValid {
 			fmt.Println(headerStyle.Copy().Foreground(lipgloss.Color("2")).Render("🎉 Все файлы валидны!"))
@@ -136,6 +177,125 @@ var validateCmd = &cobra.Command{
 	},
 }
 
+// validateOverlays проверяет результат наложения каждого оверлея окружения
+// на файл конфигурации и сообщает, все ли результаты валидны
+func validateOverlays(configFile string, project validator.Project) bool {
+	envs, err := parser.FindOverlays(configFile)
+	if err != nil {
+		log.Error("Ошибка при поиске оверлеев", "file", configFile, "error", err)
+		return false
+	}
+
+	valid := true
+	for _, env := range envs {
+		fmt.Printf("Проверка %s с оверлеем %s...\n", configFile, env)
+		log.Debug("Валидация оверлея", "file", configFile, "env", env)
+
+		processed, err := parser.ProcessYAMLFileWithOverlay(configFile, env)
+		if err != nil {
+			fmt.Println(ui.FormatError(err.Error()))
+		}
+		if err != nil || !validateDocument(processed, project) {
+			log.Warn("Оверлей не прошел валидацию", "file", configFile, "env", env)
+			valid = false
+		} else {
+			log.Info("Оверлей валиден", "file", configFile, "env", env)
+		}
+		fmt.Println()
+	}
+	return valid
+}
+
+// validateDocument проверяет итоговый документ по схеме, а затем
+// семантическими правилами, выводит нарушения и сообщает, валиден ли документ
+func validateDocument(doc *parser.Document, project validator.Project) bool {
+	if err := validator.ValidateDocument(doc); err != nil {
+		printValidationError(err)
+		return false
+	}
+
+	findings, err := validator.CheckSemantics(doc, project)
+	if err != nil {
+		fmt.Println(ui.FormatError(err.Error()))
+		return false
+	}
+	printFindings(findings)
+	return !validator.HasErrors(findings)
+}
+
+// printFindings выводит нарушения семантических правил с фрагментами исходных файлов
+func printFindings(findings []validator.Finding) {
+	for _, finding := range findings {
+		if finding.Rule.Severity == validator.SeverityError {
+			fmt.Println(ui.FormatError(finding.String()))
+		} else {
+			fmt.Println(ui.FormatWarning(finding.String()))
+		}
+		printExcerpt(finding.Position)
+	}
+}
+
+// loadProjectIndex загружает ресурсы проекта для проверки ссылок (--online)
+func loadProjectIndex() validator.Project {
+	apiClient, err := di.GetContainer().GetAPI()
+	if err != nil {
+		log.Fatal("Failed to get API client", "error", err)
+	}
+	index, err := deployer.LoadProjectIndex(context.Background(), apiClient)
+	if err != nil {
+		log.Fatal("Failed to load project resources", "error", err)
+	}
+	return index
+}
+
+// printExcerpt выводит строку исходного файла с отметкой под значением
+func printExcerpt(position parser.Position) {
+	if excerpt := position.Excerpt(); excerpt != "" {
+		for _, line := range strings.Split(excerpt, "\n") {
+			fmt.Println("    " + line)
+		}
+	}
+}
+
+// printValidationError выводит ошибку валидации. Для нарушений схемы
+// выводится строка исходного файла с отметкой под значением.
+func printValidationError(err error) {
+	var validationErr *validator.ValidationError
+	if !errors.As(err, &validationErr) {
+		fmt.Println(ui.FormatError(err.Error()))
+		return
+	}
+
+	for _, issue := range validationErr.Issues {
+		fmt.Println(ui.FormatError(issue.String()))
+		printExcerpt(issue.Position)
+	}
+}
+
+// printResolved выводит конфигурацию после обработки includes и подстановки
+// переменных. Расшифрованные значения заменяются на ***.
+func printResolved(config map[string]interface{}) error {
+	data, err := manifest.Marshal(config)
+	if err != nil {
+		return err
+	}
+	fmt.Println("---")
+	fmt.Print(secrets.Redact(string(data)))
+	return nil
+}
+
+// defaultConfigFile возвращает файл конфигурации, который deploy выбрал бы
+// в директории, или пустую строку
+func defaultConfigFile(dir string) string {
+	for _, name := range defaultConfigFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
 func loadSchemas(validator *validator.ConfigValidator) error {
 	schemas := map[string]string{
 		"mcp-servers":   "schemas/schema.json",
@@ -186,4 +346,6 @@ func init() {
 
 	validateCmd.Flags().StringVarP(&validateFile, "file", "f", "", "Файл для валидации")
 	validateCmd.Flags().StringVarP(&validateDir, "dir", "d", "", "Директория с файлами для валидации")
+	validateCmd.Flags().BoolVar(&validateShowResolved, "show-resolved", false, "Вывести итоговый документ после обработки !include и подстановки переменных")
+	validateCmd.Flags().BoolVar(&validateOnline, "online", false, "Проверять ссылки на ресурсы вне конфигурации в проекте")
 }
@@ -2,143 +2,89 @@ package api
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"iter"
 )
 
-// AgentSystem представляет систему агентов
-type AgentSystem struct {
-	ID                  string                 `json:"id"`
-	ProjectID           string                 `json:"projectId,omitempty"`
-	Name                string                 `json:"name"`
-	Description         string                 `json:"description"`
-	Status              string                 `json:"status"`
-	StatusReason        StatusReason           `json:"statusReason,omitempty"`
-	InstanceType        InstanceType           `json:"instanceType,omitempty"`
-	Agents              []AgentSystemAgent     `json:"agents,omitempty"`
-	OrchestratorOptions map[string]interface{} `json:"orchestratorOptions,omitempty"`
-	Options             map[string]interface{} `json:"options,omitempty"`
-	IntegrationOptions  map[string]interface{} `json:"integrationOptions,omitempty"`
-	PublicURL           string                 `json:"publicUrl,omitempty"`
-	CreatedAt           time.Time              `json:"createdAt"`
-	UpdatedAt           time.Time              `json:"updatedAt"`
-	CreatedBy           string                 `json:"createdBy,omitempty"`
-	UpdatedBy           string                 `json:"updatedBy,omitempty"`
-}
-
-// AgentSystemAgent представляет агента в системе
-type AgentSystemAgent struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Status string `json:"status,omitempty"`
-}
-
-// HistoryEntry представляет запись в истории
-type HistoryEntry struct {
-	ID        string    `json:"id"`
-	Action    string    `json:"action"`
-	Status    string    `json:"status"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
-	Timestamp time.Time `json:"timestamp"`
-	Level     string    `json:"level"`
-	Source    string    `json:"source"`
-}
-
-// AgentSystemCreateRequest представляет запрос на создание системы агентов
-type AgentSystemCreateRequest struct {
-	Name                string                 `json:"name"`
-	Description         string                 `json:"description,omitempty"`
-	InstanceTypeID      string                 `json:"instance_type_id,omitempty"`
-	OrchestratorOptions map[string]interface{} `json:"orchestratorOptions,omitempty"`
-	Options             map[string]interface{} `json:"options,omitempty"`
-	IntegrationOptions  map[string]interface{} `json:"integrationOptions,omitempty"`
-	Agents              []string               `json:"agents,omitempty"`
-}
-
-// AgentSystemUpdateRequest представляет запрос на обновление системы агентов
-type AgentSystemUpdateRequest struct {
-	Name                string                 `json:"name,omitempty"`
-	Description         string                 `json:"description,omitempty"`
-	InstanceTypeID      string                 `json:"instance_type_id,omitempty"`
-	OrchestratorOptions map[string]interface{} `json:"orchestratorOptions,omitempty"`
-	Options             map[string]interface{} `json:"options,omitempty"`
-	IntegrationOptions  map[string]interface{} `json:"integrationOptions,omitempty"`
-	Agents              []string               `json:"agents,omitempty"`
-}
-
-// AgentSystemListResponse представляет ответ со списком систем агентов
-type AgentSystemListResponse struct {
-	Data  []AgentSystem `json:"data"`
-	Total int           `json:"total"`
-}
-
-// AgentSystemService предоставляет методы для работы с системами агентов
+// AgentSystemService предоставляет методы для работы с системами агентов.
+// Операции спецификации доступны через встроенный сгенерированный сервис.
 type AgentSystemService struct {
-	client *Client
+	*AgentSystemManagementService
 }
 
 // NewAgentSystemService создает новый сервис для работы с системами агентов
 func NewAgentSystemService(client *Client) *AgentSystemService {
-	return &AgentSystemService{client: client}
+	return &AgentSystemService{AgentSystemManagementService: NewAgentSystemManagementService(client)}
 }
 
 // List возвращает список систем агентов
-func (s *AgentSystemService) List(ctx context.Context, limit, offset int) (*AgentSystemListResponse, error) {
-	query := map[string]string{
-		"limit":  fmt.Sprintf("%d", limit),
-		"offset": fmt.Sprintf("%d", offset),
-	}
+func (s *AgentSystemService) List(ctx context.Context, limit, offset int) (*SearchAgentSystemResponse, error) {
+	return s.SearchAgentSystem(ctx, &SearchAgentSystemParams{Limit: limit, Offset: offset})
+}
 
-	var result AgentSystemListResponse
-	err := s.client.Get(ctx, fmt.Sprintf("/api/v1/%s/agentSystems", s.client.projectID), query, &result)
-	return &result, err
+// All возвращает итератор по всем системам агентов, подходящим под фильтры
+// params. Страницы размером params.Limit (по умолчанию DefaultPageSize)
+// загружаются по мере обхода. params может быть nil.
+func (s *AgentSystemService) All(ctx context.Context, params *SearchAgentSystemParams) iter.Seq2[AgentSystemPreview, error] {
+	var filter SearchAgentSystemParams
+	if params != nil {
+		filter = *params
+	}
+	return paginate(ctx, filter.Limit, filter.Offset, func(ctx context.Context, limit, offset int) ([]AgentSystemPreview, int, error) {
+		filter.Limit, filter.Offset = limit, offset
+		page, err := s.SearchAgentSystem(ctx, &filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Data, page.Total, nil
+	})
 }
 
 // Get возвращает информацию о конкретной системе агентов
 func (s *AgentSystemService) Get(ctx context.Context, systemID string) (*AgentSystem, error) {
-	var result AgentSystem
-	err := s.client.Get(ctx, fmt.Sprintf("/api/v1/%s/agentSystems/%s", s.client.projectID, systemID), nil, &result)
-	return &result, err
+	result, err := s.GetAgentSystem(ctx, systemID)
+	if err != nil {
+		return nil, err
+	}
+	return &result.AgentSystem, nil
 }
 
-// Create создает новую систему агентов
-func (s *AgentSystemService) Create(ctx context.Context, req *AgentSystemCreateRequest) (*AgentSystem, error) {
-	var result AgentSystem
-	err := s.client.Post(ctx, fmt.Sprintf("/api/v1/%s/agentSystems", s.client.projectID), req, &result)
-	return &result, err
+// Delete удаляет систему агентов
+func (s *AgentSystemService) Delete(ctx context.Context, systemID string) error {
+	_, err := s.DeleteAgentSystem(ctx, systemID)
+	return err
 }
 
-// Update обновляет существующую систему агентов
-func (s *AgentSystemService) Update(ctx context.Context, systemID string, req *AgentSystemUpdateRequest) (*AgentSystem, error) {
-	var result AgentSystem
-	err := s.client.Put(ctx, fmt.Sprintf("/api/v1/%s/agentSystems/%s", s.client.projectID, systemID), req, &result)
-	return &result, err
+// DeleteMany удаляет несколько систем агентов одним запросом (максимум 100)
+func (s *AgentSystemService) DeleteMany(ctx context.Context, systemIDs []string) error {
+	_, err := s.BulkDeleteAgentSystem(ctx, &BulkDeleteAgentSystemParams{AgentSystemIDs: systemIDs})
+	return err
 }
 
-// Delete удаляет систему агентов
-func (s *AgentSystemService) Delete(ctx context.Context, systemID string) error {
-	return s.client.Delete(ctx, fmt.Sprintf("/api/v1/%s/agentSystems/%s", s.client.projectID, systemID), nil)
+// AddAgent добавляет существующего агента в систему агентов
+func (s *AgentSystemService) AddAgent(ctx context.Context, systemID, agentID string) error {
+	_, err := s.AddAgentToAgentSystem(ctx, systemID, agentID)
+	return err
 }
 
-// GetHistory возвращает историю системы агентов
-func (s *AgentSystemService) GetHistory(ctx context.Context, systemID string, limit, offset int) (*AgentSystemListResponse, error) {
-	query := map[string]string{
-		"limit":  fmt.Sprintf("%d", limit),
-		"offset": fmt.Sprintf("%d", offset),
-	}
+// RemoveAgent удаляет агента из системы агентов
+func (s *AgentSystemService) RemoveAgent(ctx context.Context, systemID, agentID string) error {
+	_, err := s.DeleteAgentFromAgentSystem(ctx, systemID, agentID)
+	return err
+}
 
-	var result AgentSystemListResponse
-	err := s.client.Get(ctx, fmt.Sprintf("/api/v1/%s/agentSystems/%s/history", s.client.projectID, systemID), query, &result)
-	return &result, err
+// GetHistory возвращает историю изменений системы агентов
+func (s *AgentSystemService) GetHistory(ctx context.Context, systemID string, limit, offset int) (*SearchAgentSystemHistoryResponse, error) {
+	return s.SearchAgentSystemHistory(ctx, systemID, &SearchAgentSystemHistoryParams{Limit: limit, Offset: offset})
 }
 
 // Resume возобновляет работу системы агентов
 func (s *AgentSystemService) Resume(ctx context.Context, systemID string) error {
-	return s.client.Post(ctx, fmt.Sprintf("/api/v1/%s/agentSystems/%s/resume", s.client.projectID, systemID), nil, nil)
+	_, err := s.ResumeAgentSystem(ctx, systemID, nil)
+	return err
 }
 
 // Suspend приостанавливает работу системы агентов
 func (s *AgentSystemService) Suspend(ctx context.Context, systemID string) error {
-	return s.client.Post(ctx, fmt.Sprintf("/api/v1/%s/agentSystems/%s/suspend", s.client.projectID, systemID), nil, nil)
+	_, err := s.SuspendAgentSystem(ctx, systemID, nil)
+	return err
 }
@@ -1,3 +1,7 @@
+// Модели, перечисления и операции API (models_gen.go, operations_gen.go)
+// генерируются из спецификации service.swagger.json командой swaggergen.
+//
+//go:generate go run ../tools/swaggergen -spec ../../service.swagger.json -out .
 package api
 
 import (
@@ -6,12 +10,14 @@ import (
 
 // API представляет основной API клиент со всеми сервисами
 type API struct {
-	Client       *Client
-	MCPServers   *MCPServerService
-	Agents       *AgentService
-	AgentSystems *AgentSystemService
-	Users        *UserService
-	Registries   *RegistryService
+	Client        *Client
+	MCPServers    *MCPServerService
+	Agents        *AgentService
+	AgentSystems  *AgentSystemService
+	InstanceTypes *InstanceTypeService
+	Projects      *ProjectManagementService
+	Users         *UserService
+	Registries    *RegistryService
 }
 
 // NewAPI создает новый экземпляр API с всеми сервисами
@@ -19,11 +25,13 @@ func NewAPI(baseURL, projectID string, authService auth.IAMAuthServiceInterface)
 	client := NewClient(baseURL, projectID, authService)
 
 	return &API{
-		Client:       client,
-		MCPServers:   NewMCPServerService(client),
-		Agents:       NewAgentService(client),
-		AgentSystems: NewAgentSystemService(client),
-		Users:        NewUserService(client),
-		Registries:   NewRegistryService(client),
+		Client:        client,
+		MCPServers:    NewMCPServerService(client),
+		Agents:        NewAgentService(client),
+		AgentSystems:  NewAgentSystemService(client),
+		InstanceTypes: NewInstanceTypeService(client),
+		Projects:      NewProjectManagementService(client),
+		Users:         NewUserService(client),
+		Registries:    NewRegistryService(client),
 	}
 }
@@ -33,7 +33,7 @@ func TestNewAPI(t *testing.T) {
 		t.Errorf("Expected MCPServers to use the same client")
 	}
 
-	if api.Agents.client != api.Client {
+	if api.Agents.AgentManagementService.client != api.Client || api.Agents.MarketplaceAgentManagementService.client != api.Client {
 		t.Errorf("Expected Agents to use the same client")
 	}
 
@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/auth"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
 )
 
 // AuthenticationError представляет ошибку аутентификации
@@ -25,12 +29,30 @@ func (e *AuthenticationError) Error() string {
 	return fmt.Sprintf("%s (статус %d): %s", e.Message, e.StatusCode, e.Details)
 }
 
+// APIError представляет ошибку, возвращенную API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error возвращает строковое представление ошибки
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound проверяет, что API вернул 404 - ресурс не найден
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 // Client представляет HTTP клиент для работы с AI Agents API
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	projectID  string
 	auth       auth.IAMAuthServiceInterface
+	retry      RetryPolicy
 }
 
 // NewClient создает новый экземпляр API клиента с IAM аутентификацией
@@ -42,45 +64,103 @@ func NewClient(baseURL, projectID string, authService auth.IAMAuthServiceInterfa
 		},
 		projectID: projectID,
 		auth:      authService,
+		retry:     DefaultRetryPolicy(),
 	}
 }
 
+// SetRetryPolicy задает политику повторов запросов при временных ошибках
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
 // RequestOptions содержит опции для HTTP запроса
 type RequestOptions struct {
 	Method  string
 	Path    string
 	Body    interface{}
 	Headers map[string]string
-	Query   map[string]string
+	// Query - параметры запроса. Списки передаются повторением
+	// параметра: statuses=A&statuses=B
+	Query url.Values
+	// Retry разрешает повторять неидемпотентный запрос (POST, PATCH) при
+	// временных ошибках. Идемпотентные запросы повторяются всегда.
+	Retry bool
 }
 
-// doRequest выполняет HTTP запрос к API
+// doRequest выполняет HTTP запрос к API, повторяя его при временных ошибках
+// по политике повторов клиента
 func (c *Client) doRequest(ctx context.Context, opts RequestOptions) (*http.Response, error) {
-	url := c.baseURL + opts.Path
-
-	// Добавляем query параметры
-	if len(opts.Query) > 0 {
-		url += "?"
-		first := true
-		for key, value := range opts.Query {
-			if !first {
-				url += "&"
-			}
-			url += fmt.Sprintf("%s=%s", key, value)
-			first = false
+	requestURL := c.baseURL + opts.Path
+
+	// Добавляем query параметры, путь может уже содержать часть из них
+	if query := opts.Query.Encode(); query != "" {
+		if strings.Contains(opts.Path, "?") {
+			requestURL += "&" + query
+		} else {
+			requestURL += "?" + query
 		}
 	}
 
-	var body io.Reader
+	var jsonData []byte
 	if opts.Body != nil {
-		jsonData, err := json.Marshal(opts.Body)
+		var err error
+		jsonData, err = json.Marshal(opts.Body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		body = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, opts.Method, url, body)
+	policy := c.retry
+	if !idempotentMethods[opts.Method] && !opts.Retry {
+		policy.MaxAttempts = 1
+	}
+
+	reauthenticated := false
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, opts, requestURL, jsonData)
+
+		// Токен мог быть отозван или истечь на сервере раньше срока из кэша:
+		// получаем новый токен и повторяем запрос один раз. Запрос с 401 не
+		// выполнялся, поэтому повтор безопасен для любого метода.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.auth != nil && !reauthenticated {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			log.Warn("API rejected auth token, requesting a new one", "method", opts.Method, "url", requestURL)
+			c.auth.ClearToken()
+			reauthenticated = true
+			attempt--
+			continue
+		}
+
+		delay, reason, retry := policy.retryDelay(ctx, resp, err, attempt)
+		if !retry {
+			if err != nil {
+				return nil, err
+			}
+			log.Debug("API request completed", "status", resp.StatusCode, "url", requestURL)
+			return resp, nil
+		}
+
+		if resp != nil {
+			// Тело ответа не нужно, соединение возвращается в пул
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Warn("Retrying API request", "method", opts.Method, "url", requestURL, "attempt", attempt+1, "max_attempts", policy.MaxAttempts, "delay", delay, "reason", reason)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+	}
+}
+
+// send выполняет одну попытку HTTP запроса
+func (c *Client) send(ctx context.Context, opts RequestOptions, requestURL string, jsonData []byte) (*http.Response, error) {
+	var body io.Reader
+	if jsonData != nil {
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, opts.Method, requestURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -104,15 +184,13 @@ func (c *Client) doRequest(ctx context.Context, opts RequestOptions) (*http.Resp
 		req.Header.Set(key, value)
 	}
 
-	log.Debug("Making API request", "method", opts.Method, "url", url, "headers", req.Header)
+	log.Debug("Making API request", "method", opts.Method, "url", requestURL, "headers", req.Header)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		log.Error("Failed to execute API request", "error", err, "url", url)
+		log.Error("Failed to execute API request", "error", err, "url", requestURL)
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
-
-	log.Debug("API request completed", "status", resp.StatusCode, "url", url)
 	return resp, nil
 }
 
@@ -129,16 +207,23 @@ func (c *Client) parseResponse(resp *http.Response, target interface{}) error {
 	log.Debug("API response received", "status", resp.StatusCode, "body_length", len(body))
 
 	if resp.StatusCode >= 400 {
-		log.Error("API error response", "status", resp.StatusCode, "body", string(body))
+		log.Error("API error response", "status", resp.StatusCode, "body", secrets.Redact(string(body)))
 
 		// Проверяем на ошибки аутентификации
-		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		if resp.StatusCode == http.StatusUnauthorized {
 			return &AuthenticationError{
 				StatusCode: resp.StatusCode,
 				Message:    "Ошибка аутентификации",
 				Details:    "Проверьте настройки аутентификации и попробуйте снова",
 			}
 		}
+		if resp.StatusCode == http.StatusForbidden {
+			return &AuthenticationError{
+				StatusCode: resp.StatusCode,
+				Message:    "Доступ запрещен",
+				Details:    "У сервисного аккаунта нет прав на эту операцию в проекте",
+			}
+		}
 
 		var errorResp struct {
 			Error struct {
@@ -148,21 +233,22 @@ func (c *Client) parseResponse(resp *http.Response, target interface{}) error {
 		}
 
 		if err := json.Unmarshal(body, &errorResp); err != nil {
-			log.Error("Failed to parse error response", "error", err, "body", string(body))
-			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+			log.Error("Failed to parse error response", "error", err, "body", secrets.Redact(string(body)))
+			return &APIError{StatusCode: resp.StatusCode, Message: secrets.Redact(string(body))}
 		}
 
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, errorResp.Error.Message)
+		return &APIError{StatusCode: resp.StatusCode, Message: errorResp.Error.Message}
 	}
 
-	if target != nil {
+	// Ответы без тела (204 No Content) оставляют target пустым
+	if target != nil && len(bytes.TrimSpace(body)) > 0 {
 		// Отладочный вывод для агентов
 		if len(body) > 0 && len(body) < 1000 {
-			log.Debug("Raw response body", "body", string(body))
+			log.Debug("Raw response body", "body", secrets.Redact(string(body)))
 		}
 
 		if err := json.Unmarshal(body, target); err != nil {
-			log.Error("Failed to parse response JSON", "error", err, "body", string(body))
+			log.Error("Failed to parse response JSON", "error", err, "body", secrets.Redact(string(body)))
 			return fmt.Errorf("failed to parse response: %w", err)
 		}
 		log.Debug("Response parsed successfully")
@@ -172,7 +258,7 @@ func (c *Client) parseResponse(resp *http.Response, target interface{}) error {
 }
 
 // Get выполняет GET запрос
-func (c *Client) Get(ctx context.Context, path string, query map[string]string, result interface{}) error {
+func (c *Client) Get(ctx context.Context, path string, query url.Values, result interface{}) error {
 	resp, err := c.doRequest(ctx, RequestOptions{
 		Method: "GET",
 		Path:   path,
@@ -216,6 +302,21 @@ func (c *Client) Put(ctx context.Context, path string, body interface{}, result
 	return c.parseResponse(resp, result)
 }
 
+// Patch выполняет PATCH запрос
+func (c *Client) Patch(ctx context.Context, path string, body interface{}, result interface{}) error {
+	resp, err := c.doRequest(ctx, RequestOptions{
+		Method: "PATCH",
+		Path:   path,
+		Body:   body,
+	})
+	if err != nil {
+		log.Error("Failed to execute PATCH request", "error", err, "url", fmt.Sprintf("%s%s", c.baseURL, path))
+		return err
+	}
+
+	return c.parseResponse(resp, result)
+}
+
 // Delete выполняет DELETE запрос
 func (c *Client) Delete(ctx context.Context, path string, result interface{}) error {
 	resp, err := c.doRequest(ctx, RequestOptions{
@@ -229,3 +330,15 @@ func (c *Client) Delete(ctx context.Context, path string, result interface{}) er
 
 	return c.parseResponse(resp, result)
 }
+
+// Do выполняет запрос с опциями opts и парсит ответ в result. Используется
+// для запросов, которым нужны опции, например Retry для идемпотентного POST.
+func (c *Client) Do(ctx context.Context, opts RequestOptions, result interface{}) error {
+	resp, err := c.doRequest(ctx, opts)
+	if err != nil {
+		log.Error("Failed to execute request", "error", err, "method", opts.Method, "url", fmt.Sprintf("%s%s", c.baseURL, opts.Path))
+		return err
+	}
+
+	return c.parseResponse(resp, result)
+}
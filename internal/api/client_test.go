@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"testing"
 	"time"
 
@@ -70,7 +72,7 @@ func TestClient_doRequest(t *testing.T) {
 	resp, err := client.doRequest(context.Background(), RequestOptions{
 		Method: "GET",
 		Path:   "/test/path",
-		Query:  map[string]string{"param1": "value1"},
+		Query:  url.Values{"param1": {"value1"}},
 	})
 
 	if err != nil {
@@ -84,6 +86,41 @@ func TestClient_doRequest(t *testing.T) {
 	resp.Body.Close()
 }
 
+func TestClient_doRequest_QueryEncoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		// Специальные символы кодируются и не разбивают параметр
+		if got := query.Get("search"); got != "support & sales" {
+			t.Errorf("Expected search 'support & sales', got '%s'", got)
+		}
+		// Повторяющиеся параметры передаются все, а не только последний
+		if got := query["tags"]; !reflect.DeepEqual(got, []string{"a", "b"}) {
+			t.Errorf("Expected tags [a b], got %v", got)
+		}
+		// Параметры из пути сохраняются
+		if got := query.Get("fixed"); got != "1" {
+			t.Errorf("Expected fixed=1 from path, got '%s'", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mockAuth := &MockIAMService{token: "test-token"}
+	client := NewClient(server.URL, "test-project", mockAuth)
+
+	resp, err := client.doRequest(context.Background(), RequestOptions{
+		Method: "GET",
+		Path:   "/test?fixed=1",
+		Query:  url.Values{"search": {"support & sales"}, "tags": {"a", "b"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+}
+
 func TestClient_doRequest_WithBody(t *testing.T) {
 	// Создаем тестовый сервер
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -219,7 +256,7 @@ func TestClient_Get(t *testing.T) {
 	client := NewClient(server.URL, "test-project", mockAuth)
 
 	var result map[string]string
-	err := client.Get(context.Background(), "/test", map[string]string{"param": "value"}, &result)
+	err := client.Get(context.Background(), "/test", url.Values{"param": {"value"}}, &result)
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
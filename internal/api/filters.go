@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseEnumValues преобразует значения фильтра из командной строки в значения
+// перечисления. Значение можно указать полностью (AGENT_STATUS_RUNNING) или
+// без общего префикса перечисления в любом регистре (running, image-unavailable).
+func ParseEnumValues[T ~string](all []T, values []string) ([]T, error) {
+	prefix := enumValuePrefix(all)
+
+	result := make([]T, 0, len(values))
+	for _, value := range values {
+		name := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), "-", "_"))
+
+		found := false
+		for _, candidate := range all {
+			if string(candidate) == name || string(candidate) == prefix+name {
+				result = append(result, candidate)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown value %q, expected one of: %s", value, enumValueNames(all, prefix))
+		}
+	}
+	return result, nil
+}
+
+// enumValuePrefix возвращает общий префикс значений перечисления до последнего
+// разделителя: AGENT_STATUS_ для AGENT_STATUS_RUNNING и AGENT_STATUS_FAILED
+func enumValuePrefix[T ~string](all []T) string {
+	if len(all) < 2 {
+		return ""
+	}
+	prefix := string(all[0])
+	for _, value := range all[1:] {
+		for !strings.HasPrefix(string(value), prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix[:strings.LastIndex(prefix, "_")+1]
+}
+
+// enumValueNames возвращает короткие имена значений перечисления для сообщения об ошибке
+func enumValueNames[T ~string](all []T, prefix string) string {
+	names := make([]string, 0, len(all))
+	for _, value := range all {
+		names = append(names, strings.TrimPrefix(string(value), prefix))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEnumValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []AgentStatus
+		wantErr bool
+	}{
+		{
+			name:   "short names in any case",
+			values: []string{"RUNNING", "cooled"},
+			want:   []AgentStatus{AgentStatusRunning, AgentStatusCooled},
+		},
+		{
+			name:   "dashes instead of underscores",
+			values: []string{"image-unavailable"},
+			want:   []AgentStatus{AgentStatusImageUnavailable},
+		},
+		{
+			name:   "full enum value",
+			values: []string{"AGENT_STATUS_FAILED"},
+			want:   []AgentStatus{AgentStatusFailed},
+		},
+		{
+			name:    "unknown value",
+			values:  []string{"BROKEN"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEnumValues(AgentStatusValues, tt.values)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got %v", got)
+				}
+				// В ошибке перечислены допустимые значения без префикса
+				if !strings.Contains(err.Error(), "RUNNING") || strings.Contains(err.Error(), "AGENT_STATUS_RUNNING") {
+					t.Errorf("Expected short names in error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// InstanceTypeService предоставляет методы для работы с типами вычислительных
+// конфигураций. Операции спецификации доступны через встроенный сервис.
+type InstanceTypeService struct {
+	*CommonService
+}
+
+// NewInstanceTypeService создает новый сервис для работы с типами конфигураций
+func NewInstanceTypeService(client *Client) *InstanceTypeService {
+	return &InstanceTypeService{CommonService: NewCommonService(client)}
+}
+
+// List возвращает список типов конфигураций
+func (s *InstanceTypeService) List(ctx context.Context, limit, offset int) (*SearchInstanceTypeResponse, error) {
+	return s.SearchInstanceTypes(ctx, &SearchInstanceTypesParams{Limit: limit, Offset: offset})
+}
+
+// All возвращает итератор по всем типам конфигураций, подходящим под фильтры
+// params. Страницы размером params.Limit (по умолчанию DefaultPageSize)
+// загружаются по мере обхода. params может быть nil.
+func (s *InstanceTypeService) All(ctx context.Context, params *SearchInstanceTypesParams) iter.Seq2[InstanceType, error] {
+	var filter SearchInstanceTypesParams
+	if params != nil {
+		filter = *params
+	}
+	return paginate(ctx, filter.Limit, filter.Offset, func(ctx context.Context, limit, offset int) ([]InstanceType, int, error) {
+		filter.Limit, filter.Offset = limit, offset
+		page, err := s.SearchInstanceTypes(ctx, &filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Data, page.Total, nil
+	})
+}
+
+// Get возвращает информацию о конкретном типе конфигурации
+func (s *InstanceTypeService) Get(ctx context.Context, instanceTypeID string) (*InstanceType, error) {
+	result, err := s.GetInstanceType(ctx, instanceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	return &result.InstanceType, nil
+}
@@ -3,130 +3,94 @@ package api
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
-// MCPServer представляет MCP сервер
-type MCPServer struct {
-	ID           string                 `json:"id"`
-	Name         string                 `json:"name"`
-	Description  string                 `json:"description"`
-	Status       string                 `json:"status"`
-	StatusReason StatusReason           `json:"statusReason,omitempty"`
-	InstanceType InstanceType           `json:"instanceType,omitempty"`
-	ImageSource  map[string]interface{} `json:"imageSource,omitempty"`
-	Options      map[string]interface{} `json:"options,omitempty"`
-	Tools        []Tool                 `json:"tools,omitempty"`
-	PublicURL    string                 `json:"publicUrl,omitempty"`
-	CreatedAt    CustomTime             `json:"createdAt"`
-	UpdatedAt    CustomTime             `json:"updatedAt"`
-	CreatedBy    string                 `json:"createdBy,omitempty"`
-	UpdatedBy    string                 `json:"updatedBy,omitempty"`
-}
-
-// Tool представляет инструмент MCP сервера
-type Tool struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	InputSchema map[string]interface{} `json:"input_schema"`
-}
-
-// MCPServerCreateRequest представляет запрос на создание MCP сервера
-type MCPServerCreateRequest struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description,omitempty"`
-	Options     map[string]interface{} `json:"options"`
-}
-
-// MCPServerUpdateRequest представляет запрос на обновление MCP сервера
-type MCPServerUpdateRequest struct {
-	Name        string                 `json:"name,omitempty"`
-	Description string                 `json:"description,omitempty"`
-	Options     map[string]interface{} `json:"options,omitempty"`
-}
-
-// MCPServerListResponse представляет ответ со списком MCP серверов
-type MCPServerListResponse struct {
-	Data  []MCPServer `json:"data"`
-	Total int         `json:"total"`
-}
-
-// MCPServerHistoryResponse представляет ответ с историей MCP сервера
-type MCPServerHistoryResponse struct {
-	Data []HistoryEntry `json:"data"`
-}
-
-// MCPServerService предоставляет методы для работы с MCP серверами
+// MCPServerService предоставляет методы для работы с MCP серверами. Операции
+// спецификации доступны через встроенные сгенерированные сервисы.
 type MCPServerService struct {
+	*MCPServerManagementService
+	*MarketplaceMCPServerManagementService
 	client *Client
 }
 
 // NewMCPServerService создает новый сервис для работы с MCP серверами
 func NewMCPServerService(client *Client) *MCPServerService {
-	return &MCPServerService{client: client}
+	return &MCPServerService{
+		MCPServerManagementService:            NewMCPServerManagementService(client),
+		MarketplaceMCPServerManagementService: NewMarketplaceMCPServerManagementService(client),
+		client:                                client,
+	}
 }
 
 // List возвращает список MCP серверов
-func (s *MCPServerService) List(ctx context.Context, limit, offset int) (*MCPServerListResponse, error) {
-	query := map[string]string{
-		"limit":  fmt.Sprintf("%d", limit),
-		"offset": fmt.Sprintf("%d", offset),
-	}
+func (s *MCPServerService) List(ctx context.Context, limit, offset int) (*SearchMCPServerResponse, error) {
+	return s.SearchMCPServer(ctx, &SearchMCPServerParams{Limit: limit, Offset: offset})
+}
 
-	var result MCPServerListResponse
-	err := s.client.Get(ctx, fmt.Sprintf("/api/v1/%s/mcpServers", s.client.projectID), query, &result)
-	return &result, err
+// All возвращает итератор по всем MCP серверам, подходящим под фильтры params.
+// Страницы размером params.Limit (по умолчанию DefaultPageSize) загружаются
+// по мере обхода. params может быть nil.
+func (s *MCPServerService) All(ctx context.Context, params *SearchMCPServerParams) iter.Seq2[MCPServerPreview, error] {
+	var filter SearchMCPServerParams
+	if params != nil {
+		filter = *params
+	}
+	return paginate(ctx, filter.Limit, filter.Offset, func(ctx context.Context, limit, offset int) ([]MCPServerPreview, int, error) {
+		filter.Limit, filter.Offset = limit, offset
+		page, err := s.SearchMCPServer(ctx, &filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Data, page.Total, nil
+	})
 }
 
 // Get возвращает информацию о конкретном MCP сервере
 func (s *MCPServerService) Get(ctx context.Context, serverID string) (*MCPServer, error) {
-	var result MCPServer
-	err := s.client.Get(ctx, fmt.Sprintf("/api/v1/%s/mcpServers/%s", s.client.projectID, serverID), nil, &result)
-	return &result, err
-}
-
-// Create создает новый MCP сервер
-func (s *MCPServerService) Create(ctx context.Context, req *MCPServerCreateRequest) (*MCPServer, error) {
-	var result MCPServer
-	err := s.client.Post(ctx, fmt.Sprintf("/api/v1/%s/mcpServers", s.client.projectID), req, &result)
-	return &result, err
-}
-
-// Update обновляет существующий MCP сервер
-func (s *MCPServerService) Update(ctx context.Context, serverID string, req *MCPServerUpdateRequest) (*MCPServer, error) {
-	var result MCPServer
-	err := s.client.Put(ctx, fmt.Sprintf("/api/v1/%s/mcpServers/%s", s.client.projectID, serverID), req, &result)
-	return &result, err
+	result, err := s.GetMCPServer(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return &result.MCPServer, nil
 }
 
 // Delete удаляет MCP сервер
 func (s *MCPServerService) Delete(ctx context.Context, serverID string) error {
-	return s.client.Delete(ctx, fmt.Sprintf("/api/v1/%s/mcpServers/%s", s.client.projectID, serverID), nil)
+	_, err := s.DeleteMCPServer(ctx, serverID)
+	return err
+}
+
+// DeleteMany удаляет несколько MCP серверов одним запросом (максимум 100)
+func (s *MCPServerService) DeleteMany(ctx context.Context, serverIDs []string) error {
+	_, err := s.BulkDeleteMCPServer(ctx, &BulkDeleteMCPServerParams{MCPServerIDs: serverIDs})
+	return err
 }
 
 // Resume возобновляет работу MCP сервера
 func (s *MCPServerService) Resume(ctx context.Context, serverID string) error {
-	return s.client.Post(ctx, fmt.Sprintf("/api/v1/%s/mcpServers/resume/%s", s.client.projectID, serverID), nil, nil)
+	_, err := s.ResumeMCPServer(ctx, serverID, nil)
+	return err
 }
 
 // Suspend приостанавливает работу MCP сервера
 func (s *MCPServerService) Suspend(ctx context.Context, serverID string) error {
-	return s.client.Post(ctx, fmt.Sprintf("/api/v1/%s/mcpServers/suspend/%s", s.client.projectID, serverID), nil, nil)
+	_, err := s.SuspendMCPServer(ctx, serverID, nil)
+	return err
 }
 
-// GetHistory возвращает историю операций MCP сервера
-func (s *MCPServerService) GetHistory(ctx context.Context, serverID string) (*MCPServerHistoryResponse, error) {
-	var result MCPServerHistoryResponse
-	err := s.client.Get(ctx, fmt.Sprintf("/api/v1/%s/mcpServers/%s/history", s.client.projectID, serverID), nil, &result)
-	return &result, err
+// GetHistory возвращает историю изменений MCP сервера
+func (s *MCPServerService) GetHistory(ctx context.Context, serverID string) (*SearchMCPServerHistoryResponse, error) {
+	return s.SearchMCPServerHistory(ctx, serverID, nil)
 }
 
 // GetTools возвращает список инструментов MCP сервера
 func (s *MCPServerService) GetTools(ctx context.Context, serverID string) ([]Tool, error) {
-	var result struct {
-		Tools []Tool `json:"tools"`
+	server, err := s.Get(ctx, serverID)
+	if err != nil {
+		return nil, err
 	}
-	err := s.client.Get(ctx, fmt.Sprintf("/api/v1/%s/mcpServers/%s/tools", s.client.projectID, serverID), nil, &result)
-	return result.Tools, err
+	return server.Tools, nil
 }
 
 // ExecuteTool выполняет инструмент MCP сервера
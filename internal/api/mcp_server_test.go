@@ -22,21 +22,20 @@ func TestMCPServerService_List(t *testing.T) {
 		if r.URL.Query().Get("limit") != "10" {
 			t.Errorf("Expected limit=10, got %s", r.URL.Query().Get("limit"))
 		}
-		if r.URL.Query().Get("offset") != "0" {
-			t.Errorf("Expected offset=0, got %s", r.URL.Query().Get("offset"))
+		// Нулевые параметры не передаются, как в protobuf JSON
+		if r.URL.Query().Has("offset") {
+			t.Errorf("Expected no offset for zero value, got %s", r.URL.Query().Get("offset"))
 		}
 
 		// Отправляем ответ
-		response := MCPServerListResponse{
-			Data: []MCPServer{
+		response := SearchMCPServerResponse{
+			Data: []MCPServerPreview{
 				{
-					ID:          "1",
-					Name:        "test-server",
-					Description: "Test MCP server",
-					Status:      "active",
-					CreatedAt:   CustomTime{Time: time.Now()},
-					UpdatedAt:   CustomTime{Time: time.Now()},
-					Options:     map[string]interface{}{"host": "localhost"},
+					ID:        "1",
+					Name:      "test-server",
+					Status:    MCPServerStatusRunning,
+					CreatedAt: CustomTime{Time: time.Now()},
+					UpdatedAt: CustomTime{Time: time.Now()},
 				},
 			},
 			Total: 1,
@@ -78,18 +77,19 @@ func TestMCPServerService_Get(t *testing.T) {
 			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
 		}
 
-		server := MCPServer{
-			ID:          "test-id",
-			Name:        "test-server",
-			Description: "Test MCP server",
-			Status:      "active",
-			CreatedAt:   CustomTime{Time: time.Now()},
-			UpdatedAt:   CustomTime{Time: time.Now()},
-			Options:     map[string]interface{}{"host": "localhost"},
+		response := GetMCPServerResponse{
+			MCPServer: MCPServer{
+				ID:          "test-id",
+				Name:        "test-server",
+				Description: "Test MCP server",
+				Status:      MCPServerStatusRunning,
+				CreatedAt:   CustomTime{Time: time.Now()},
+				UpdatedAt:   CustomTime{Time: time.Now()},
+			},
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(server)
+		json.NewEncoder(w).Encode(response)
 	}))
 	defer server.Close()
 
@@ -123,7 +123,7 @@ func TestMCPServerService_Create(t *testing.T) {
 		}
 
 		// Проверяем тело запроса
-		var req MCPServerCreateRequest
+		var req CreateMCPServerRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			t.Errorf("Failed to decode request body: %v", err)
 		}
@@ -131,21 +131,14 @@ func TestMCPServerService_Create(t *testing.T) {
 		if req.Name != "new-server" {
 			t.Errorf("Expected name 'new-server', got '%s'", req.Name)
 		}
-
-		// Отправляем ответ
-		createdServer := MCPServer{
-			ID:          "new-id",
-			Name:        req.Name,
-			Description: req.Description,
-			Status:      "active",
-			CreatedAt:   CustomTime{Time: time.Now()},
-			UpdatedAt:   CustomTime{Time: time.Now()},
-			Options:     req.Options,
+		if req.EnvironmentOptions.RawEnvs["HOST"] != "localhost" {
+			t.Errorf("Expected environment HOST=localhost, got %v", req.EnvironmentOptions.RawEnvs)
 		}
 
+		// API возвращает только идентификатор созданного сервера
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
-		json.NewEncoder(w).Encode(createdServer)
+		json.NewEncoder(w).Encode(CreateMCPServerResponse{MCPServerID: "new-id"})
 	}))
 	defer server.Close()
 
@@ -153,23 +146,19 @@ func TestMCPServerService_Create(t *testing.T) {
 	client := NewClient(server.URL, "test-project", mockAuth)
 	service := NewMCPServerService(client)
 
-	req := &MCPServerCreateRequest{
-		Name:        "new-server",
-		Description: "New MCP server",
-		Options:     map[string]interface{}{"host": "localhost"},
+	req := &CreateMCPServerRequest{
+		Name:               "new-server",
+		Description:        "New MCP server",
+		EnvironmentOptions: EnvironmentOptions{RawEnvs: map[string]string{"HOST": "localhost"}},
 	}
 
-	result, err := service.Create(context.Background(), req)
+	result, err := service.CreateMCPServer(context.Background(), req)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	if result.Name != "new-server" {
-		t.Errorf("Expected name 'new-server', got '%s'", result.Name)
-	}
-
-	if result.Status != "active" {
-		t.Errorf("Expected status 'active', got '%s'", result.Status)
+	if result.MCPServerID != "new-id" {
+		t.Errorf("Expected ID 'new-id', got '%s'", result.MCPServerID)
 	}
 }
 
@@ -180,12 +169,12 @@ func TestMCPServerService_Update(t *testing.T) {
 			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
 		}
 
-		if r.Method != "PUT" {
-			t.Errorf("Expected method PUT, got %s", r.Method)
+		if r.Method != "PATCH" {
+			t.Errorf("Expected method PATCH, got %s", r.Method)
 		}
 
 		// Проверяем тело запроса
-		var req MCPServerUpdateRequest
+		var req UpdateMCPServerRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			t.Errorf("Failed to decode request body: %v", err)
 		}
@@ -193,20 +182,12 @@ func TestMCPServerService_Update(t *testing.T) {
 		if req.Name != "updated-server" {
 			t.Errorf("Expected name 'updated-server', got '%s'", req.Name)
 		}
-
-		// Отправляем ответ
-		updatedServer := MCPServer{
-			ID:          "test-id",
-			Name:        req.Name,
-			Description: req.Description,
-			Status:      "active",
-			CreatedAt:   CustomTime{Time: time.Now()},
-			UpdatedAt:   CustomTime{Time: time.Now()},
-			Options:     req.Options,
+		if req.Scaling.MaxScale != 3 {
+			t.Errorf("Expected maxScale 3, got %d", req.Scaling.MaxScale)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(updatedServer)
+		w.Write([]byte(`{}`))
 	}))
 	defer server.Close()
 
@@ -214,20 +195,15 @@ func TestMCPServerService_Update(t *testing.T) {
 	client := NewClient(server.URL, "test-project", mockAuth)
 	service := NewMCPServerService(client)
 
-	req := &MCPServerUpdateRequest{
+	req := &UpdateMCPServerRequest{
 		Name:        "updated-server",
 		Description: "Updated MCP server",
-		Options:     map[string]interface{}{"host": "updated-host"},
+		Scaling:     Scaling{MinScale: 1, MaxScale: 3},
 	}
 
-	result, err := service.Update(context.Background(), "test-id", req)
-	if err != nil {
+	if _, err := service.UpdateMCPServer(context.Background(), "test-id", req); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
-
-	if result.Name != "updated-server" {
-		t.Errorf("Expected name 'updated-server', got '%s'", result.Name)
-	}
 }
 
 func TestMCPServerService_Delete(t *testing.T) {
@@ -262,8 +238,8 @@ func TestMCPServerService_Resume(t *testing.T) {
 			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
 		}
 
-		if r.Method != "POST" {
-			t.Errorf("Expected method POST, got %s", r.Method)
+		if r.Method != "PATCH" {
+			t.Errorf("Expected method PATCH, got %s", r.Method)
 		}
 
 		w.WriteHeader(http.StatusOK)
@@ -287,8 +263,8 @@ func TestMCPServerService_Suspend(t *testing.T) {
 			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
 		}
 
-		if r.Method != "POST" {
-			t.Errorf("Expected method POST, got %s", r.Method)
+		if r.Method != "PATCH" {
+			t.Errorf("Expected method PATCH, got %s", r.Method)
 		}
 
 		w.WriteHeader(http.StatusOK)
@@ -312,16 +288,15 @@ func TestMCPServerService_GetHistory(t *testing.T) {
 			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
 		}
 
-		response := MCPServerHistoryResponse{
-			Data: []HistoryEntry{
+		response := SearchMCPServerHistoryResponse{
+			Data: []MCPServerHistoryEvent{
 				{
-					ID:        "1",
-					Action:    "create",
-					Status:    "success",
-					Message:   "Server created successfully",
-					CreatedAt: time.Now(),
+					EventType: HistoryEventTypeCreation,
+					After:     MCPServerSnapshot{Name: "test-server", Status: MCPServerStatusRunning},
+					Version:   CustomTime{Time: time.Now()},
 				},
 			},
+			Total: 1,
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -342,26 +317,28 @@ func TestMCPServerService_GetHistory(t *testing.T) {
 		t.Errorf("Expected 1 history entry, got %d", len(result.Data))
 	}
 
-	if result.Data[0].Action != "create" {
-		t.Errorf("Expected action 'create', got '%s'", result.Data[0].Action)
+	if result.Data[0].EventType != HistoryEventTypeCreation {
+		t.Errorf("Expected event type '%s', got '%s'", HistoryEventTypeCreation, result.Data[0].EventType)
 	}
 }
 
 func TestMCPServerService_GetTools(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		expectedPath := "/api/v1/test-project/mcpServers/test-id/tools"
+		// Инструменты приходят в составе MCP сервера
+		expectedPath := "/api/v1/test-project/mcpServers/test-id"
 		if r.URL.Path != expectedPath {
 			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
 		}
 
-		response := struct {
-			Tools []Tool `json:"tools"`
-		}{
-			Tools: []Tool{
-				{
-					Name:        "test-tool",
-					Description: "Test tool",
-					InputSchema: map[string]interface{}{"type": "object"},
+		response := GetMCPServerResponse{
+			MCPServer: MCPServer{
+				ID: "test-id",
+				Tools: []Tool{
+					{
+						Name:        "test-tool",
+						Description: "Test tool",
+						Args:        []ToolArg{{Name: "query", Type: "string"}},
+					},
 				},
 			},
 		}
@@ -0,0 +1,1357 @@
+// Code generated by swaggergen from service.swagger.json. DO NOT EDIT.
+
+package api
+
+// AddAgentAgentSystemResponse - Ответ Добавления Агента в Систему Агентов - Подтверждение ассоциации (contractsAddAgentAgentSystemResponse)
+type AddAgentAgentSystemResponse struct {
+}
+
+// Agent - Сообщение Агента - Основная сущность, представляющая экземпляр ИИ агента (agentAgent)
+type Agent struct {
+	// Классификация типа агента (только для чтения)
+	AgentType AgentType `json:"agentType,omitempty"`
+	// URL мониторинга Arize Phoenix (только для чтения)
+	ArizePhoenixPublicURL string `json:"arizePhoenixPublicUrl,omitempty"`
+	// Временная метка создания
+	CreatedAt CustomTime `json:"createdAt,omitzero"`
+	// ID пользователя, создавшего агента (формат UUID, обязательный)
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Описание агента (3-125 символов)
+	Description string `json:"description,omitempty"`
+	// Уникальный идентификатор агента (формат UUID)
+	ID string `json:"id,omitempty"`
+	// Конфигурация источника образа контейнера
+	ImageSource AgentImageSource `json:"imageSource,omitzero"`
+	// Спецификация типа вычислительного конфигурации для агента
+	InstanceType       InstanceType       `json:"instanceType,omitzero"`
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Связанные MCP серверы (максимум 10)
+	MCPServers []AgentMCPServer `json:"mcpServers,omitempty"`
+	// Имя агента (3-125 символов, строчные буквы с дефисами, должен начинаться/заканчиваться буквенно-цифровым)
+	Name string `json:"name,omitempty"`
+	// Специфичные для агента опции конфигурации
+	Options AgentOptions `json:"options,omitzero"`
+	// Идентификатор проекта, которому принадлежит агент (формат UUID)
+	ProjectID string `json:"projectId,omitempty"`
+	// Публичный URL для доступа к агенту (только для чтения)
+	PublicURL string `json:"publicUrl,omitempty"`
+	// Текущий операционный статус агента
+	Status AgentStatus `json:"status,omitempty"`
+	// Подробная причина текущего статуса, если применимо
+	StatusReason StatusReason `json:"statusReason,omitzero"`
+	// Временная метка последнего обновления
+	UpdatedAt CustomTime `json:"updatedAt,omitzero"`
+	// ID пользователя, последним обновившего агента (формат UUID, обязательный)
+	UpdatedBy string `json:"updatedBy,omitempty"`
+	// Системы агентов, в которых используется этот агент (максимум 10)
+	UsedInAgentSystems []AgentSystemRef `json:"usedInAgentSystems,omitempty"`
+}
+
+// AgentHistoryEvent - Событие Истории Агента - Разница снимков и метаданные для изменения (contractsAgentHistoryEvent)
+type AgentHistoryEvent struct {
+	// Снимок сущности после изменения
+	After AgentSnapshot `json:"after,omitzero"`
+	// Идентификатор автора (формат UUID, опционально)
+	AuthorID string `json:"authorId,omitempty"`
+	// Снимок сущности до изменения
+	Before AgentSnapshot `json:"before,omitzero"`
+	// Классификация типа события (обязательно)
+	EventType HistoryEventType `json:"eventType,omitempty"`
+	// Версия события (временная метка)
+	Version CustomTime `json:"version,omitzero"`
+}
+
+// AgentImageSource - Сообщение Источник Образа Агента - Определяет источник образа для агента (commonAgentImageSource)
+type AgentImageSource struct {
+	// Идентификатор агента (формат UUID)
+	AgentID string `json:"agentId,omitempty"`
+	// URI образа в реестре контейнеров
+	ARImageURI string `json:"arImageUri,omitempty"`
+	// Идентификатор агента в маркетплейсе (формат UUID)
+	MarketplaceAgentID string `json:"marketplaceAgentId,omitempty"`
+}
+
+// AgentMCPServer - agentAgentsMCPServer
+type AgentMCPServer struct {
+	MCPServerID string               `json:"mcpServerId,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	Source      MCPServerImageSource `json:"source,omitzero"`
+	Status      MCPServerStatus      `json:"status,omitempty"`
+	// пространство для переопределения настроек для конкретного агента
+	Tools []Tool `json:"tools,omitempty"`
+}
+
+// AgentOptions - Сообщение Опций Агента - Определяет настройки агента (agentAgentOptions)
+type AgentOptions struct {
+	// Настройки окружения
+	Env EnvironmentOptions `json:"env,omitzero"`
+	// Настройки LLM
+	LLM LLMOptions `json:"llm,omitzero"`
+	// Масштабирование
+	Scaling Scaling `json:"scaling,omitzero"`
+	// Системный промпт
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+}
+
+// AgentPredefined - hubAgentPredefined
+type AgentPredefined struct {
+	Category string `json:"category,omitempty"`
+	// creation, update details
+	CreatedAt    CustomTime `json:"createdAt,omitzero"`
+	CreatedBy    string     `json:"createdBy,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	ExposedPorts []int      `json:"exposedPorts,omitempty"`
+	// identification
+	ID                         string                `json:"id,omitempty"`
+	LicenseURL                 string                `json:"licenseUrl,omitempty"`
+	Name                       string                `json:"name,omitempty"`
+	PreviewDescription         string                `json:"previewDescription,omitempty"`
+	RecommendedSystemPrompt    string                `json:"recommendedSystemPrompt,omitempty"`
+	Status                     AgentPredefinedStatus `json:"status,omitempty"`
+	SupplierCompany            string                `json:"supplierCompany,omitempty"`
+	SupplierCompanyDescription string                `json:"supplierCompanyDescription,omitempty"`
+	SupplierCompanyLink        string                `json:"supplierCompanyLink,omitempty"`
+	Tags                       []string              `json:"tags,omitempty"`
+	Type                       AgentPredefinedType   `json:"type,omitempty"`
+	UpdatedAt                  CustomTime            `json:"updatedAt,omitzero"`
+	UpdatedBy                  string                `json:"updatedBy,omitempty"`
+	Versions                   []string              `json:"versions,omitempty"`
+}
+
+// AgentPredefinedStatus - Перечисление Статусов Предопределенных Агентов - Определяет статусы агентов в хабе (enumAgentPredefinedStatus)
+type AgentPredefinedStatus string
+
+const (
+	AgentPredefinedStatusUnknown   AgentPredefinedStatus = "AGENT_PREDEFINED_STATUS_UNKNOWN"
+	AgentPredefinedStatusPreview   AgentPredefinedStatus = "AGENT_PREDEFINED_STATUS_PREVIEW"
+	AgentPredefinedStatusAvailable AgentPredefinedStatus = "AGENT_PREDEFINED_STATUS_AVAILABLE"
+)
+
+// AgentPredefinedStatusValues - все значения AgentPredefinedStatus в порядке спецификации
+var AgentPredefinedStatusValues = []AgentPredefinedStatus{
+	AgentPredefinedStatusUnknown,
+	AgentPredefinedStatusPreview,
+	AgentPredefinedStatusAvailable,
+}
+
+// AgentPredefinedType - Перечисление Типов Предопределенных Агентов - Классифицирует предопределенные агенты в хабе (enumAgentPredefinedType)
+type AgentPredefinedType string
+
+const (
+	AgentPredefinedTypeUnknown  AgentPredefinedType = "AGENT_PREDEFINED_TYPE_UNKNOWN"
+	AgentPredefinedTypeFreeTier AgentPredefinedType = "AGENT_PREDEFINED_TYPE_FREE_TIER"
+	AgentPredefinedTypePayable  AgentPredefinedType = "AGENT_PREDEFINED_TYPE_PAYABLE"
+	AgentPredefinedTypeInternal AgentPredefinedType = "AGENT_PREDEFINED_TYPE_INTERNAL"
+)
+
+// AgentPredefinedTypeValues - все значения AgentPredefinedType в порядке спецификации
+var AgentPredefinedTypeValues = []AgentPredefinedType{
+	AgentPredefinedTypeUnknown,
+	AgentPredefinedTypeFreeTier,
+	AgentPredefinedTypePayable,
+	AgentPredefinedTypeInternal,
+}
+
+// AgentPreview - Сообщение Предпросмотра Агента - Только для чтения, краткая версия сущности для результатов поиска (agentAgentPreview)
+type AgentPreview struct {
+	// Классификация типа агента (только для чтения)
+	AgentType AgentType `json:"agentType,omitempty"`
+	// Раздел аудита - Отслеживание создания и модификации
+	CreatedAt CustomTime `json:"createdAt,omitzero"`
+	// ID пользователя, создавшего агента (формат UUID, обязательный)
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Описание агента (3-125 символов)
+	Description string `json:"description,omitempty"`
+	// Раздел идентификации - Основные поля идентичности для агента
+	ID string `json:"id,omitempty"`
+	// Конфигурация источника образа контейнера
+	ImageSource AgentImageSource `json:"imageSource,omitzero"`
+	// Раздел опций конфигурации - Настройка и ресурсы агента
+	InstanceType InstanceType `json:"instanceType,omitzero"`
+	// Имя агента (3-125 символов)
+	Name string `json:"name,omitempty"`
+	// Идентификатор проекта, которому принадлежит агент (формат UUID)
+	ProjectID string `json:"projectId,omitempty"`
+	// Публичный URL для доступа к агенту (только для чтения)
+	PublicURL string `json:"publicUrl,omitempty"`
+	// Раздел статуса и модели - Текущее состояние и логика работы
+	Status AgentStatus `json:"status,omitempty"`
+	// Подробная причина текущего статуса, если применимо
+	StatusReason StatusReason `json:"statusReason,omitzero"`
+	// Временная метка последнего обновления
+	UpdatedAt CustomTime `json:"updatedAt,omitzero"`
+	// ID пользователя, последним обновившего агента (формат UUID, обязательный)
+	UpdatedBy string `json:"updatedBy,omitempty"`
+}
+
+// AgentSnapshot - Сообщение Снимка Агента - Только для чтения, краткая версия сущности для результатов поиска (agentAgentSnapshot)
+type AgentSnapshot struct {
+	// ID автора (формат UUID, обязательный)
+	AuthorID string `json:"authorId,omitempty"`
+	// Описание агента (3-125 символов)
+	Description string `json:"description,omitempty"`
+	// Конфигурация источника образа контейнера
+	ImageSource AgentImageSource `json:"imageSource,omitzero"`
+	// Раздел опций конфигурации - Настройка и ресурсы агента
+	InstanceTypeID string `json:"instanceTypeId,omitempty"`
+	// Связанные MCP серверы (максимум 10)
+	MCPServers []AgentMCPServer `json:"mcpServers,omitempty"`
+	// Имя агента (3-125 символов)
+	Name string `json:"name,omitempty"`
+	// Специфичные для агента опции конфигурации
+	Options AgentOptions `json:"options,omitzero"`
+	// Раздел статуса и модели - Текущее состояние и логика работы
+	Status AgentStatus `json:"status,omitempty"`
+	// Подробная причина текущего статуса, если применимо
+	StatusReason StatusReason `json:"statusReason,omitzero"`
+	// Раздел аудита - Отслеживание создания и модификации
+	Version CustomTime `json:"version,omitzero"`
+}
+
+// AgentStatus - Перечисление Статусов Агента - Определяет все возможные операционные состояния для ИИ агентов (enumAgentStatus)
+type AgentStatus string
+
+const (
+	AgentStatusUnknown            AgentStatus = "AGENT_STATUS_UNKNOWN"
+	AgentStatusResourceAllocation AgentStatus = "AGENT_STATUS_RESOURCE_ALLOCATION"
+	AgentStatusPulling            AgentStatus = "AGENT_STATUS_PULLING"
+	AgentStatusRunning            AgentStatus = "AGENT_STATUS_RUNNING"
+	AgentStatusOnSuspension       AgentStatus = "AGENT_STATUS_ON_SUSPENSION"
+	AgentStatusSuspended          AgentStatus = "AGENT_STATUS_SUSPENDED"
+	AgentStatusOnDeletion         AgentStatus = "AGENT_STATUS_ON_DELETION"
+	AgentStatusDeleted            AgentStatus = "AGENT_STATUS_DELETED"
+	AgentStatusFailed             AgentStatus = "AGENT_STATUS_FAILED"
+	AgentStatusCooled             AgentStatus = "AGENT_STATUS_COOLED"
+	AgentStatusLLMUnavailable     AgentStatus = "AGENT_STATUS_LLM_UNAVAILABLE"
+	AgentStatusToolUnavailable    AgentStatus = "AGENT_STATUS_TOOL_UNAVAILABLE"
+	AgentStatusImageUnavailable   AgentStatus = "AGENT_STATUS_IMAGE_UNAVAILABLE"
+)
+
+// AgentStatusValues - все значения AgentStatus в порядке спецификации
+var AgentStatusValues = []AgentStatus{
+	AgentStatusUnknown,
+	AgentStatusResourceAllocation,
+	AgentStatusPulling,
+	AgentStatusRunning,
+	AgentStatusOnSuspension,
+	AgentStatusSuspended,
+	AgentStatusOnDeletion,
+	AgentStatusDeleted,
+	AgentStatusFailed,
+	AgentStatusCooled,
+	AgentStatusLLMUnavailable,
+	AgentStatusToolUnavailable,
+	AgentStatusImageUnavailable,
+}
+
+// AgentSystem - Сообщение Системы Агентов - Основная сущность, представляющая экземпляр системы агентов (agent_systemAgentSystem)
+type AgentSystem struct {
+	// Агенты в системе (максимум 10)
+	Agents []AgentSystemAgent `json:"agents,omitempty"`
+	// URL мониторинга Arize Phoenix (только для чтения)
+	ArizePhoenixPublicURL string `json:"arizePhoenixPublicUrl,omitempty"`
+	// Раздел аудита - Отслеживание создания и модификации
+	CreatedAt CustomTime `json:"createdAt,omitzero"`
+	// ID пользователя, создавшего систему агентов (формат UUID, обязательный, только для чтения)
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Описание системы агентов (3-125 символов)
+	Description string `json:"description,omitempty"`
+	// Раздел идентификации - Основные поля идентичности для системы агентов
+	ID string `json:"id,omitempty"`
+	// Раздел опций конфигурации - Настройка и ресурсы системы агентов
+	InstanceType InstanceType `json:"instanceType,omitzero"`
+	// Раздел интеграции - Настройки внешних подключений
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Имя системы агентов (3-125 символов, строчные буквы с дефисами, должен начинаться/заканчиваться буквенно-цифровым)
+	Name string `json:"name,omitempty"`
+	// Специфичные для системы агентов опции конфигурации
+	Options AgentSystemOptions `json:"options,omitzero"`
+	// Настройки оркестратора системы агентов
+	OrchestratorOptions AgentSystemOrchestratorOptions `json:"orchestratorOptions,omitzero"`
+	// Идентификатор проекта, которому принадлежит система агентов (формат UUID)
+	ProjectID string `json:"projectId,omitempty"`
+	// Раздел публичных URL доступа - Внешние точки доступа
+	PublicURL string `json:"publicUrl,omitempty"`
+	// Раздел статуса и модели - Текущее состояние и логика работы
+	Status AgentSystemStatus `json:"status,omitempty"`
+	// Подробная причина текущего статуса, если применимо
+	StatusReason StatusReason `json:"statusReason,omitzero"`
+	// Временная метка последнего обновления
+	UpdatedAt CustomTime `json:"updatedAt,omitzero"`
+	// ID пользователя, последним обновившего систему агентов (формат UUID, обязательный, только для чтения)
+	UpdatedBy string `json:"updatedBy,omitempty"`
+}
+
+// AgentSystemAgent - agent_systemAgentSystemAgent
+type AgentSystemAgent struct {
+	AgentID    string      `json:"agentId,omitempty"`
+	MCPServers []MCPServer `json:"mcpServers,omitempty"`
+	Name       string      `json:"name,omitempty"`
+	// пространство для переопределения настроек для конкретного агента
+	Scaling Scaling          `json:"scaling,omitzero"`
+	Source  AgentImageSource `json:"source,omitzero"`
+	Status  AgentStatus      `json:"status,omitempty"`
+}
+
+// AgentSystemHistoryEvent - Событие истории системы агентов - Разница снимков и метаданные для изменения (contractsAgentSystemHistoryEvent)
+type AgentSystemHistoryEvent struct {
+	// Снимок сущности после изменения
+	After AgentSystemSnapshot `json:"after,omitzero"`
+	// Идентификатор автора (формат UUID, необязательный)
+	AuthorID string `json:"authorId,omitempty"`
+	// Снимок сущности до изменения
+	Before AgentSystemSnapshot `json:"before,omitzero"`
+	// Классификация типа события (обязательная)
+	EventType HistoryEventType `json:"eventType,omitempty"`
+	// Версия события (метка времени)
+	Version CustomTime `json:"version,omitzero"`
+}
+
+// AgentSystemOptions - Сообщение Опций Системы Агентов - Определяет настройки системы агентов (agent_systemAgentSystemOptions)
+type AgentSystemOptions struct {
+	// Настройки хранилища контекста
+	ContextStorage ContextStorageOptions `json:"contextStorage,omitzero"`
+	// Настройки наблюдаемости
+	Observability ObservabilityOptions `json:"observability,omitzero"`
+}
+
+// AgentSystemOrchestratorOptions - agent_systemAgentSystemOrchestratorOptions
+type AgentSystemOrchestratorOptions struct {
+	Env          EnvironmentOptions `json:"env,omitzero"`
+	LLM          LLMOptions         `json:"llm,omitzero"`
+	Scaling      Scaling            `json:"scaling,omitzero"`
+	SystemPrompt string             `json:"systemPrompt,omitempty"`
+}
+
+// AgentSystemPreview - Сообщение Предпросмотра Системы Агентов - Только для чтения, краткая версия сущности для результатов поиска (entitiesagent_systemAgentSystemPreview)
+type AgentSystemPreview struct {
+	// Раздел аудита - Отслеживание создания и модификации
+	CreatedAt CustomTime `json:"createdAt,omitzero"`
+	// ID пользователя, создавшего систему агентов (формат UUID, обязательный, только для чтения)
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Раздел идентификации - Основные поля идентичности для системы агентов
+	ID string `json:"id,omitempty"`
+	// Спецификация типа вычислительного конфигурации для системы агентов
+	InstanceType InstanceType `json:"instanceType,omitzero"`
+	// Имя системы агентов (3-125 символов)
+	Name string `json:"name,omitempty"`
+	// Идентификатор проекта, которому принадлежит система агентов (формат UUID)
+	ProjectID string `json:"projectId,omitempty"`
+	// Раздел публичных URL доступа - Внешние точки доступа
+	PublicURL string `json:"publicUrl,omitempty"`
+	// Раздел статуса и модели - Текущее состояние и логика работы
+	Status AgentSystemStatus `json:"status,omitempty"`
+	// Подробная причина текущего статуса, если применимо
+	StatusReason StatusReason `json:"statusReason,omitzero"`
+	// Временная метка последнего обновления
+	UpdatedAt CustomTime `json:"updatedAt,omitzero"`
+	// ID пользователя, последним обновившего систему агентов (формат UUID, обязательный, только для чтения)
+	UpdatedBy string `json:"updatedBy,omitempty"`
+}
+
+// AgentSystemRef - entitiesagentAgentSystemPreview
+type AgentSystemRef struct {
+	ID           string            `json:"id,omitempty"`
+	InstanceType InstanceType      `json:"instanceType,omitzero"`
+	Name         string            `json:"name,omitempty"`
+	ProjectID    string            `json:"projectId,omitempty"`
+	PublicURL    string            `json:"publicUrl,omitempty"`
+	Status       AgentSystemStatus `json:"status,omitempty"`
+	StatusReason StatusReason      `json:"statusReason,omitzero"`
+}
+
+// AgentSystemSnapshot - Сообщение Снимка Системы Агентов - Только для чтения, краткая версия сущности для результатов поиска (agent_systemAgentSystemSnapshot)
+type AgentSystemSnapshot struct {
+	// Агенты в системе (максимум 10)
+	Agents []AgentSystemAgent `json:"agents,omitempty"`
+	// ID автора (формат UUID, обязательный, только для чтения)
+	AuthorID string `json:"authorId,omitempty"`
+	// Раздел опций конфигурации - Настройка и ресурсы системы агентов
+	InstanceTypeID string `json:"instanceTypeId,omitempty"`
+	// Настройки интеграции
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Имя системы агентов (3-125 символов)
+	Name string `json:"name,omitempty"`
+	// Специфичные для системы агентов опции конфигурации
+	Options AgentSystemOptions `json:"options,omitzero"`
+	// Настройки оркестратора системы агентов
+	OrchestratorOptions AgentSystemOrchestratorOptions `json:"orchestratorOptions,omitzero"`
+	// Раздел статуса и модели - Текущее состояние и логика работы
+	Status AgentSystemStatus `json:"status,omitempty"`
+	// Подробная причина текущего статуса, если применимо
+	StatusReason StatusReason `json:"statusReason,omitzero"`
+	// Раздел аудита - Отслеживание создания и модификации
+	Version CustomTime `json:"version,omitzero"`
+}
+
+// AgentSystemStatus - Перечисление Статусов Системы Агентов - Определяет все возможные операционные состояния для систем агентов (enumAgentSystemStatus)
+type AgentSystemStatus string
+
+const (
+	AgentSystemStatusUnknown            AgentSystemStatus = "AGENT_SYSTEM_STATUS_UNKNOWN"
+	AgentSystemStatusResourceAllocation AgentSystemStatus = "AGENT_SYSTEM_STATUS_RESOURCE_ALLOCATION"
+	AgentSystemStatusPulling            AgentSystemStatus = "AGENT_SYSTEM_STATUS_PULLING"
+	AgentSystemStatusRunning            AgentSystemStatus = "AGENT_SYSTEM_STATUS_RUNNING"
+	AgentSystemStatusOnSuspension       AgentSystemStatus = "AGENT_SYSTEM_STATUS_ON_SUSPENSION"
+	AgentSystemStatusSuspended          AgentSystemStatus = "AGENT_SYSTEM_STATUS_SUSPENDED"
+	AgentSystemStatusOnDeletion         AgentSystemStatus = "AGENT_SYSTEM_STATUS_ON_DELETION"
+	AgentSystemStatusDeleted            AgentSystemStatus = "AGENT_SYSTEM_STATUS_DELETED"
+	AgentSystemStatusFailed             AgentSystemStatus = "AGENT_SYSTEM_STATUS_FAILED"
+	AgentSystemStatusCooled             AgentSystemStatus = "AGENT_SYSTEM_STATUS_COOLED"
+	AgentSystemStatusAgentUnavailable   AgentSystemStatus = "AGENT_SYSTEM_STATUS_AGENT_UNAVAILABLE"
+)
+
+// AgentSystemStatusValues - все значения AgentSystemStatus в порядке спецификации
+var AgentSystemStatusValues = []AgentSystemStatus{
+	AgentSystemStatusUnknown,
+	AgentSystemStatusResourceAllocation,
+	AgentSystemStatusPulling,
+	AgentSystemStatusRunning,
+	AgentSystemStatusOnSuspension,
+	AgentSystemStatusSuspended,
+	AgentSystemStatusOnDeletion,
+	AgentSystemStatusDeleted,
+	AgentSystemStatusFailed,
+	AgentSystemStatusCooled,
+	AgentSystemStatusAgentUnavailable,
+}
+
+// AgentType - Перечисление Типов Агента - Классифицирует агентов на основе их источника создания (enumAgentType)
+type AgentType string
+
+const (
+	AgentTypeUnknown   AgentType = "AGENT_TYPE_UNKNOWN"
+	AgentTypeBlueprint AgentType = "AGENT_TYPE_BLUEPRINT"
+	AgentTypeFromHub   AgentType = "AGENT_TYPE_FROM_HUB"
+	AgentTypeCustom    AgentType = "AGENT_TYPE_CUSTOM"
+)
+
+// AgentTypeValues - все значения AgentType в порядке спецификации
+var AgentTypeValues = []AgentType{
+	AgentTypeUnknown,
+	AgentTypeBlueprint,
+	AgentTypeFromHub,
+	AgentTypeCustom,
+}
+
+// AuthenticationOptions - Сообщение Опции Аутентификации - Определяет настройки аутентификации (commonAuthenticationOptions)
+type AuthenticationOptions struct {
+	// Флаг включения аутентификации
+	IsEnabled bool `json:"isEnabled,omitempty"`
+	// Настройки CORS для публичного URL
+	PublicURLCORS PublicURLCORS `json:"publicUrlCors,omitzero"`
+	// Идентификатор сервисного аккаунта (формат UUID)
+	ServiceAccountID string `json:"serviceAccountId,omitempty"`
+	// Тип аутентификации
+	Type AuthenticationType `json:"type,omitempty"`
+}
+
+// AuthenticationType - Перечисление Типов Аутентификации - Определяет методы аутентификации для интеграций (enumAuthenticationType)
+type AuthenticationType string
+
+const (
+	AuthenticationTypeUnknown    AuthenticationType = "AUTHENTICATION_TYPE_UNKNOWN"
+	AuthenticationTypeTokenBased AuthenticationType = "AUTHENTICATION_TYPE_TOKEN_BASED"
+	AuthenticationTypeKeyBased   AuthenticationType = "AUTHENTICATION_TYPE_KEY_BASED"
+)
+
+// AuthenticationTypeValues - все значения AuthenticationType в порядке спецификации
+var AuthenticationTypeValues = []AuthenticationType{
+	AuthenticationTypeUnknown,
+	AuthenticationTypeTokenBased,
+	AuthenticationTypeKeyBased,
+}
+
+// AutoUpdateOptions - Сообщение Опции Автообновления - Определяет настройки автообновления (commonAutoUpdateOptions)
+type AutoUpdateOptions struct {
+	// Источник обновления через образ
+	ImageSource AutoUpdateOptionsImageSource `json:"imageSource,omitzero"`
+	// Флаг включения автообновления
+	IsEnabled bool `json:"isEnabled,omitempty"`
+}
+
+// AutoUpdateOptionsImageSource - Сообщение Источник Образа для Автообновления - Определяет параметры образа для автообновления (commonAutoUpdateOptionsImageSource)
+type AutoUpdateOptionsImageSource struct {
+	// Тег образа (максимум 64 символа)
+	Tag string `json:"tag,omitempty"`
+}
+
+// BulkDeleteAgentResponse - Ответ Массового Удаления Агентов - Подтверждение массового удаления (contractsBulkDeleteAgentResponse)
+type BulkDeleteAgentResponse struct {
+}
+
+// BulkDeleteAgentSystemResponse - Ответ Массового Удаления Систем Агентов - Подтверждение массового удаления (contractsBulkDeleteAgentSystemResponse)
+type BulkDeleteAgentSystemResponse struct {
+}
+
+// BulkDeleteMCPServerResponse - Ответ на массовое удаление серверов MCP - Подтверждение массового удаления (contractsBulkDeleteMCPServerResponse)
+type BulkDeleteMCPServerResponse struct {
+}
+
+// BulkResumeAgentRequest - Запрос Массового Возобновления Агентов - Параметры для возобновления нескольких агентов (AgentManagementServiceBulkResumeAgentBody)
+type BulkResumeAgentRequest struct {
+	// Список идентификаторов агентов для возобновления (список UUID, максимум 100)
+	AgentIDs []string `json:"agentIds,omitempty"`
+}
+
+// BulkResumeAgentResponse - Ответ Массового Возобновления Агентов - Подтверждение массового возобновления (contractsBulkResumeAgentResponse)
+type BulkResumeAgentResponse struct {
+}
+
+// BulkResumeAgentSystemRequest - Запрос Массового Возобновления Систем Агентов - Параметры для возобновления нескольких систем (AgentSystemManagementServiceBulkResumeAgentSystemBody)
+type BulkResumeAgentSystemRequest struct {
+	// Список идентификаторов систем агентов для возобновления (список UUID, максимум 100)
+	AgentSystemIDs []string `json:"agentSystemIds,omitempty"`
+}
+
+// BulkResumeAgentSystemResponse - Ответ Массового Возобновления Систем Агентов - Подтверждение массового возобновления (contractsBulkResumeAgentSystemResponse)
+type BulkResumeAgentSystemResponse struct {
+}
+
+// BulkResumeMCPServerRequest - Запрос на массовое возобновление серверов MCP - Параметры для возобновления нескольких серверов (MCPServerManagementServiceBulkResumeMCPServerBody)
+type BulkResumeMCPServerRequest struct {
+	// Список идентификаторов серверов MCP для возобновления (список UUID, максимум 100)
+	MCPServerIDs []string `json:"mcpServerIds,omitempty"`
+}
+
+// BulkResumeMCPServerResponse - Ответ на массовое возобновление серверов MCP - Подтверждение массового возобновления (contractsBulkResumeMCPServerResponse)
+type BulkResumeMCPServerResponse struct {
+}
+
+// BulkSuspendAgentRequest - Запрос Массовой Приостановки Агентов - Параметры для приостановки нескольких агентов (AgentManagementServiceBulkSuspendAgentBody)
+type BulkSuspendAgentRequest struct {
+	// Список идентификаторов агентов для приостановки (список UUID, максимум 100)
+	AgentIDs []string `json:"agentIds,omitempty"`
+}
+
+// BulkSuspendAgentResponse - Ответ Массовой Приостановки Агентов - Подтверждение массовой приостановки (contractsBulkSuspendAgentResponse)
+type BulkSuspendAgentResponse struct {
+}
+
+// BulkSuspendAgentSystemRequest - Запрос Массовой Приостановки Систем Агентов - Параметры для приостановки нескольких систем (AgentSystemManagementServiceBulkSuspendAgentSystemBody)
+type BulkSuspendAgentSystemRequest struct {
+	// Список идентификаторов систем агентов для приостановки (список UUID, максимум 100)
+	AgentSystemIDs []string `json:"agentSystemIds,omitempty"`
+}
+
+// BulkSuspendAgentSystemResponse - Ответ Массовой Приостановки Систем Агентов - Подтверждение массовой приостановки (contractsBulkSuspendAgentSystemResponse)
+type BulkSuspendAgentSystemResponse struct {
+}
+
+// BulkSuspendMCPServerRequest - Запрос на массовую приостановку работы серверов MCP - Параметры для приостановки работ нескольких серверов (MCPServerManagementServiceBulkSuspendMCPServerBody)
+type BulkSuspendMCPServerRequest struct {
+	// Список идентификаторов серверов MCP для приостановки (список UUID, максимум 100)
+	MCPServerIDs []string `json:"mcpServerIds,omitempty"`
+}
+
+// BulkSuspendMCPServerResponse - Ответ на массовую приостановку работы серверов MCP - Подтверждение массовой приостановки (contractsBulkSuspendMCPServerResponse)
+type BulkSuspendMCPServerResponse struct {
+}
+
+// ConcurrencyType - Сообщение Тип Параллелизма - Определяет правила масштабирования по параллелизму (commonConcurrencyType)
+type ConcurrencyType struct {
+	// Жесткий лимит параллелизма (0 или больше)
+	Hard int `json:"hard,omitempty"`
+	// Мягкий лимит параллелизма (0 или больше)
+	Soft int `json:"soft,omitempty"`
+}
+
+// ContextStorageOptions - Сообщение Опций Хранилища Контекста - Определяет настройки хранилища контекста (agent_systemContextStorageOptions)
+type ContextStorageOptions struct {
+	// Флаг включения хранилища контекста
+	IsEnabled bool `json:"isEnabled,omitempty"`
+}
+
+// CreateAgentRequest - Запрос Создания Агента - Входные параметры для создания нового ИИ агента (AgentManagementServiceCreateAgentBody)
+type CreateAgentRequest struct {
+	// Описание агента (максимум 125 символов)
+	Description string `json:"description,omitempty"`
+	// Список портов для предоставления внешнего доступа
+	ExportedPorts []int `json:"exportedPorts,omitempty"`
+	// Конфигурация источника образа контейнера
+	ImageSource AgentImageSource `json:"imageSource,omitzero"`
+	// Идентификатор конфигурации для вычислительных ресурсов (формат UUID, обязательный)
+	InstanceTypeID     string             `json:"instanceTypeId,omitempty"`
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Идентификатор MCP сервера для интеграции инструментов (формат UUID, обязательный)
+	MCPServerID string `json:"mcpServerId,omitempty"`
+	// Имя агента (3-125 символов, строчные буквы с дефисами, должен начинаться/заканчиваться буквенно-цифровым)
+	Name string `json:"name,omitempty"`
+	// Специфичные для агента опции конфигурации
+	Options AgentOptions `json:"options,omitzero"`
+}
+
+// CreateAgentResponse - Ответ Создания Агента - Результат успешного создания агента (contractsCreateAgentResponse)
+type CreateAgentResponse struct {
+	// Уникальный идентификатор созданного агента (формат UUID)
+	AgentID string `json:"agentId,omitempty"`
+}
+
+// CreateAgentSystemRequest - Запрос Создания Системы Агентов - Входные параметры для создания системы (AgentSystemManagementServiceCreateAgentSystemBody)
+type CreateAgentSystemRequest struct {
+	// Описание системы (максимум 125 символов)
+	Description string `json:"description,omitempty"`
+	// Идентификатор конфигурации (формат UUID)
+	InstanceTypeID string `json:"instanceTypeId,omitempty"`
+	// Опции интеграции (аутентификация, CORS и т.д.)
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Имя системы (3-125 символов, строчные буквы с дефисами)
+	Name string `json:"name,omitempty"`
+	// Опции на уровне системы
+	Options AgentSystemOptions `json:"options,omitzero"`
+	// Опции оркестратора (маршрутизация/координация)
+	OrchestratorOptions AgentSystemOrchestratorOptions `json:"orchestratorOptions,omitzero"`
+}
+
+// CreateAgentSystemResponse - Ответ Создания Системы Агентов - Результат создания системы (contractsCreateAgentSystemResponse)
+type CreateAgentSystemResponse struct {
+	// Идентификатор вновь созданной системы агентов (формат UUID)
+	AgentSystemID string `json:"agentSystemId,omitempty"`
+}
+
+// CreateMCPServerRequest - Запрос на создание сервера MCP - Входные параметры для создания сервера (MCPServerManagementServiceCreateMCPServerBody)
+type CreateMCPServerRequest struct {
+	// Описание сервера (максимум 125 символов)
+	Description string `json:"description,omitempty"`
+	// Опции окружения (переменные окружения)
+	EnvironmentOptions EnvironmentOptions `json:"environmentOptions,omitzero"`
+	// Выставленные TCP-порты
+	ExposedPorts []int `json:"exposedPorts,omitempty"`
+	// Источник образа контейнера
+	ImageSource MCPServerImageSource `json:"imageSource,omitzero"`
+	// Идентификатор конфигурации (в формате UUID)
+	InstanceTypeID     string             `json:"instanceTypeId,omitempty"`
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Название сервера (3-125 символов, строчные буквы с дефисами)
+	Name    string  `json:"name,omitempty"`
+	Scaling Scaling `json:"scaling,omitzero"`
+}
+
+// CreateMCPServerResponse - Ответ на создание сервера MCP - Результат создания сервера (contractsCreateMCPServerResponse)
+type CreateMCPServerResponse struct {
+	// Идентификатор созданного сервера MCP (в формате UUID)
+	MCPServerID string `json:"mcpServerId,omitempty"`
+}
+
+// DeleteAgentAgentSystemResponse - Ответ Удаления Агента из Системы Агентов - Подтверждение разъединения (contractsDeleteAgentAgentSystemResponse)
+type DeleteAgentAgentSystemResponse struct {
+}
+
+// DeleteAgentResponse - Ответ Удаления Агента - Подтверждение удаления (contractsDeleteAgentResponse)
+type DeleteAgentResponse struct {
+}
+
+// DeleteAgentSystemResponse - Ответ Удаления Системы Агентов - Подтверждение удаления (contractsDeleteAgentSystemResponse)
+type DeleteAgentSystemResponse struct {
+}
+
+// DeleteMCPServerResponse - Ответ на удаление сервера MCP - Подтверждение удаления (contractsDeleteMCPServerResponse)
+type DeleteMCPServerResponse struct {
+}
+
+// Duration - Сообщение Продолжительность - Определяет временной интервал (entitiescommonDuration)
+type Duration struct {
+	// Часы
+	Hours int `json:"hours,omitempty"`
+	// Минуты
+	Minutes int `json:"minutes,omitempty"`
+	// Секунды
+	Seconds int `json:"seconds,omitempty"`
+}
+
+// EnvironmentOptions - Сообщение Опции Окружения - Определяет настройки переменных окружения (commonEnvironmentOptions)
+type EnvironmentOptions struct {
+	// Карта сырых переменных окружения (максимум 20 пар, ключи и значения от 3 до 128 символов)
+	RawEnvs map[string]string `json:"rawEnvs,omitempty"`
+	// Карта переменных окружения из секретов
+	SecretEnvs map[string]SecretLocator `json:"secretEnvs,omitempty"`
+}
+
+// EnvironmentOptionsPredefined - Сообщение Предопределенные Опции Окружения - Определяет предопределенные настройки переменных окружения (commonEnvironmentOptionsPredefined)
+type EnvironmentOptionsPredefined struct {
+	// Карта сырых переменных окружения (максимум 20 пар, ключи от 3 до 128 символов)
+	RawEnvs map[string]string `json:"rawEnvs,omitempty"`
+}
+
+// FoundationModelOptions - Сообщение Опции Базовой Модели - Определяет настройки базовой модели (commonFoundationModelOptions)
+type FoundationModelOptions struct {
+	// Название модели (от 3 до 128 символов)
+	ModelName string `json:"modelName,omitempty"`
+}
+
+// GetAgentResponse - Ответ Получения Агента - Возвращает полную сущность агента (contractsGetAgentResponse)
+type GetAgentResponse struct {
+	// Сущность агента с конфигурацией, статусом и метаданными
+	Agent Agent `json:"agent,omitzero"`
+}
+
+// GetAgentSystemResponse - Ответ Получения Системы Агентов - Возвращает полную сущность системы агентов (contractsGetAgentSystemResponse)
+type GetAgentSystemResponse struct {
+	// Сущность Системы Агентов с конфигурацией, статусом и метаданными
+	AgentSystem AgentSystem `json:"agentSystem,omitzero"`
+}
+
+// GetInstanceTypeResponse - Get Instance Type Response - Возвращает детали конфигурации (contractsGetInstanceTypeResponse)
+type GetInstanceTypeResponse struct {
+	// Спецификация и метаданные конфигурации
+	InstanceType InstanceType `json:"instanceType,omitzero"`
+}
+
+// GetMCPServerResponse - Ответ на получение информации о сервере MCP - Возвращает полную сущность сервера MCP (contractsGetMCPServerResponse)
+type GetMCPServerResponse struct {
+	// Сущность сервера MCP с конфигурацией, статусом и метаданными
+	MCPServer MCPServer `json:"mcpServer,omitzero"`
+}
+
+// GetPredefinedAgentResponse - Ответ на получение предопределённого агента - Результат получения (contractsGetPredefinedAgentResponse)
+type GetPredefinedAgentResponse struct {
+	// Предопределённый агент
+	PredefinedAgent AgentPredefined `json:"predefinedAgent,omitzero"`
+}
+
+// GetPredefinedMCPServerResponse - Ответ на получение предопределённого сервера MCP - Результат получения (contractsGetPredefinedMCPServerResponse)
+type GetPredefinedMCPServerResponse struct {
+	// Предопределённый сервер MCP
+	PredefinedMCPServer MCPServerPredefined `json:"predefinedMcpServer,omitzero"`
+}
+
+// GetProjectInfoResponse - Ответ на получение информации о проекте - Статус и квоты проекта (contractsGetProjectInfoResponse)
+type GetProjectInfoResponse struct {
+	// Квоты и использование проекта
+	Quotes []Quota `json:"quotes,omitempty"`
+	// Текущий статус проекта
+	Status ProjectStatus `json:"status,omitempty"`
+}
+
+// HistoryEventType - Перечисление Типов Событий Истории - Определяет типы событий для отслеживания изменений сущностей (enumHistoryEventType)
+type HistoryEventType string
+
+const (
+	HistoryEventTypeNone      HistoryEventType = "HISTORY_EVENT_TYPE_NONE"
+	HistoryEventTypeCreation  HistoryEventType = "HISTORY_EVENT_TYPE_CREATION"
+	HistoryEventTypeChanged   HistoryEventType = "HISTORY_EVENT_TYPE_CHANGED"
+	HistoryEventTypeDeleted   HistoryEventType = "HISTORY_EVENT_TYPE_DELETED"
+	HistoryEventTypeSuspended HistoryEventType = "HISTORY_EVENT_TYPE_SUSPENDED"
+	HistoryEventTypeResumed   HistoryEventType = "HISTORY_EVENT_TYPE_RESUMED"
+)
+
+// HistoryEventTypeValues - все значения HistoryEventType в порядке спецификации
+var HistoryEventTypeValues = []HistoryEventType{
+	HistoryEventTypeNone,
+	HistoryEventTypeCreation,
+	HistoryEventTypeChanged,
+	HistoryEventTypeDeleted,
+	HistoryEventTypeSuspended,
+	HistoryEventTypeResumed,
+}
+
+// InstanceType - Сообщение Тип Экземпляра - Представляет спецификации типов облачных экземплярова (commonInstanceType)
+type InstanceType struct {
+	// Временная метка создания конфигурации
+	CreatedAt CustomTime `json:"createdAt,omitzero"`
+	// ID пользователя, который создал конфигурацию (формат UUID, обязательный)
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Уникальный идентификатор конфигурации (формат UUID)
+	ID string `json:"id,omitempty"`
+	// Выделение CPU в миллиядрах (максимум 1,000,000)
+	MCPU int `json:"mCpu,omitempty"`
+	// Выделение RAM в МиБ (максимум 1,000,000)
+	MibRAM int `json:"mibRam,omitempty"`
+	// Человекочитаемое имя для конфигурации (максимум 128 символов)
+	Name string `json:"name,omitempty"`
+	// Временная метка последнего обновления конфигурации
+	UpdatedAt CustomTime `json:"updatedAt,omitzero"`
+	// ID пользователя, который последним обновил конфигурацию (формат UUID, обязательный)
+	UpdatedBy string `json:"updatedBy,omitempty"`
+}
+
+// IntegrationOptions - Сообщение Опции Интеграции - Определяет настройки интеграции (commonIntegrationOptions)
+type IntegrationOptions struct {
+	// Настройки аутентификации
+	AuthOptions AuthenticationOptions `json:"authOptions,omitzero"`
+	// Настройки автообновления
+	AutoUpdateOptions AutoUpdateOptions `json:"autoUpdateOptions,omitzero"`
+	// Настройки логирования
+	Logging LoggingOptions `json:"logging,omitzero"`
+}
+
+// LLMOptions - Сообщение Опции LLM - Определяет настройки Large Language Models (commonLLMOptions)
+type LLMOptions struct {
+	// Опции базовой модели
+	FoundationModels FoundationModelOptions `json:"foundationModels,omitzero"`
+	// Опции машинного обучения
+	MLInference MLInferenceOptions `json:"mlInference,omitzero"`
+}
+
+// LoggingOptions - Сообщение Опции Логирования - Определяет настройки логирования (commonLoggingOptions)
+type LoggingOptions struct {
+	// Включено ли логирование создаваемого объекта
+	IsEnabledLogging bool `json:"isEnabledLogging,omitempty"`
+	// Идентификатор группы логовваемого объекта (может быть пустым)
+	LogGroupID string `json:"logGroupId,omitempty"`
+}
+
+// MCPServer - mcp_serverMCPServer
+type MCPServer struct {
+	ArizePhoenixPublicURL string                  `json:"arizePhoenixPublicUrl,omitempty"`
+	CreatedAt             CustomTime              `json:"createdAt,omitzero"`
+	CreatedBy             string                  `json:"createdBy,omitempty"`
+	Description           string                  `json:"description,omitempty"`
+	EnvironmentOptions    EnvironmentOptions      `json:"environmentOptions,omitzero"`
+	ExposedPorts          []int                   `json:"exposedPorts,omitempty"`
+	ID                    string                  `json:"id,omitempty"`
+	ImageSource           MCPServerImageSource    `json:"imageSource,omitzero"`
+	InstanceType          InstanceType            `json:"instanceType,omitzero"`
+	IntegrationOptions    IntegrationOptions      `json:"integrationOptions,omitzero"`
+	Name                  string                  `json:"name,omitempty"`
+	ProjectID             string                  `json:"projectId,omitempty"`
+	PublicURL             string                  `json:"publicUrl,omitempty"`
+	Scaling               Scaling                 `json:"scaling,omitzero"`
+	Status                MCPServerStatus         `json:"status,omitempty"`
+	StatusReason          StatusReason            `json:"statusReason,omitzero"`
+	Tools                 []Tool                  `json:"tools,omitempty"`
+	UpdatedAt             CustomTime              `json:"updatedAt,omitzero"`
+	UpdatedBy             string                  `json:"updatedBy,omitempty"`
+	UsedInAgents          []MCPServerAgentPreview `json:"usedInAgents,omitempty"`
+}
+
+// MCPServerAgentPreview - Сообщение Предпросмотра Агента MCP сервера - Только для чтения, краткая версия сущности для результатов поиска (mcp_serverMCPServerAgentPreview)
+type MCPServerAgentPreview struct {
+	// Раздел аудита - Отслеживание создания и модификации
+	CreatedAt CustomTime `json:"createdAt,omitzero"`
+	// ID пользователя, создавшего агента (формат UUID, обязательный)
+	CreatedBy string `json:"createdBy,omitempty"`
+	// Описание агента (3-125 символов)
+	Description string `json:"description,omitempty"`
+	// Раздел идентификации - Основные поля идентичности для агента
+	ID string `json:"id,omitempty"`
+	// Раздел опций конфигурации - Настройка и ресурсы агента
+	InstanceType InstanceType `json:"instanceType,omitzero"`
+	Name         string       `json:"name,omitempty"`
+	ProjectID    string       `json:"projectId,omitempty"`
+	// Подробная причина текущего статуса, если применимо
+	StatusReason StatusReason `json:"statusReason,omitzero"`
+	// Временная метка последнего обновления
+	UpdatedAt CustomTime `json:"updatedAt,omitzero"`
+	// ID пользователя, последним обновившего агента (формат UUID, обязательный)
+	UpdatedBy string `json:"updatedBy,omitempty"`
+}
+
+// MCPServerHistoryEvent - Событие истории сервера MCP - Снимок сущности до и после изменения с метаданными (contractsMCPServerHistoryEvent)
+type MCPServerHistoryEvent struct {
+	// Снимок сущности после изменения
+	After MCPServerSnapshot `json:"after,omitzero"`
+	// Идентификатор автора (в формате UUID, опционально)
+	AuthorID string `json:"authorId,omitempty"`
+	// Снимок сущности до изменения
+	Before MCPServerSnapshot `json:"before,omitzero"`
+	// Классификация типа события (обязательно)
+	EventType HistoryEventType `json:"eventType,omitempty"`
+	// Версия события (метка времени)
+	Version CustomTime `json:"version,omitzero"`
+}
+
+// MCPServerImageSource - Сообщение Источник Образа MCP Сервера - Определяет источник образа для MCP сервера (commonMCPServerImageSource)
+type MCPServerImageSource struct {
+	// URI образа в реестре контейнеров
+	ARImageURI string `json:"arImageUri,omitempty"`
+	// Идентификатор MCP сервера в маркетплейсе (формат UUID)
+	MarketplaceMCPServerID string `json:"marketplaceMcpServerId,omitempty"`
+	// Идентификатор MCP сервера (формат UUID)
+	MCPServerID string `json:"mcpServerId,omitempty"`
+}
+
+// MCPServerPredefined - hubMCPServerPredefined
+type MCPServerPredefined struct {
+	Category                   string                       `json:"category,omitempty"`
+	CreatedAt                  CustomTime                   `json:"createdAt,omitzero"`
+	CreatedBy                  string                       `json:"createdBy,omitempty"`
+	Description                string                       `json:"description,omitempty"`
+	EnvironmentOptions         EnvironmentOptionsPredefined `json:"environmentOptions,omitzero"`
+	ExposedPorts               []int                        `json:"exposedPorts,omitempty"`
+	ID                         string                       `json:"id,omitempty"`
+	LicenseURL                 string                       `json:"licenseUrl,omitempty"`
+	Name                       string                       `json:"name,omitempty"`
+	PreviewDescription         string                       `json:"previewDescription,omitempty"`
+	Status                     MCPServerPredefinedStatus    `json:"status,omitempty"`
+	SupplierCompany            string                       `json:"supplierCompany,omitempty"`
+	SupplierCompanyDescription string                       `json:"supplierCompanyDescription,omitempty"`
+	SupplierCompanyLink        string                       `json:"supplierCompanyLink,omitempty"`
+	Tags                       []string                     `json:"tags,omitempty"`
+	Tools                      []Tool                       `json:"tools,omitempty"`
+	Type                       MCPServerPredefinedType      `json:"type,omitempty"`
+	UpdatedAt                  CustomTime                   `json:"updatedAt,omitzero"`
+	UpdatedBy                  string                       `json:"updatedBy,omitempty"`
+	Versions                   []string                     `json:"versions,omitempty"`
+}
+
+// MCPServerPredefinedStatus - Перечисление Статусов Предопределенных MCP Серверов - Определяет статусы предопределенных MCP серверов в хабе (enumMCPServerPredefinedStatus)
+type MCPServerPredefinedStatus string
+
+const (
+	MCPServerPredefinedStatusUnknown              MCPServerPredefinedStatus = "MCP_SERVER_STATUS_UNKNOWN"
+	MCPServerPredefinedStatusOnResourceAllocation MCPServerPredefinedStatus = "MCP_SERVER_STATUS_ON_RESOURCE_ALLOCATION"
+	MCPServerPredefinedStatusAvailable            MCPServerPredefinedStatus = "MCP_SERVER_STATUS_AVAILABLE"
+)
+
+// MCPServerPredefinedStatusValues - все значения MCPServerPredefinedStatus в порядке спецификации
+var MCPServerPredefinedStatusValues = []MCPServerPredefinedStatus{
+	MCPServerPredefinedStatusUnknown,
+	MCPServerPredefinedStatusOnResourceAllocation,
+	MCPServerPredefinedStatusAvailable,
+}
+
+// MCPServerPredefinedType - Перечисление Типов Предопределенных MCP Серверов - Классифицирует предопределенные MCP серверы в хабе (enumMCPServerPredefinedType)
+type MCPServerPredefinedType string
+
+const (
+	MCPServerPredefinedTypeUnknown  MCPServerPredefinedType = "MCP_SERVER_PREDEFINED_TYPE_UNKNOWN"
+	MCPServerPredefinedTypeFreeTier MCPServerPredefinedType = "MCP_SERVER_PREDEFINED_TYPE_FREE_TIER"
+	MCPServerPredefinedTypePayable  MCPServerPredefinedType = "MCP_SERVER_PREDEFINED_TYPE_PAYABLE"
+	MCPServerPredefinedTypeInternal MCPServerPredefinedType = "MCP_SERVER_PREDEFINED_TYPE_INTERNAL"
+)
+
+// MCPServerPredefinedTypeValues - все значения MCPServerPredefinedType в порядке спецификации
+var MCPServerPredefinedTypeValues = []MCPServerPredefinedType{
+	MCPServerPredefinedTypeUnknown,
+	MCPServerPredefinedTypeFreeTier,
+	MCPServerPredefinedTypePayable,
+	MCPServerPredefinedTypeInternal,
+}
+
+// MCPServerPreview - Только для чтения, краткая версия сущности для результатов поиска (mcp_serverMCPServerPreview)
+type MCPServerPreview struct {
+	CreatedAt    CustomTime           `json:"createdAt,omitzero"`
+	CreatedBy    string               `json:"createdBy,omitempty"`
+	ID           string               `json:"id,omitempty"`
+	ImageSource  MCPServerImageSource `json:"imageSource,omitzero"`
+	InstanceType InstanceType         `json:"instanceType,omitzero"`
+	Name         string               `json:"name,omitempty"`
+	ProjectID    string               `json:"projectId,omitempty"`
+	PublicURL    string               `json:"publicUrl,omitempty"`
+	Status       MCPServerStatus      `json:"status,omitempty"`
+	StatusReason StatusReason         `json:"statusReason,omitzero"`
+	Tools        []Tool               `json:"tools,omitempty"`
+	UpdatedAt    CustomTime           `json:"updatedAt,omitzero"`
+	UpdatedBy    string               `json:"updatedBy,omitempty"`
+}
+
+// MCPServerSnapshot - mcp_serverMCPServerSnapshot
+type MCPServerSnapshot struct {
+	AuthorID           string               `json:"authorId,omitempty"`
+	EnvironmentOptions EnvironmentOptions   `json:"environmentOptions,omitzero"`
+	ImageSource        MCPServerImageSource `json:"imageSource,omitzero"`
+	InstanceTypeID     string               `json:"instanceTypeId,omitempty"`
+	Name               string               `json:"name,omitempty"`
+	Status             MCPServerStatus      `json:"status,omitempty"`
+	StatusReason       StatusReason         `json:"statusReason,omitzero"`
+	Tools              []Tool               `json:"tools,omitempty"`
+	Version            CustomTime           `json:"version,omitzero"`
+}
+
+// MCPServerStatus - Статус MCP сервера - Определяет все возможные операционные состояния для MCP серверов (enumMCPServerStatus)
+type MCPServerStatus string
+
+const (
+	MCPServerStatusUnknown              MCPServerStatus = "MCP_SERVER_STATUS_UNKNOWN"
+	MCPServerStatusOnResourceAllocation MCPServerStatus = "MCP_SERVER_STATUS_ON_RESOURCE_ALLOCATION"
+	MCPServerStatusAvailable            MCPServerStatus = "MCP_SERVER_STATUS_AVAILABLE"
+	MCPServerStatusImageUnavailable     MCPServerStatus = "MCP_SERVER_STATUS_IMAGE_UNAVAILABLE"
+	MCPServerStatusWaitingForScrapping  MCPServerStatus = "MCP_SERVER_STATUS_WAITING_FOR_SCRAPPING"
+	MCPServerStatusOnDeletion           MCPServerStatus = "MCP_SERVER_STATUS_ON_DELETION"
+	MCPServerStatusDeleted              MCPServerStatus = "MCP_SERVER_STATUS_DELETED"
+	MCPServerStatusOnSuspending         MCPServerStatus = "MCP_SERVER_STATUS_ON_SUSPENDING"
+	MCPServerStatusSuspended            MCPServerStatus = "MCP_SERVER_STATUS_SUSPENDED"
+	MCPServerStatusFailed               MCPServerStatus = "MCP_SERVER_STATUS_FAILED"
+	MCPServerStatusRunning              MCPServerStatus = "MCP_SERVER_STATUS_RUNNING"
+	MCPServerStatusCooled               MCPServerStatus = "MCP_SERVER_STATUS_COOLED"
+)
+
+// MCPServerStatusValues - все значения MCPServerStatus в порядке спецификации
+var MCPServerStatusValues = []MCPServerStatus{
+	MCPServerStatusUnknown,
+	MCPServerStatusOnResourceAllocation,
+	MCPServerStatusAvailable,
+	MCPServerStatusImageUnavailable,
+	MCPServerStatusWaitingForScrapping,
+	MCPServerStatusOnDeletion,
+	MCPServerStatusDeleted,
+	MCPServerStatusOnSuspending,
+	MCPServerStatusSuspended,
+	MCPServerStatusFailed,
+	MCPServerStatusRunning,
+	MCPServerStatusCooled,
+}
+
+// MLInferenceOptions - Сообщение Опции Машинного Обучения - Определяет настройки ML инференса (commonMLInferenceOptions)
+type MLInferenceOptions struct {
+	// Идентификатор запуска модели (формат UUID)
+	ModelRunID string `json:"modelRunId,omitempty"`
+}
+
+// ObservabilityOptions - Сообщение Опций Наблюдаемости - Определяет настройки наблюдаемости (agent_systemObservabilityOptions)
+type ObservabilityOptions struct {
+	// Флаг включения наблюдаемости
+	IsEnabled bool `json:"isEnabled,omitempty"`
+}
+
+// ProjectStatus - Перечисление статусов проекта - Жизненные циклы состояний проекта (contractsProjectStatus)
+type ProjectStatus string
+
+const (
+	ProjectStatusNone         ProjectStatus = "NONE"
+	ProjectStatusOnActivation ProjectStatus = "ON_ACTIVATION"
+	ProjectStatusActive       ProjectStatus = "ACTIVE"
+	ProjectStatusOnSuspension ProjectStatus = "ON_SUSPENSION"
+	ProjectStatusSuspended    ProjectStatus = "SUSPENDED"
+	ProjectStatusOnResume     ProjectStatus = "ON_RESUME"
+	ProjectStatusOnDeletion   ProjectStatus = "ON_DELETION"
+	ProjectStatusDeleted      ProjectStatus = "DELETED"
+)
+
+// ProjectStatusValues - все значения ProjectStatus в порядке спецификации
+var ProjectStatusValues = []ProjectStatus{
+	ProjectStatusNone,
+	ProjectStatusOnActivation,
+	ProjectStatusActive,
+	ProjectStatusOnSuspension,
+	ProjectStatusSuspended,
+	ProjectStatusOnResume,
+	ProjectStatusOnDeletion,
+	ProjectStatusDeleted,
+}
+
+// ProtobufAny - Any contains an arbitrary serialized protocol buffer message along with a (protobufAny)
+type ProtobufAny map[string]interface{}
+
+// PublicURLCORS - Сообщение CORS для публичного URL контейнера - определяет параметры Cross-Origin Resource Sharing (commonPublicUrlCors)
+type PublicURLCORS struct {
+	// Разрешение учетных данных - это логическое поле, которое указывает, разрешено ли использование аутентификационных данных (cookies, HTTP Authentication) при выполнении запросов к URL публичного контейнера.
+	AllowCredential bool `json:"allowCredential,omitempty"`
+	// Разрешенные заголовки - поле строки, которое указывает заголовки, которые разрешено отправлять вместе с запросами к URL публичного контейнера.
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// Разрешенные методы - это поле строки, которое указывает методы HTTP, которые разрешены для выполнения запросов к URL публичного контейнера.
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// Разрешенные источники - это поле строки, которое указывает origins (источники), которым разрешено выполнять запросы к URL публичного контейнера.
+	AllowOrigins []string `json:"allowOrigins,omitempty"`
+	// Раскрываемые заголовки - это поле строки, которое указывает заголовки, которые разрешено раскрывать клиенту при выполнении запросов к URL публичного контейнера.
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// Включено ли использование CORS для публичного URL контейнера
+	IsEnabled bool `json:"isEnabled,omitempty"`
+}
+
+// Quota - Сообщение Квота - Определяет параметры квоты ресурса (commonQuota)
+type Quota struct {
+	// Лимит ресурсов
+	Limit float64 `json:"limit,omitempty"`
+	// Тип квоты
+	Type QuotaType `json:"type,omitempty"`
+	// Использовано ресурсов
+	Used float64 `json:"used,omitempty"`
+}
+
+// QuotaType - Перечисление Тип Квоты - Определяет возможные типы квот (commonQuotaType)
+type QuotaType string
+
+const (
+	QuotaTypeNone            QuotaType = "QUOTA_TYPE_NONE"
+	QuotaTypeCPU             QuotaType = "QUOTA_TYPE_CPU"
+	QuotaTypeRAM             QuotaType = "QUOTA_TYPE_RAM"
+	QuotaTypeAgentUsed       QuotaType = "QUOTA_TYPE_AGENT_USED"
+	QuotaTypeAgentSystemUsed QuotaType = "QUOTA_TYPE_AGENT_SYSTEM_USED"
+	QuotaTypeMCPServersUsed  QuotaType = "QUOTA_TYPE_MCP_SERVERS_USED"
+)
+
+// QuotaTypeValues - все значения QuotaType в порядке спецификации
+var QuotaTypeValues = []QuotaType{
+	QuotaTypeNone,
+	QuotaTypeCPU,
+	QuotaTypeRAM,
+	QuotaTypeAgentUsed,
+	QuotaTypeAgentSystemUsed,
+	QuotaTypeMCPServersUsed,
+}
+
+// RPCStatus - rpcStatus
+type RPCStatus struct {
+	Code    int           `json:"code,omitempty"`
+	Details []ProtobufAny `json:"details,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
+
+// RPSType - Сообщение Тип RPS - Определяет правила масштабирования по RPS (commonRPSType)
+type RPSType struct {
+	// Значение RPS (запросы в секунду, 0 или больше)
+	Value int `json:"value,omitempty"`
+}
+
+// ReasonType - Перечисление Тип Причины - Классифицирует различные типы причин статуса (commonReasonType)
+type ReasonType string
+
+const (
+	ReasonTypeInternal         ReasonType = "REASON_TYPE_INTERNAL"
+	ReasonTypeValidation       ReasonType = "REASON_TYPE_VALIDATION"
+	ReasonTypeIntegrational    ReasonType = "REASON_TYPE_INTEGRATIONAL"
+	ReasonTypeNotEnoughQuotes  ReasonType = "REASON_TYPE_NOT_ENOUGH_QUOTES"
+	ReasonTypeNotEnoughBalance ReasonType = "REASON_TYPE_NOT_ENOUGH_BALANCE"
+)
+
+// ReasonTypeValues - все значения ReasonType в порядке спецификации
+var ReasonTypeValues = []ReasonType{
+	ReasonTypeInternal,
+	ReasonTypeValidation,
+	ReasonTypeIntegrational,
+	ReasonTypeNotEnoughQuotes,
+	ReasonTypeNotEnoughBalance,
+}
+
+// ResumeAgentRequest - Запрос Возобновления Агента - Параметры для одиночного возобновления агента (AgentManagementServiceResumeAgentBody)
+type ResumeAgentRequest struct {
+}
+
+// ResumeAgentResponse - Ответ Возобновления Агента - Подтверждение операции возобновления (contractsResumeAgentResponse)
+type ResumeAgentResponse struct {
+}
+
+// ResumeAgentSystemRequest - Запрос Возобновления Системы Агентов - Параметры для одиночного возобновления системы (AgentSystemManagementServiceResumeAgentSystemBody)
+type ResumeAgentSystemRequest struct {
+}
+
+// ResumeAgentSystemResponse - Ответ Возобновления Системы Агентов - Подтверждение операции возобновления (contractsResumeAgentSystemResponse)
+type ResumeAgentSystemResponse struct {
+}
+
+// ResumeMCPServerRequest - Запрос на возобновление сервера MCP - Параметры для возобновления одного сервера (MCPServerManagementServiceResumeMCPServerBody)
+type ResumeMCPServerRequest struct {
+}
+
+// ResumeMCPServerResponse - Ответ на возобновление сервера MCP - Подтверждение операции возобновления (contractsResumeMCPServerResponse)
+type ResumeMCPServerResponse struct {
+}
+
+// Scaling - Сообщение Масштабирование - Определяет правила масштабирования для сервисов (commonScaling)
+type Scaling struct {
+	// Флаг поддержания активности
+	IsKeepAlive bool `json:"isKeepAlive,omitempty"`
+	// Флаг масштабирования всей системы
+	IsScaleUpAllSystem bool `json:"isScaleUpAllSystem,omitempty"`
+	// Продолжительность поддержания активности
+	KeepAliveDuration Duration `json:"keepAliveDuration,omitzero"`
+	// Максимальный уровень масштабирования (до 20)
+	MaxScale int `json:"maxScale,omitempty"`
+	// Минимальный уровень масштабирования (0 или больше)
+	MinScale int `json:"minScale,omitempty"`
+	// Правила масштабирования
+	ScalingRules ScalingRules `json:"scalingRules,omitzero"`
+}
+
+// ScalingRules - Сообщение Правила Масштабирования - Определяет тип правил масштабирования (commonScalingRules)
+type ScalingRules struct {
+	// Тип по параллелизму
+	Concurrency ConcurrencyType `json:"concurrency,omitzero"`
+	// Тип по RPS (запросы в секунду)
+	RPS RPSType `json:"rps,omitzero"`
+}
+
+// SearchAgentHistoryResponse - Ответ Поиска Истории Агента - Набор результатов и счетчик общего количества (contractsSearchAgentHistoryResponse)
+type SearchAgentHistoryResponse struct {
+	// Данные результата (события)
+	Data []AgentHistoryEvent `json:"data,omitempty"`
+	// Общее количество событий истории
+	Total int `json:"total,omitempty"`
+}
+
+// SearchAgentResponse - Ответ Поиска Агентов - Набор результатов и счетчик общего количества (contractsSearchAgentResponse)
+type SearchAgentResponse struct {
+	// Данные результата (максимум 100 элементов)
+	Data []AgentPreview `json:"data,omitempty"`
+	// Общее количество элементов, соответствующих фильтру
+	Total int `json:"total,omitempty"`
+}
+
+// SearchAgentSystemHistoryResponse - Ответ на поиск истории системы агентов - Набор результатов и счетчик общего количества (contractsSearchAgentSystemHistoryResponse)
+type SearchAgentSystemHistoryResponse struct {
+	// Данные результата (события)
+	Data []AgentSystemHistoryEvent `json:"data,omitempty"`
+	// Общее количество событий истории
+	Total int `json:"total,omitempty"`
+}
+
+// SearchAgentSystemResponse - Ответ Поиска Системы Агентов - Набор результатов и счетчик общего количества (contractsSearchAgentSystemResponse)
+type SearchAgentSystemResponse struct {
+	// Данные результата (максимум 100 элементов)
+	Data []AgentSystemPreview `json:"data,omitempty"`
+	// Общее количество элементов, соответствующих фильтру
+	Total int `json:"total,omitempty"`
+}
+
+// SearchInstanceTypeResponse - Search Instance Type Response - Результаты поиска и общий счетчик (contractsSearchInstanceTypeResponse)
+type SearchInstanceTypeResponse struct {
+	// Результаты данных (максимум 100 элементов)
+	Data []InstanceType `json:"data,omitempty"`
+	// Общее количество элементов, соответствующих фильтру
+	Total int `json:"total,omitempty"`
+}
+
+// SearchMCPServerHistoryResponse - Ответ на поиск истории изменений сервера MCP - Результаты и общее количество событий (contractsSearchMCPServerHistoryResponse)
+type SearchMCPServerHistoryResponse struct {
+	// Результаты данных (события)
+	Data []MCPServerHistoryEvent `json:"data,omitempty"`
+	// Общее количество событий истории
+	Total int `json:"total,omitempty"`
+}
+
+// SearchMCPServerResponse - Ответ на поиск серверов MCP - Результаты и общее количество элементов (contractsSearchMCPServerResponse)
+type SearchMCPServerResponse struct {
+	// Результаты данных (максимум 100 элементов)
+	Data []MCPServerPreview `json:"data,omitempty"`
+	// Общее количество элементов, соответствующих фильтру
+	Total int `json:"total,omitempty"`
+}
+
+// SearchPredefinedAgentResponse - Ответ на поиск предопределённых агентов - Результаты поиска (contractsSearchPredefinedAgentResponse)
+type SearchPredefinedAgentResponse struct {
+	// Список всех категорий
+	Categories []string `json:"categories,omitempty"`
+	// Список найденных предопределённых агентов (максимум 100)
+	Data []AgentPredefined `json:"data,omitempty"`
+	// Список всех тегов
+	Tags []string `json:"tags,omitempty"`
+	// Общее количество найденных агентов
+	Total int `json:"total,omitempty"`
+}
+
+// SearchPredefinedMCPServerResponse - Ответ на поиск предопределённых серверов MCP - Результаты поиска (contractsSearchPredefinedMCPServerResponse)
+type SearchPredefinedMCPServerResponse struct {
+	// Список всех категорий
+	Categories []string `json:"categories,omitempty"`
+	// Список найденных предопределённых серверов MCP (максимум 100)
+	Data []MCPServerPredefined `json:"data,omitempty"`
+	// Список всех тегов
+	Tags []string `json:"tags,omitempty"`
+	// Общее количество найденных серверов
+	Total int `json:"total,omitempty"`
+}
+
+// SecretLocator - Сообщение Локатор Секрета - Определяет ссылку на секрет (commonSecretLocator)
+type SecretLocator struct {
+	// Идентификатор секрета (формат UUID)
+	ID string `json:"id,omitempty"`
+	// Версия секрета (не меньше 1)
+	Version int `json:"version,omitempty"`
+}
+
+// StatusReason - Сообщение Причина Статуса - Представляет статус ошибки с подробной информацией о причине (commonStatusReason)
+type StatusReason struct {
+	// Дополнительные атрибуты и метаданные для причины
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Уникальный идентификатор ключа причины
+	Key string `json:"key,omitempty"`
+	// Человекочитаемое сообщение, описывающее причину
+	Message string `json:"message,omitempty"`
+	// Тип причины статуса/ошибки
+	ReasonType ReasonType `json:"reasonType,omitempty"`
+}
+
+// SuspendAgentRequest - Запрос Приостановки Агента - Параметры для одиночной приостановки агента (AgentManagementServiceSuspendAgentBody)
+type SuspendAgentRequest struct {
+}
+
+// SuspendAgentResponse - Ответ Приостановки Агента - Подтверждение приостановки (contractsSuspendAgentResponse)
+type SuspendAgentResponse struct {
+}
+
+// SuspendAgentSystemRequest - Запрос Приостановки Системы Агентов - Параметры для одиночной приостановки системы (AgentSystemManagementServiceSuspendAgentSystemBody)
+type SuspendAgentSystemRequest struct {
+}
+
+// SuspendAgentSystemResponse - Ответ Приостановки Системы Агентов - Подтверждение приостановки (contractsSuspendAgentSystemResponse)
+type SuspendAgentSystemResponse struct {
+}
+
+// SuspendMCPServerRequest - Запрос на приостановку работы сервера MCP - Параметры для приостановки одного сервера (MCPServerManagementServiceSuspendMCPServerBody)
+type SuspendMCPServerRequest struct {
+}
+
+// SuspendMCPServerResponse - Ответ на приостановку работы сервера MCP - Подтверждение приостановки (contractsSuspendMCPServerResponse)
+type SuspendMCPServerResponse struct {
+}
+
+// Tool - mcp_serverTool
+type Tool struct {
+	Args        []ToolArg `json:"args,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Name        string    `json:"name,omitempty"`
+}
+
+// ToolArg - mcp_serverToolArg
+type ToolArg struct {
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// UpdateAgentRequest - Запрос Обновления Агента - Параметры для изменения свойств агента (AgentManagementServiceUpdateAgentBody)
+type UpdateAgentRequest struct {
+	// Описание агента (максимум 125 символов)
+	Description string `json:"description,omitempty"`
+	// Источник образа контейнера
+	ImageSource AgentImageSource `json:"imageSource,omitzero"`
+	// Идентификатор конфигурации (формат UUID)
+	InstanceTypeID     string             `json:"instanceTypeId,omitempty"`
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Связанные MCP серверы (максимум 10)
+	MCPServers []AgentMCPServer `json:"mcpServers,omitempty"`
+	// Имя агента (3-125 символов, строчные буквы с дефисами, должен начинаться/заканчиваться буквенно-цифровым)
+	Name string `json:"name,omitempty"`
+	// Специфичные для агента опции конфигурации
+	Options AgentOptions `json:"options,omitzero"`
+}
+
+// UpdateAgentResponse - Ответ Обновления Агента - Подтверждение операции обновления (contractsUpdateAgentResponse)
+type UpdateAgentResponse struct {
+}
+
+// UpdateAgentSystemRequest - Запрос Обновления Системы Агентов - Параметры для изменения свойств системы (AgentSystemManagementServiceUpdateAgentSystemBody)
+type UpdateAgentSystemRequest struct {
+	// Агенты, участвующие в системе (максимум 10)
+	Agents []AgentSystemAgent `json:"agents,omitempty"`
+	// Описание системы (максимум 125 символов)
+	Description string `json:"description,omitempty"`
+	// Идентификатор конфигурации (формат UUID)
+	InstanceTypeID string `json:"instanceTypeId,omitempty"`
+	// Опции интеграции (аутентификация, CORS и т.д.)
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Имя системы (3-125 символов, строчные буквы с дефисами)
+	Name string `json:"name,omitempty"`
+	// Опции на уровне системы
+	Options AgentSystemOptions `json:"options,omitzero"`
+	// Опции оркестратора (маршрутизация/координация)
+	OrchestratorOptions AgentSystemOrchestratorOptions `json:"orchestratorOptions,omitzero"`
+}
+
+// UpdateAgentSystemResponse - Ответ Обновления Системы Агентов - Подтверждение операции обновления (contractsUpdateAgentSystemResponse)
+type UpdateAgentSystemResponse struct {
+}
+
+// UpdateMCPServerRequest - Запрос на обновление сервера MCP - Параметры для изменения свойств сервера (MCPServerManagementServiceUpdateMCPServerBody)
+type UpdateMCPServerRequest struct {
+	// Описание сервера (максимум 125 символов)
+	Description string `json:"description,omitempty"`
+	// Опции окружения (переменные окружения)
+	EnvironmentOptions EnvironmentOptions `json:"environmentOptions,omitzero"`
+	// Выставленные TCP-порты
+	ExposedPorts []int `json:"exposedPorts,omitempty"`
+	// Источник образа контейнера
+	ImageSource MCPServerImageSource `json:"imageSource,omitzero"`
+	// Идентификатор конфигурации (в формате UUID)
+	InstanceTypeID     string             `json:"instanceTypeId,omitempty"`
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitzero"`
+	// Название сервера (3-125 символов, строчные буквы с дефисами)
+	Name    string  `json:"name,omitempty"`
+	Scaling Scaling `json:"scaling,omitzero"`
+}
+
+// UpdateMCPServerResponse - Ответ на обновление сервера MCP - Подтверждение операции обновления (contractsUpdateMCPServerResponse)
+type UpdateMCPServerResponse struct {
+}
@@ -0,0 +1,1279 @@
+// Code generated by swaggergen from service.swagger.json. DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AgentManagementService - операции AgentManagementService
+type AgentManagementService struct {
+	client *Client
+}
+
+// NewAgentManagementService создает сервис операций AgentManagementService
+func NewAgentManagementService(client *Client) *AgentManagementService {
+	return &AgentManagementService{client: client}
+}
+
+// BulkDeleteAgentParams - query параметры BulkDeleteAgent
+type BulkDeleteAgentParams struct {
+	// Список идентификаторов агентов для удаления (список UUID, максимум 100)
+	AgentIDs []string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *BulkDeleteAgentParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	for _, v := range p.AgentIDs {
+		values.Add("agentIds", v)
+	}
+	return values
+}
+
+// BulkDeleteAgent - Массовое удаление нескольких агентов (DELETE /api/v1/{projectId}/agents)
+func (s *AgentManagementService) BulkDeleteAgent(ctx context.Context, params *BulkDeleteAgentParams) (*BulkDeleteAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents", url.PathEscape(s.client.projectID))
+
+	var result BulkDeleteAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkResumeAgent - Массовое возобновление нескольких агентов (PATCH /api/v1/{projectId}/agents/resume)
+func (s *AgentManagementService) BulkResumeAgent(ctx context.Context, body *BulkResumeAgentRequest) (*BulkResumeAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/resume", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &BulkResumeAgentRequest{}
+	}
+
+	var result BulkResumeAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkSuspendAgent - Массовая приостановка нескольких агентов (PATCH /api/v1/{projectId}/agents/suspend)
+func (s *AgentManagementService) BulkSuspendAgent(ctx context.Context, body *BulkSuspendAgentRequest) (*BulkSuspendAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/suspend", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &BulkSuspendAgentRequest{}
+	}
+
+	var result BulkSuspendAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAgent - Создать новый экземпляр ИИ агента (POST /api/v1/{projectId}/agents)
+func (s *AgentManagementService) CreateAgent(ctx context.Context, body *CreateAgentRequest) (*CreateAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &CreateAgentRequest{}
+	}
+
+	var result CreateAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPost,
+		Path:   path,
+		Body:   body,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAgent - Удалить один экземпляр агента (DELETE /api/v1/{projectId}/agents/{agentId})
+func (s *AgentManagementService) DeleteAgent(ctx context.Context, agentID string) (*DeleteAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentID))
+
+	var result DeleteAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAgent - Получить подробную информацию об агенте (GET /api/v1/{projectId}/agents/{agentId})
+func (s *AgentManagementService) GetAgent(ctx context.Context, agentID string) (*GetAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentID))
+
+	var result GetAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResumeAgent - Возобновить операцию агента (PATCH /api/v1/{projectId}/agents/resume/{agentId})
+func (s *AgentManagementService) ResumeAgent(ctx context.Context, agentID string, body *ResumeAgentRequest) (*ResumeAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/resume/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentID))
+	if body == nil {
+		body = &ResumeAgentRequest{}
+	}
+
+	var result ResumeAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchAgentParams - query параметры SearchAgent
+type SearchAgentParams struct {
+	// Размер страницы (0..100)
+	Limit int
+	// Смещение страницы (>= 0)
+	Offset int
+	// Фильтр по имени агента (опционально)
+	Name string
+	// Фильтр по конкретным ID агентов (список UUID, максимум 100)
+	AgentIDs []string
+	// Фильтр по использованию MCP серверов (список UUID, максимум 100)
+	UsesMCPServersIDs []string
+	// Фильтр по статусам
+	Statuses []AgentStatus
+	// Исключить агентов с этими статусами
+	NotInStatuses []AgentStatus
+	// Строка свободного текстового поиска
+	Search    string
+	UpdatedBy string
+	CreatedBy string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchAgentParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Name != "" {
+		values.Set("name", p.Name)
+	}
+	for _, v := range p.AgentIDs {
+		values.Add("agentIds", v)
+	}
+	for _, v := range p.UsesMCPServersIDs {
+		values.Add("usesMcpServersIds", v)
+	}
+	for _, v := range p.Statuses {
+		values.Add("statuses", string(v))
+	}
+	for _, v := range p.NotInStatuses {
+		values.Add("notInStatuses", string(v))
+	}
+	if p.Search != "" {
+		values.Set("search", p.Search)
+	}
+	if p.UpdatedBy != "" {
+		values.Set("updatedBy", p.UpdatedBy)
+	}
+	if p.CreatedBy != "" {
+		values.Set("createdBy", p.CreatedBy)
+	}
+	return values
+}
+
+// SearchAgent - Поиск и фильтрация агентов (GET /api/v1/{projectId}/agents)
+func (s *AgentManagementService) SearchAgent(ctx context.Context, params *SearchAgentParams) (*SearchAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents", url.PathEscape(s.client.projectID))
+
+	var result SearchAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchAgentHistoryParams - query параметры SearchAgentHistory
+type SearchAgentHistoryParams struct {
+	// Размер страницы
+	Limit int
+	// Смещение страницы
+	Offset    int
+	UpdatedBy string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchAgentHistoryParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.UpdatedBy != "" {
+		values.Set("updatedBy", p.UpdatedBy)
+	}
+	return values
+}
+
+// SearchAgentHistory - Получить историю изменений агента (GET /api/v1/{projectId}/agents/{agentId}/history)
+func (s *AgentManagementService) SearchAgentHistory(ctx context.Context, agentID string, params *SearchAgentHistoryParams) (*SearchAgentHistoryResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/%s/history", url.PathEscape(s.client.projectID), url.PathEscape(agentID))
+
+	var result SearchAgentHistoryResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SuspendAgent - Приостановить операцию агента (PATCH /api/v1/{projectId}/agents/suspend/{agentId})
+func (s *AgentManagementService) SuspendAgent(ctx context.Context, agentID string, body *SuspendAgentRequest) (*SuspendAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/suspend/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentID))
+	if body == nil {
+		body = &SuspendAgentRequest{}
+	}
+
+	var result SuspendAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAgent - Обновить конфигурацию агента (PATCH /api/v1/{projectId}/agents/{agentId})
+func (s *AgentManagementService) UpdateAgent(ctx context.Context, agentID string, body *UpdateAgentRequest) (*UpdateAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agents/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentID))
+	if body == nil {
+		body = &UpdateAgentRequest{}
+	}
+
+	var result UpdateAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AgentSystemManagementService - операции AgentSystemManagementService
+type AgentSystemManagementService struct {
+	client *Client
+}
+
+// NewAgentSystemManagementService создает сервис операций AgentSystemManagementService
+func NewAgentSystemManagementService(client *Client) *AgentSystemManagementService {
+	return &AgentSystemManagementService{client: client}
+}
+
+// AddAgentToAgentSystem - Добавить агента в систему агентов (PATCH /api/v1/{projectId}/agentSystems/{agentSystemId}/{agentId})
+func (s *AgentSystemManagementService) AddAgentToAgentSystem(ctx context.Context, agentSystemID string, agentID string) (*AddAgentAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/%s/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID), url.PathEscape(agentID))
+
+	var result AddAgentAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkDeleteAgentSystemParams - query параметры BulkDeleteAgentSystem
+type BulkDeleteAgentSystemParams struct {
+	// Список идентификаторов систем агентов для удаления (список UUID, максимум 100)
+	AgentSystemIDs []string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *BulkDeleteAgentSystemParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	for _, v := range p.AgentSystemIDs {
+		values.Add("agentSystemIds", v)
+	}
+	return values
+}
+
+// BulkDeleteAgentSystem - Массовое удаление нескольких систем агентов (DELETE /api/v1/{projectId}/agentSystems)
+func (s *AgentSystemManagementService) BulkDeleteAgentSystem(ctx context.Context, params *BulkDeleteAgentSystemParams) (*BulkDeleteAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems", url.PathEscape(s.client.projectID))
+
+	var result BulkDeleteAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkResumeAgentSystem - Массовое возобновление нескольких систем агентов (PATCH /api/v1/{projectId}/agentSystems/resume)
+func (s *AgentSystemManagementService) BulkResumeAgentSystem(ctx context.Context, body *BulkResumeAgentSystemRequest) (*BulkResumeAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/resume", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &BulkResumeAgentSystemRequest{}
+	}
+
+	var result BulkResumeAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkSuspendAgentSystem - Массовая приостановка нескольких систем агентов (PATCH /api/v1/{projectId}/agentSystems/suspend)
+func (s *AgentSystemManagementService) BulkSuspendAgentSystem(ctx context.Context, body *BulkSuspendAgentSystemRequest) (*BulkSuspendAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/suspend", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &BulkSuspendAgentSystemRequest{}
+	}
+
+	var result BulkSuspendAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAgentSystem - Создать новый экземпляр системы агентов (POST /api/v1/{projectId}/agentSystems)
+func (s *AgentSystemManagementService) CreateAgentSystem(ctx context.Context, body *CreateAgentSystemRequest) (*CreateAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &CreateAgentSystemRequest{}
+	}
+
+	var result CreateAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPost,
+		Path:   path,
+		Body:   body,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAgentFromAgentSystem - Удалить агента из системы агентов (DELETE /api/v1/{projectId}/agentSystems/{agentSystemId}/{agentId})
+func (s *AgentSystemManagementService) DeleteAgentFromAgentSystem(ctx context.Context, agentSystemID string, agentID string) (*DeleteAgentAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/%s/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID), url.PathEscape(agentID))
+
+	var result DeleteAgentAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAgentSystem - Удалить один экземпляр системы агентов (DELETE /api/v1/{projectId}/agentSystems/{agentSystemId})
+func (s *AgentSystemManagementService) DeleteAgentSystem(ctx context.Context, agentSystemID string) (*DeleteAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID))
+
+	var result DeleteAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAgentSystem - Получить подробную информацию о системе агентов (GET /api/v1/{projectId}/agentSystems/{agentSystemId})
+func (s *AgentSystemManagementService) GetAgentSystem(ctx context.Context, agentSystemID string) (*GetAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID))
+
+	var result GetAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResumeAgentSystem - Возобновить операцию системы агентов (PATCH /api/v1/{projectId}/agentSystems/resume/{agentSystemId})
+func (s *AgentSystemManagementService) ResumeAgentSystem(ctx context.Context, agentSystemID string, body *ResumeAgentSystemRequest) (*ResumeAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/resume/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID))
+	if body == nil {
+		body = &ResumeAgentSystemRequest{}
+	}
+
+	var result ResumeAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchAgentSystemParams - query параметры SearchAgentSystem
+type SearchAgentSystemParams struct {
+	// Размер страницы (0..100)
+	Limit int
+	// Смещение страницы (>= 0)
+	Offset int
+	// Фильтр по имени системы агентов (опционально)
+	Name string
+	// Фильтр по конкретным ID систем агентов (список UUID, максимум 100)
+	AgentSystemIDs []string
+	// Фильтр по используемым ID агентов (список UUID, максимум 100)
+	UsesAgentIDs []string
+	// Фильтр по статусам
+	Statuses []AgentSystemStatus
+	// Исключить системы с этими статусами
+	NotInStatuses []AgentSystemStatus
+	// Строка свободного текстового поиска
+	Search    string
+	UpdatedBy string
+	CreatedBy string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchAgentSystemParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Name != "" {
+		values.Set("name", p.Name)
+	}
+	for _, v := range p.AgentSystemIDs {
+		values.Add("agentSystemIds", v)
+	}
+	for _, v := range p.UsesAgentIDs {
+		values.Add("usesAgentIds", v)
+	}
+	for _, v := range p.Statuses {
+		values.Add("statuses", string(v))
+	}
+	for _, v := range p.NotInStatuses {
+		values.Add("notInStatuses", string(v))
+	}
+	if p.Search != "" {
+		values.Set("search", p.Search)
+	}
+	if p.UpdatedBy != "" {
+		values.Set("updatedBy", p.UpdatedBy)
+	}
+	if p.CreatedBy != "" {
+		values.Set("createdBy", p.CreatedBy)
+	}
+	return values
+}
+
+// SearchAgentSystem - Поиск и фильтрация систем агентов (GET /api/v1/{projectId}/agentSystems)
+func (s *AgentSystemManagementService) SearchAgentSystem(ctx context.Context, params *SearchAgentSystemParams) (*SearchAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems", url.PathEscape(s.client.projectID))
+
+	var result SearchAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchAgentSystemHistoryParams - query параметры SearchAgentSystemHistory
+type SearchAgentSystemHistoryParams struct {
+	// Размер страницы
+	Limit int
+	// Смещение страницы
+	Offset int
+	// Обновлен пользователем
+	UpdatedBy string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchAgentSystemHistoryParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.UpdatedBy != "" {
+		values.Set("updatedBy", p.UpdatedBy)
+	}
+	return values
+}
+
+// SearchAgentSystemHistory - Получить историю изменений системы агентов (GET /api/v1/{projectId}/agentSystems/{agentSystemId}/history)
+func (s *AgentSystemManagementService) SearchAgentSystemHistory(ctx context.Context, agentSystemID string, params *SearchAgentSystemHistoryParams) (*SearchAgentSystemHistoryResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/%s/history", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID))
+
+	var result SearchAgentSystemHistoryResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SuspendAgentSystem - Приостановить операцию системы агентов (PATCH /api/v1/{projectId}/agentSystems/suspend/{agentSystemId})
+func (s *AgentSystemManagementService) SuspendAgentSystem(ctx context.Context, agentSystemID string, body *SuspendAgentSystemRequest) (*SuspendAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/suspend/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID))
+	if body == nil {
+		body = &SuspendAgentSystemRequest{}
+	}
+
+	var result SuspendAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAgentSystem - Обновить конфигурацию системы агентов (PATCH /api/v1/{projectId}/agentSystems/{agentSystemId})
+func (s *AgentSystemManagementService) UpdateAgentSystem(ctx context.Context, agentSystemID string, body *UpdateAgentSystemRequest) (*UpdateAgentSystemResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/agentSystems/%s", url.PathEscape(s.client.projectID), url.PathEscape(agentSystemID))
+	if body == nil {
+		body = &UpdateAgentSystemRequest{}
+	}
+
+	var result UpdateAgentSystemResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CommonService - операции CommonService
+type CommonService struct {
+	client *Client
+}
+
+// NewCommonService создает сервис операций CommonService
+func NewCommonService(client *Client) *CommonService {
+	return &CommonService{client: client}
+}
+
+// GetInstanceType - Получить информацию о конфигурации (GET /api/v1/{projectId}/instanceTypes/{instanceTypeId})
+func (s *CommonService) GetInstanceType(ctx context.Context, instanceTypeID string) (*GetInstanceTypeResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/instanceTypes/%s", url.PathEscape(s.client.projectID), url.PathEscape(instanceTypeID))
+
+	var result GetInstanceTypeResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchInstanceTypesParams - query параметры SearchInstanceTypes
+type SearchInstanceTypesParams struct {
+	// Размер страницы (от 0 до 100)
+	Limit int
+	// Смещение страницы (от 0)
+	Offset int
+	// Название конфигурации (необязательное поле)
+	Name string
+	// Фильтр по идентификаторам конфигураций (список UUID, максимум 100)
+	InstanceTypeIDs []string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchInstanceTypesParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Name != "" {
+		values.Set("name", p.Name)
+	}
+	for _, v := range p.InstanceTypeIDs {
+		values.Add("instanceTypeIds", v)
+	}
+	return values
+}
+
+// SearchInstanceTypes - Поиск конфигураций (GET /api/v1/{projectId}/instanceTypes)
+func (s *CommonService) SearchInstanceTypes(ctx context.Context, params *SearchInstanceTypesParams) (*SearchInstanceTypeResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/instanceTypes", url.PathEscape(s.client.projectID))
+
+	var result SearchInstanceTypeResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MCPServerManagementService - операции MCPServerManagementService
+type MCPServerManagementService struct {
+	client *Client
+}
+
+// NewMCPServerManagementService создает сервис операций MCPServerManagementService
+func NewMCPServerManagementService(client *Client) *MCPServerManagementService {
+	return &MCPServerManagementService{client: client}
+}
+
+// BulkDeleteMCPServerParams - query параметры BulkDeleteMCPServer
+type BulkDeleteMCPServerParams struct {
+	// Список идентификаторов серверов MCP для удаления (список UUID, максимум 100)
+	MCPServerIDs []string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *BulkDeleteMCPServerParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	for _, v := range p.MCPServerIDs {
+		values.Add("mcpServerIds", v)
+	}
+	return values
+}
+
+// BulkDeleteMCPServer - Массовое удаление нескольких MCP серверов (DELETE /api/v1/{projectId}/mcpServers)
+func (s *MCPServerManagementService) BulkDeleteMCPServer(ctx context.Context, params *BulkDeleteMCPServerParams) (*BulkDeleteMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers", url.PathEscape(s.client.projectID))
+
+	var result BulkDeleteMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkResumeMCPServer - Массовое возобновление нескольких MCP серверов (PATCH /api/v1/{projectId}/mcpServers/resume)
+func (s *MCPServerManagementService) BulkResumeMCPServer(ctx context.Context, body *BulkResumeMCPServerRequest) (*BulkResumeMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/resume", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &BulkResumeMCPServerRequest{}
+	}
+
+	var result BulkResumeMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkSuspendMCPServer - Массовая приостановка нескольких MCP серверов (PATCH /api/v1/{projectId}/mcpServers/suspend)
+func (s *MCPServerManagementService) BulkSuspendMCPServer(ctx context.Context, body *BulkSuspendMCPServerRequest) (*BulkSuspendMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/suspend", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &BulkSuspendMCPServerRequest{}
+	}
+
+	var result BulkSuspendMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateMCPServer - Создать новый экземпляр MCP сервера (POST /api/v1/{projectId}/mcpServers)
+func (s *MCPServerManagementService) CreateMCPServer(ctx context.Context, body *CreateMCPServerRequest) (*CreateMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers", url.PathEscape(s.client.projectID))
+	if body == nil {
+		body = &CreateMCPServerRequest{}
+	}
+
+	var result CreateMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPost,
+		Path:   path,
+		Body:   body,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteMCPServer - Удалить один экземпляр MCP сервера (DELETE /api/v1/{projectId}/mcpServers/{mcpServerId})
+func (s *MCPServerManagementService) DeleteMCPServer(ctx context.Context, mcpServerID string) (*DeleteMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/%s", url.PathEscape(s.client.projectID), url.PathEscape(mcpServerID))
+
+	var result DeleteMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodDelete,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMCPServer - Получить подробную информацию о MCP сервере (GET /api/v1/{projectId}/mcpServers/{mcpServerId})
+func (s *MCPServerManagementService) GetMCPServer(ctx context.Context, mcpServerID string) (*GetMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/%s", url.PathEscape(s.client.projectID), url.PathEscape(mcpServerID))
+
+	var result GetMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResumeMCPServer - Возобновить работу MCP сервера (PATCH /api/v1/{projectId}/mcpServers/resume/{mcpServerId})
+func (s *MCPServerManagementService) ResumeMCPServer(ctx context.Context, mcpServerID string, body *ResumeMCPServerRequest) (*ResumeMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/resume/%s", url.PathEscape(s.client.projectID), url.PathEscape(mcpServerID))
+	if body == nil {
+		body = &ResumeMCPServerRequest{}
+	}
+
+	var result ResumeMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchMCPServerParams - query параметры SearchMCPServer
+type SearchMCPServerParams struct {
+	// Размер страницы (0..100)
+	Limit int
+	// Смещение страницы (>= 0)
+	Offset int
+	// Фильтр по конкретным идентификаторам серверов MCP (список UUID, максимум 100)
+	MCPServerIDs []string
+	// Фильтр по имени сервера MCP (опционально)
+	Name string
+	// Фильтр по статусам
+	Statuses []MCPServerStatus
+	// Исключение серверов с этими статусами
+	NotInStatuses []MCPServerStatus
+	// Строка свободного текстового поиска
+	Search string
+	// Идентификатор пользователя, который внес изменения (в формате UUID, опционально)
+	UpdatedBy string
+	// Идентификатор пользователя, который создал сервер (в формате UUID, опционально)
+	CreatedBy string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchMCPServerParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	for _, v := range p.MCPServerIDs {
+		values.Add("mcpServerIds", v)
+	}
+	if p.Name != "" {
+		values.Set("name", p.Name)
+	}
+	for _, v := range p.Statuses {
+		values.Add("statuses", string(v))
+	}
+	for _, v := range p.NotInStatuses {
+		values.Add("notInStatuses", string(v))
+	}
+	if p.Search != "" {
+		values.Set("search", p.Search)
+	}
+	if p.UpdatedBy != "" {
+		values.Set("updatedBy", p.UpdatedBy)
+	}
+	if p.CreatedBy != "" {
+		values.Set("createdBy", p.CreatedBy)
+	}
+	return values
+}
+
+// SearchMCPServer - Поиск и фильтрация MCP серверов (GET /api/v1/{projectId}/mcpServers)
+func (s *MCPServerManagementService) SearchMCPServer(ctx context.Context, params *SearchMCPServerParams) (*SearchMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers", url.PathEscape(s.client.projectID))
+
+	var result SearchMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchMCPServerHistoryParams - query параметры SearchMCPServerHistory
+type SearchMCPServerHistoryParams struct {
+	// Размер страницы
+	Limit int
+	// Смещение страницы
+	Offset int
+	// Идентификатор пользователя, который внес изменения (в формате UUID, опционально)
+	UpdatedBy string
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchMCPServerHistoryParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.UpdatedBy != "" {
+		values.Set("updatedBy", p.UpdatedBy)
+	}
+	return values
+}
+
+// SearchMCPServerHistory - Получить историю изменений MCP сервера (GET /api/v1/{projectId}/mcpServers/{mcpServerId}/history)
+func (s *MCPServerManagementService) SearchMCPServerHistory(ctx context.Context, mcpServerID string, params *SearchMCPServerHistoryParams) (*SearchMCPServerHistoryResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/%s/history", url.PathEscape(s.client.projectID), url.PathEscape(mcpServerID))
+
+	var result SearchMCPServerHistoryResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SuspendMCPServer - Приостановить работу MCP сервера (PATCH /api/v1/{projectId}/mcpServers/suspend/{mcpServerId})
+func (s *MCPServerManagementService) SuspendMCPServer(ctx context.Context, mcpServerID string, body *SuspendMCPServerRequest) (*SuspendMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/suspend/%s", url.PathEscape(s.client.projectID), url.PathEscape(mcpServerID))
+	if body == nil {
+		body = &SuspendMCPServerRequest{}
+	}
+
+	var result SuspendMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateMCPServer - Обновить конфигурацию MCP сервера (PATCH /api/v1/{projectId}/mcpServers/{mcpServerId})
+func (s *MCPServerManagementService) UpdateMCPServer(ctx context.Context, mcpServerID string, body *UpdateMCPServerRequest) (*UpdateMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s/mcpServers/%s", url.PathEscape(s.client.projectID), url.PathEscape(mcpServerID))
+	if body == nil {
+		body = &UpdateMCPServerRequest{}
+	}
+
+	var result UpdateMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+		Retry:  true,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MarketplaceAgentManagementService - операции MarketplaceAgentManagementService
+type MarketplaceAgentManagementService struct {
+	client *Client
+}
+
+// NewMarketplaceAgentManagementService создает сервис операций MarketplaceAgentManagementService
+func NewMarketplaceAgentManagementService(client *Client) *MarketplaceAgentManagementService {
+	return &MarketplaceAgentManagementService{client: client}
+}
+
+// GetPredefinedAgent - Получить подробную информацию об агенте маркетплейса (GET /api/v1/marketplace/agents/{id})
+func (s *MarketplaceAgentManagementService) GetPredefinedAgent(ctx context.Context, id string) (*GetPredefinedAgentResponse, error) {
+	path := fmt.Sprintf("/api/v1/marketplace/agents/%s", url.PathEscape(id))
+
+	var result GetPredefinedAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchPredefinedAgentParams - query параметры SearchPredefinedAgent
+type SearchPredefinedAgentParams struct {
+	// Лимит количества результатов (от 0 до 100)
+	Limit int
+	// Смещение для постраничной навигации (от 0)
+	Offset int
+	// Название агента (необязательное поле)
+	Name string
+	// Список идентификаторов агентов (список UUID, максимум 100)
+	AgentIDs []string
+	// Список тегов (максимум 100)
+	Tags []string
+	// Список категорий (максимум 100)
+	Categories []string
+	// Список статусов агентов (максимум 100)
+	Statuses []AgentPredefinedStatus
+	// Список типов агентов (максимум 100)
+	Types []AgentPredefinedType
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchPredefinedAgentParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Name != "" {
+		values.Set("name", p.Name)
+	}
+	for _, v := range p.AgentIDs {
+		values.Add("agentIds", v)
+	}
+	for _, v := range p.Tags {
+		values.Add("tags", v)
+	}
+	for _, v := range p.Categories {
+		values.Add("categories", v)
+	}
+	for _, v := range p.Statuses {
+		values.Add("statuses", string(v))
+	}
+	for _, v := range p.Types {
+		values.Add("types", string(v))
+	}
+	return values
+}
+
+// SearchPredefinedAgent - Поиск агентов в маркетплейсе (GET /api/v1/marketplace/agents)
+func (s *MarketplaceAgentManagementService) SearchPredefinedAgent(ctx context.Context, params *SearchPredefinedAgentParams) (*SearchPredefinedAgentResponse, error) {
+	path := "/api/v1/marketplace/agents"
+
+	var result SearchPredefinedAgentResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MarketplaceMCPServerManagementService - операции MarketplaceMCPServerManagementService
+type MarketplaceMCPServerManagementService struct {
+	client *Client
+}
+
+// NewMarketplaceMCPServerManagementService создает сервис операций MarketplaceMCPServerManagementService
+func NewMarketplaceMCPServerManagementService(client *Client) *MarketplaceMCPServerManagementService {
+	return &MarketplaceMCPServerManagementService{client: client}
+}
+
+// GetPredefinedMCPServer - Получить подробную информацию об MCP сервере маркетплейса (GET /api/v1/marketplace/mcpServers/{id})
+func (s *MarketplaceMCPServerManagementService) GetPredefinedMCPServer(ctx context.Context, id string) (*GetPredefinedMCPServerResponse, error) {
+	path := fmt.Sprintf("/api/v1/marketplace/mcpServers/%s", url.PathEscape(id))
+
+	var result GetPredefinedMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchPredefinedMCPServerParams - query параметры SearchPredefinedMCPServer
+type SearchPredefinedMCPServerParams struct {
+	// Лимит количества результатов (от 0 до 100)
+	Limit int
+	// Смещение для постраничной навигации (от 0)
+	Offset int
+	// Название сервера (необязательное поле)
+	Name string
+	// Список идентификаторов агентов (список UUID, максимум 100)
+	AgentIDs []string
+	// Список тегов (максимум 100)
+	Tags []string
+	// Список категорий (максимум 100)
+	Categories []string
+	// Список статусов серверов (максимум 100)
+	Status []MCPServerPredefinedStatus
+	// Список типов серверов (максимум 100)
+	Types []MCPServerPredefinedType
+}
+
+// values возвращает заданные параметры. Списки передаются
+// повторением параметра: statuses=A&statuses=B
+func (p *SearchPredefinedMCPServerParams) values() url.Values {
+	values := url.Values{}
+	if p == nil {
+		return values
+	}
+	if p.Limit != 0 {
+		values.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset != 0 {
+		values.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Name != "" {
+		values.Set("name", p.Name)
+	}
+	for _, v := range p.AgentIDs {
+		values.Add("agentIds", v)
+	}
+	for _, v := range p.Tags {
+		values.Add("tags", v)
+	}
+	for _, v := range p.Categories {
+		values.Add("categories", v)
+	}
+	for _, v := range p.Status {
+		values.Add("status", string(v))
+	}
+	for _, v := range p.Types {
+		values.Add("types", string(v))
+	}
+	return values
+}
+
+// SearchPredefinedMCPServer - Поиск MCP серверов в маркетплейсе (GET /api/v1/marketplace/mcpServers)
+func (s *MarketplaceMCPServerManagementService) SearchPredefinedMCPServer(ctx context.Context, params *SearchPredefinedMCPServerParams) (*SearchPredefinedMCPServerResponse, error) {
+	path := "/api/v1/marketplace/mcpServers"
+
+	var result SearchPredefinedMCPServerResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  params.values(),
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ProjectManagementService - операции ProjectManagementService
+type ProjectManagementService struct {
+	client *Client
+}
+
+// NewProjectManagementService создает сервис операций ProjectManagementService
+func NewProjectManagementService(client *Client) *ProjectManagementService {
+	return &ProjectManagementService{client: client}
+}
+
+// GetProjectInfo - Получить подробную информацию о проекте c квотами по продукту (GET /api/v1/{projectId})
+func (s *ProjectManagementService) GetProjectInfo(ctx context.Context) (*GetProjectInfoResponse, error) {
+	path := fmt.Sprintf("/api/v1/%s", url.PathEscape(s.client.projectID))
+
+	var result GetProjectInfoResponse
+	err := s.client.Do(ctx, RequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
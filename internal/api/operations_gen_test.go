@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAgentSystemService_Resume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Метод и путь берутся из спецификации, а не из /{id}/resume
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected PATCH request, got %s", r.Method)
+		}
+		expectedPath := "/api/v1/test-project/agentSystems/resume/system-1"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path '%s', got '%s'", expectedPath, r.URL.Path)
+		}
+
+		// Тело без полей передается пустым объектом
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if len(body) != 0 {
+			t.Errorf("Expected empty body, got %v", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	mockAuth := &MockIAMService{token: "test-token"}
+	client := NewClient(server.URL, "test-project", mockAuth)
+	service := NewAgentSystemService(client)
+
+	if err := service.Resume(context.Background(), "system-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestAgentManagementService_SearchAgentQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		// Списки передаются повторением параметра
+		if got := query["statuses"]; !reflect.DeepEqual(got, []string{"AGENT_STATUS_RUNNING", "AGENT_STATUS_COOLED"}) {
+			t.Errorf("Expected statuses to be repeated, got %v", got)
+		}
+		if got := query["usesMcpServersIds"]; !reflect.DeepEqual(got, []string{"mcp-1"}) {
+			t.Errorf("Expected usesMcpServersIds=mcp-1, got %v", got)
+		}
+		if query.Get("name") != "weather" {
+			t.Errorf("Expected name=weather, got %s", query.Get("name"))
+		}
+		if query.Has("search") {
+			t.Errorf("Expected no search parameter, got %s", query.Get("search"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SearchAgentResponse{
+			Data:  []AgentPreview{{ID: "agent-1", Status: AgentStatusRunning}},
+			Total: 1,
+		})
+	}))
+	defer server.Close()
+
+	mockAuth := &MockIAMService{token: "test-token"}
+	client := NewClient(server.URL, "test-project", mockAuth)
+	service := NewAgentManagementService(client)
+
+	result, err := service.SearchAgent(context.Background(), &SearchAgentParams{
+		Name:              "weather",
+		Statuses:          []AgentStatus{AgentStatusRunning, AgentStatusCooled},
+		UsesMCPServersIDs: []string{"mcp-1"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].Status != AgentStatusRunning {
+		t.Errorf("Unexpected result: %+v", result.Data)
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize - размер страницы, которым итераторы All обходят списки,
+// если в параметрах поиска не задан Limit
+const DefaultPageSize = 100
+
+// paginate возвращает итератор по всем элементам списка с пагинацией через
+// limit/offset. Следующая страница запрашивается только после того, как
+// вызывающий код обработал предыдущую. Обход заканчивается на неполной
+// странице или когда получено total элементов, если сервер вернул total.
+// Ошибка запроса передается вторым значением, после нее итерация прекращается.
+func paginate[T any](ctx context.Context, limit, offset int, fetch func(ctx context.Context, limit, offset int) ([]T, int, error)) iter.Seq2[T, error] {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		for {
+			items, total, err := fetch(ctx, limit, offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			offset += len(items)
+			// Ответ без total (0) обходится до неполной страницы
+			if len(items) < limit || (total > 0 && offset >= total) {
+				return
+			}
+		}
+	}
+}
+
+// paginateToken возвращает итератор по всем элементам списка с пагинацией
+// через токен следующей страницы. Обход заканчивается, когда сервер
+// возвращает пустой токен.
+func paginateToken[T any](ctx context.Context, fetch func(ctx context.Context, pageToken string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pageToken := ""
+		for {
+			items, next, err := fetch(ctx, pageToken)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if next == "" || next == pageToken {
+				return
+			}
+			pageToken = next
+		}
+	}
+}
+
+// Collect собирает все элементы итератора в срез. При ошибке возвращает
+// ее вместе с элементами, полученными до нее.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
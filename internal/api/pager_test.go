@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	var calls []int
+	fetch := func(ctx context.Context, limit, offset int) ([]int, int, error) {
+		calls = append(calls, offset)
+		end := min(offset+limit, len(items))
+		return items[offset:end], len(items), nil
+	}
+
+	got, err := Collect(paginate(context.Background(), 2, 0, fetch))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, items) {
+		t.Errorf("Expected %v, got %v", items, got)
+	}
+	if !reflect.DeepEqual(calls, []int{0, 2, 4}) {
+		t.Errorf("Expected offsets [0 2 4], got %v", calls)
+	}
+
+	// Следующая страница не запрашивается, если обход прерван
+	calls = nil
+	for item := range paginate(context.Background(), 2, 0, fetch) {
+		if item == 2 {
+			break
+		}
+	}
+	if len(calls) != 1 {
+		t.Errorf("Expected 1 request, got %d", len(calls))
+	}
+}
+
+func TestPaginate_WithoutTotal(t *testing.T) {
+	items := []int{1, 2, 3, 4}
+
+	var calls int
+	fetch := func(ctx context.Context, limit, offset int) ([]int, int, error) {
+		calls++
+		end := min(offset+limit, len(items))
+		return items[offset:end], 0, nil
+	}
+
+	got, err := Collect(paginate(context.Background(), 2, 0, fetch))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, items) {
+		t.Errorf("Expected %v, got %v", items, got)
+	}
+	// Две полные страницы и пустая, которая завершает обход
+	if calls != 3 {
+		t.Errorf("Expected 3 requests, got %d", calls)
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	fetchErr := errors.New("boom")
+	fetch := func(ctx context.Context, limit, offset int) ([]int, int, error) {
+		if offset > 0 {
+			return nil, 0, fetchErr
+		}
+		return []int{1, 2}, 10, nil
+	}
+
+	got, err := Collect(paginate(context.Background(), 2, 0, fetch))
+	if !errors.Is(err, fetchErr) {
+		t.Fatalf("Expected fetch error, got %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Expected items before error, got %v", got)
+	}
+}
+
+func TestPaginateToken(t *testing.T) {
+	pages := map[string]struct {
+		items []string
+		next  string
+	}{
+		"":   {[]string{"a", "b"}, "p2"},
+		"p2": {[]string{"c"}, "p3"},
+		"p3": {nil, ""},
+	}
+	fetch := func(ctx context.Context, pageToken string) ([]string, string, error) {
+		page := pages[pageToken]
+		return page.items, page.next, nil
+	}
+
+	got, err := Collect(paginateToken(context.Background(), fetch))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Expected [a b c], got %v", got)
+	}
+}
+
+func TestAgentService_All(t *testing.T) {
+	const total = 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		// Фильтры передаются в каждый запрос страницы
+		if query.Get("name") != "support" {
+			t.Errorf("Expected name=support, got %s", query.Get("name"))
+		}
+
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		var data []AgentPreview
+		for i := offset; i < min(offset+limit, total); i++ {
+			data = append(data, AgentPreview{ID: strconv.Itoa(i)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SearchAgentResponse{Data: data, Total: total})
+	}))
+	defer server.Close()
+
+	mockAuth := &MockIAMService{token: "test-token"}
+	client := NewClient(server.URL, "test-project", mockAuth)
+	service := NewAgentService(client)
+
+	agents, err := Collect(service.All(context.Background(), &SearchAgentParams{Name: "support", Limit: 2}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(agents) != total {
+		t.Fatalf("Expected %d agents, got %d", total, len(agents))
+	}
+	for i, agent := range agents {
+		if agent.ID != strconv.Itoa(i) {
+			t.Errorf("Expected agent %d, got %s", i, agent.ID)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// rotatingAuth выдает новый токен после каждого ClearToken
+type rotatingAuth struct {
+	generation int
+	cleared    int
+}
+
+func (a *rotatingAuth) GetToken(ctx context.Context) (string, error) {
+	return fmt.Sprintf("token-%d", a.generation), nil
+}
+
+func (a *rotatingAuth) IsAuthenticated() bool {
+	return true
+}
+
+func (a *rotatingAuth) ClearToken() {
+	a.cleared++
+	a.generation++
+}
+
+func TestClient_ReauthenticateOn401(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	authService := &rotatingAuth{}
+	client := NewClient(server.URL, "test-project", authService)
+
+	// POST не повторяется при временных ошибках, но повторяется после 401
+	var result map[string]string
+	if err := client.Post(context.Background(), "/test", map[string]string{"a": "b"}, &result); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if attempts.Load() != 2 || authService.cleared != 1 {
+		t.Errorf("attempts = %d, cleared = %d, want 2 and 1", attempts.Load(), authService.cleared)
+	}
+	if result["status"] != "ok" {
+		t.Errorf("result = %v", result)
+	}
+}
+
+func TestClient_ReauthenticateOnce(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	authService := &rotatingAuth{}
+	err := NewClient(server.URL, "test-project", authService).Get(context.Background(), "/test", nil, nil)
+
+	authErr, ok := err.(*AuthenticationError)
+	if !ok || authErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Get() error = %v, want authentication error with status 401", err)
+	}
+	if attempts.Load() != 2 || authService.cleared != 1 {
+		t.Errorf("attempts = %d, cleared = %d, want 2 and 1", attempts.Load(), authService.cleared)
+	}
+}
+
+func TestClient_ForbiddenIsNotReplayed(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	authService := &rotatingAuth{}
+	err := NewClient(server.URL, "test-project", authService).Get(context.Background(), "/test", nil, nil)
+
+	authErr, ok := err.(*AuthenticationError)
+	if !ok || authErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("Get() error = %v, want authentication error with status 403", err)
+	}
+	if attempts.Load() != 1 || authService.cleared != 0 {
+		t.Errorf("attempts = %d, cleared = %d, want 1 and 0", attempts.Load(), authService.cleared)
+	}
+}
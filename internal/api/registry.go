@@ -3,7 +3,10 @@ package api
 import (
 	"context"
 	"fmt"
+	"iter"
+	"net/url"
 	"os"
+	"strconv"
 )
 
 // RegistryCreateRequest представляет запрос на создание реестра
@@ -102,16 +105,17 @@ func (s *RegistryService) Create(ctx context.Context, req *RegistryCreateRequest
 	return &response, nil
 }
 
-// List возвращает список реестров
-func (s *RegistryService) List(ctx context.Context, limit, offset int) (*RegistryListResponse, error) {
+// List возвращает одну страницу списка реестров. pageToken - токен
+// NextPageToken из предыдущего ответа, для первой страницы пустой.
+func (s *RegistryService) List(ctx context.Context, pageSize int, pageToken string) (*RegistryListResponse, error) {
 	path := fmt.Sprintf("/v1/projects/%s/registries", s.client.projectID)
 
-	query := make(map[string]string)
-	if limit > 0 {
-		query["pageSize"] = fmt.Sprintf("%d", limit)
+	query := url.Values{}
+	if pageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(pageSize))
 	}
-	if offset > 0 {
-		query["pageToken"] = fmt.Sprintf("%d", offset)
+	if pageToken != "" {
+		query.Set("pageToken", pageToken)
 	}
 
 	// Используем специальный URL для Artifact Registry
@@ -129,6 +133,18 @@ func (s *RegistryService) List(ctx context.Context, limit, offset int) (*Registr
 	return &response, nil
 }
 
+// All возвращает итератор по всем реестрам проекта. Страницы размером
+// pageSize загружаются по мере обхода по токену следующей страницы.
+func (s *RegistryService) All(ctx context.Context, pageSize int) iter.Seq2[Registry, error] {
+	return paginateToken(ctx, func(ctx context.Context, pageToken string) ([]Registry, string, error) {
+		page, err := s.List(ctx, pageSize, pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		return page.Registries, page.NextPageToken, nil
+	})
+}
+
 // Get возвращает информацию о реестре по ID
 func (s *RegistryService) Get(ctx context.Context, registryID string) (*Registry, error) {
 	path := fmt.Sprintf("/v1/projects/%s/registries/%s", s.client.projectID, registryID)
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy задает повторные попытки запросов при временных ошибках:
+// сетевых ошибках и ответах 429, 502, 503, 504
+type RetryPolicy struct {
+	// MaxAttempts - максимальное количество попыток, включая первую.
+	// Значение меньше 2 отключает повторы.
+	MaxAttempts int
+	// BaseDelay - задержка перед первым повтором, далее удваивается
+	BaseDelay time.Duration
+	// MaxDelay - максимальная задержка перед повтором. Если Retry-After
+	// требует ждать дольше, запрос не повторяется.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy возвращает политику повторов по умолчанию
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// backoff возвращает задержку перед повтором после attempt попыток:
+// экспоненциальная задержка с полным джиттером
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 32 {
+		if d := p.BaseDelay << uint(shift); d > 0 && d < p.MaxDelay {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay) + 1
+}
+
+// idempotentMethods - методы, которые повторяются без RequestOptions.Retry
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryableStatus проверяет, что ответ с кодом status означает временную ошибку
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableError проверяет, что ошибку выполнения запроса можно повторить:
+// разрыв соединения или таймаут, но не отмена контекста вызывающего
+func retryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryAfter возвращает задержку из заголовка Retry-After: секунды или дата
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// retryDelay возвращает задержку перед повтором ответа resp или ошибки и
+// false, если запрос повторять не нужно
+func (p RetryPolicy) retryDelay(ctx context.Context, resp *http.Response, err error, attempt int) (time.Duration, string, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, "", false
+	}
+	if err != nil {
+		if !retryableError(ctx, err) {
+			return 0, "", false
+		}
+		return p.backoff(attempt), err.Error(), true
+	}
+	if !retryableStatus(resp.StatusCode) {
+		return 0, "", false
+	}
+	reason := fmt.Sprintf("status %d", resp.StatusCode)
+	if delay, ok := retryAfter(resp, time.Now()); ok {
+		if delay > p.MaxDelay {
+			return 0, "", false
+		}
+		return delay, reason, true
+	}
+	return p.backoff(attempt), reason, true
+}
+
+// sleep ждет delay или отмены контекста
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryClient создает клиент с короткими задержками повторов
+func newRetryClient(url string, maxAttempts int) *Client {
+	client := NewClient(url, "test-project", &MockIAMService{token: "test-token"})
+	client.SetRetryPolicy(RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    50 * time.Millisecond,
+	})
+	return client
+}
+
+func TestClient_RetryTransientStatus(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempts.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"status":"ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	var result map[string]string
+	if err := newRetryClient(server.URL, 4).Get(context.Background(), "/test", nil, &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("attempts = %d, want 3", attempts.Load())
+	}
+	if result["status"] != "ok" {
+		t.Errorf("result = %v", result)
+	}
+}
+
+func TestClient_RetryMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	err := newRetryClient(server.URL, 3).Get(context.Background(), "/test", nil, nil)
+	if !isStatus(err, http.StatusGatewayTimeout) {
+		t.Fatalf("Get() error = %v, want API error with status 504", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("attempts = %d, want 3", attempts.Load())
+	}
+}
+
+func TestClient_RetryNonIdempotent(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newRetryClient(server.URL, 4)
+	if err := client.Post(context.Background(), "/test", map[string]string{"a": "b"}, nil); !isStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("Post() error = %v, want API error with status 503", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("POST attempts = %d, want 1", attempts.Load())
+	}
+
+	attempts.Store(0)
+	err := client.Do(context.Background(), RequestOptions{Method: "POST", Path: "/test", Body: map[string]string{"a": "b"}, Retry: true}, nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("POST with Retry attempts = %d, want 2", attempts.Load())
+	}
+}
+
+func TestClient_RetryBodyResent(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	if err := newRetryClient(server.URL, 2).Put(context.Background(), "/test", map[string]string{"a": "b"}, nil); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] != `{"a":"b"}` {
+		t.Errorf("bodies = %q, want the same body twice", bodies)
+	}
+}
+
+func TestClient_RetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	var first time.Time
+	var delay time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		delay = time.Since(first)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newRetryClient(server.URL, 2)
+	client.retry.MaxDelay = 2 * time.Second
+	if err := client.Get(context.Background(), "/test", nil, nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if delay < time.Second {
+		t.Errorf("retry after %v, want at least Retry-After 1s", delay)
+	}
+}
+
+func TestClient_RetryAfterExceedsMaxDelay(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := newRetryClient(server.URL, 4).Get(context.Background(), "/test", nil, nil)
+	if !isStatus(err, http.StatusTooManyRequests) {
+		t.Fatalf("Get() error = %v, want API error with status 429", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("attempts = %d, want 1", attempts.Load())
+	}
+}
+
+func TestClient_RetryConnectionError(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Закрываем соединение без ответа
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	if err := newRetryClient(server.URL, 2).Get(context.Background(), "/test", nil, nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("attempts = %d, want 2", attempts.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "5", want: 5 * time.Second, ok: true},
+		{value: now.Add(10 * time.Second).Format(http.TimeFormat), want: 10 * time.Second, ok: true},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", tt.value)
+		got, ok := retryAfter(resp, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt := 1; attempt <= 40; attempt++ {
+		limit := min(policy.BaseDelay<<min(attempt-1, 10), policy.MaxDelay)
+		if got := policy.backoff(attempt); got <= 0 || got > limit {
+			t.Errorf("backoff(%d) = %v, want (0, %v]", attempt, got, limit)
+		}
+	}
+}
+
+// isStatus проверяет, что err - ошибка API с кодом status
+func isStatus(err error, status int) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == status
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/url"
 )
 
 // UserService предоставляет методы для работы с пользователями
@@ -26,9 +27,7 @@ func (s *UserService) Get(ctx context.Context, customerID, userID string) (*User
 // GetByEmail возвращает информацию о пользователе по email
 func (s *UserService) GetByEmail(ctx context.Context, customerID, email string) (*User, error) {
 	var result User
-	query := map[string]string{
-		"email": email,
-	}
+	query := url.Values{"email": {email}}
 	path := fmt.Sprintf("/api/v1/customers/%s/users", customerID)
 	err := s.client.Get(ctx, path, query, &result)
 	return &result, err
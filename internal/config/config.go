@@ -23,6 +23,8 @@ type Config struct {
 	ServiceConfig                   ServiceConfig `envPrefix:"SERVICE_"`
 	ServiceAccountConfig            IAMConfig     `envPrefix:"IAM_"`
 	BulkOperationsConcurrencyFactor int           `env:"BULK_OPERATIONS_CONCURRENCY" envDefault:"20"`
+	// APIMaxAttempts - количество попыток запроса к API при временных ошибках
+	APIMaxAttempts int `env:"API_MAX_ATTEMPTS" envDefault:"4"`
 
 	IntegrationApiGrpcAddr string `env:"PUBLIC_API_ENDPOINT"          envDefault:"ai-agents.api.cloud.ru"`
 	ProjectID              string `env:"PROJECT_ID"                   envDefault:""`
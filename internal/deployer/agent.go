@@ -5,17 +5,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/docker"
-	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
-	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
 )
 
 // AgentDeployer обрабатывает развертывание агентов
 type AgentDeployer struct {
-	api *api.API
+	api      *api.API
+	parallel ParallelOptions
 }
 
 // NewAgentDeployer создает новый деплойер агентов
@@ -25,196 +28,366 @@ func NewAgentDeployer(api *api.API) *AgentDeployer {
 	}
 }
 
-// AgentConfig представляет конфигурацию агента из YAML
-type AgentConfig struct {
-	Name        string                 `yaml:"name"`
-	Description string                 `yaml:"description"`
-	Options     map[string]interface{} `yaml:"options"`
-	LLMOptions  map[string]interface{} `yaml:"llm_options"`
-	MCPServers  []string               `yaml:"mcp_servers"`
+// SetParallel задает параллельное развертывание агентов
+func (d *AgentDeployer) SetParallel(opts ParallelOptions) {
+	d.parallel = opts
+}
+
+// agentDeployOptions содержит параметры развертывания одного агента
+type agentDeployOptions struct {
+	DryRun       bool
+	DockerClient *docker.Client
+	ProjectDir   string
 }
 
 // ValidateAgents валидирует конфигурацию агентов
 func (d *AgentDeployer) ValidateAgents(configFile string) error {
-	// Обрабатываем includes
-	processedConfig, err := parser.ProcessYAMLFile(configFile)
-	if err != nil {
-		return fmt.Errorf("failed to process YAML file with includes: %w", err)
-	}
-
-	// Валидируем по схеме
-	schemaPath := "schemas/schema.json"
-	if err := validator.ValidateConfig(processedConfig, schemaPath); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
-	}
-
-	return nil
+	return validateConfigFile(configFile)
 }
 
 // DeployAgents развертывает агентов на основе YAML конфигурации
 func (d *AgentDeployer) DeployAgents(ctx context.Context, configFile string, dryRun bool, buildAndPushImages bool) ([]DeployResult, error) {
 	results := []DeployResult{}
 
-	// Обрабатываем includes
-	processedConfig, err := parser.ProcessYAMLFile(configFile)
+	// Обрабатываем includes и строим модель конфигурации
+	m, err := loadManifest(configFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process YAML file with includes: %w", err)
+		return nil, err
 	}
 
-	// Извлекаем агентов из обработанной конфигурации
-	agentsConfig, ok := processedConfig["agents"].([]interface{})
-	if !ok {
+	// Пути secret://file отсчитываются от директории конфигурации
+	ctx = secrets.WithBaseDir(ctx, filepath.Dir(configFile))
+
+	if len(m.Agents) == 0 {
 		return nil, fmt.Errorf("invalid 'agents' section in config file")
 	}
 
-	fmt.Println(ui.FormatInfo(fmt.Sprintf("Found %d agents to deploy.", len(agentsConfig))))
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Found %d agents to deploy.", len(m.Agents))))
 
-	// Создаем Docker клиент для сборки и загрузки образов
-	var dockerClient *docker.Client
+	opts := agentDeployOptions{
+		DryRun:     dryRun,
+		ProjectDir: agentProjectDir(configFile),
+	}
 	if buildAndPushImages {
-		// Получаем URL registry из конфигурации или переменных окружения
-		registryURL := os.Getenv("ARTIFACT_REGISTRY_URL")
-		if registryURL == "" {
-			registryURL = "cr.cloud.ru"
+		opts.DockerClient = newDockerClient()
+	}
+
+	registry := NewRegistry(d.api)
+
+	tasks := make([]task, len(m.Agents))
+	for i := range m.Agents {
+		agent := &m.Agents[i]
+		tasks[i] = task{kind: KindAgent, name: agent.Name, run: func(ctx context.Context) DeployResult {
+			return d.deployAgent(ctx, agent, opts, registry)
+		}}
+	}
+
+	runTasks(ctx, tasks, d.parallel, func(i int, t task) {
+		if dryRun {
+			fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Dry run for agent: %s", i+1, len(tasks), t.name)))
+		} else {
+			fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Deploying agent: %s", i+1, len(tasks), t.name)))
+		}
+	}, func(i int, result DeployResult) {
+		printResult(i+1, len(tasks), result)
+		results = append(results, result)
+	})
+
+	return results, nil
+}
+
+// deployAgent развертывает одного агента: создает его, если агента с таким именем
+// нет в проекте, или обновляет, если конфигурация отличается. Ссылки на MCP
+// серверы и тип конфигурации разрешаются через реестр.
+func (d *AgentDeployer) deployAgent(ctx context.Context, agent *manifest.Agent, opts agentDeployOptions, registry *Registry) DeployResult {
+	name := agent.Name
+
+	fail := func(id, message string, err error) DeployResult {
+		return DeployResult{
+			Success: false,
+			Kind:    KindAgent,
+			Name:    name,
+			ID:      id,
+			Message: message,
+			Error:   err,
 		}
-		dockerClient = docker.NewClient(registryURL)
 	}
 
-	// Определяем путь к директории проекта (где находится configFile)
-	projectDir, err := filepath.Abs(filepath.Dir(configFile))
-	if err != nil {
-		projectDir = "."
-	}
-
-	for i, agentConfigRaw := range agentsConfig {
-		agentConfigMap, ok := agentConfigRaw.(map[string]interface{})
-		if !ok {
-			results = append(results, DeployResult{
-				Success: false,
-				Message: fmt.Sprintf("Invalid agent configuration format for agent %d", i+1),
-			})
-			continue
-		}
-
-		name, _ := agentConfigMap["name"].(string)
-		description, _ := agentConfigMap["description"].(string)
-		options, _ := agentConfigMap["options"].(map[string]interface{})
-		llmOptions, _ := agentConfigMap["llm_options"].(map[string]interface{})
-		mcpServers, _ := agentConfigMap["mcp_servers"].([]interface{})
-
-		// Преобразуем mcp_servers в []string
-		var mcpServerNames []string
-		for _, server := range mcpServers {
-			if serverName, ok := server.(string); ok {
-				mcpServerNames = append(mcpServerNames, serverName)
-			}
+	// API агентов принимает только известные options (AIA011)
+	if agent.Options != nil && len(agent.Options.Extra) > 0 {
+		keys := make([]string, 0, len(agent.Options.Extra))
+		for key := range agent.Options.Extra {
+			keys = append(keys, key)
 		}
+		sort.Strings(keys)
+		err := fmt.Errorf("agent '%s' sets options %s, which the API does not accept", name, strings.Join(keys, ", "))
+		return fail("", err.Error(), err)
+	}
 
-		if dryRun {
-			results = append(results, DeployResult{
-				Success: true,
-				Message: fmt.Sprintf("Would deploy agent: %s", name),
-			})
-			fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Dry run for agent: %s", i+1, len(agentsConfig), name)))
-			continue
-		}
-
-		fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Deploying agent: %s", i+1, len(agentsConfig), name)))
-
-		// Собираем и загружаем Docker образ если требуется
-		var imageURI string
-		if buildAndPushImages && dockerClient != nil {
-			// Ищем Dockerfile в директории проекта
-			dockerfilePath, err := docker.FindDockerfile(projectDir)
-			if err != nil {
-				fmt.Println(ui.FormatWarning(fmt.Sprintf("Dockerfile not found for %s: %v", name, err)))
-			} else {
-				// Формируем имя образа
-				registryURL := os.Getenv("ARTIFACT_REGISTRY_URL")
-				if registryURL == "" {
-					registryURL = "cr.cloud.ru"
-				}
-				imageName := fmt.Sprintf("%s:latest", name)
+	instanceTypeID, err := registry.ResolveInstanceType(ctx, agent.InstanceType)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve instance type for agent %s: %v", name, err), err)
+	}
 
-				// Собираем и загружаем образ
-				fmt.Println(ui.FormatInfo(fmt.Sprintf("Building and pushing Docker image for %s...", name)))
-				if err := dockerClient.BuildAndPush(ctx, dockerfilePath, projectDir, imageName, registryURL); err != nil {
-					return nil, fmt.Errorf("failed to build and push image for %s: %w", name, err)
-				}
+	// Разрешаем ссылки на MCP серверы
+	mcpServers, err := resolveMCPServers(ctx, registry, agent.MCPServers)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve MCP servers for agent %s: %v", name, err), err)
+	}
+
+	// Ищем агента в проекте по ID из файла состояния или по имени
+	existing, err := findAgent(ctx, d.api, registry.stateID(KindAgent, agent.StateKey()), name)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to look up agent %s: %v", name, err), err)
+	}
+
+	imageSource := manifest.ToMap(agent.ImageSource)
 
-				imageURI = fmt.Sprintf("%s/%s", registryURL, imageName)
-				fmt.Println(ui.FormatSuccess(fmt.Sprintf("Image pushed: %s", imageURI)))
+	var changes []Change
+	if existing != nil {
+		changes, err = diffSpecs(ctx, agentSpec(agent, instanceTypeID, imageSource, mcpServers), agentSpecFromLive(existing), registry.secretCompare(KindAgent, agent.StateKey(), !opts.DryRun))
+		if err != nil {
+			return fail(existing.ID, fmt.Sprintf("Failed to compare agent %s: %v", name, err), err)
+		}
+	}
+
+	if opts.DryRun {
+		action := ActionCreated
+		id := ""
+		if existing != nil {
+			id = existing.ID
+			action = ActionUnchanged
+			if len(changes) > 0 {
+				action = ActionUpdated
 			}
 		}
+		return DeployResult{
+			Success: true,
+			Kind:    KindAgent,
+			Name:    name,
+			ID:      id,
+			Action:  action,
+			Message: fmt.Sprintf("Would deploy agent: %s (%s)", name, action),
+		}
+	}
 
-		// Создаем запрос для создания агента
-		createReq := &api.AgentCreateRequest{
-			Name:           name,
-			Description:    description,
-			Options:        options,
-			InstanceTypeID: "58a24a3d-b126-47a5-a39c-30a8aeaa4721", // Используем ID из существующего MCP сервера
+	// Собираем и загружаем Docker образ если требуется
+	if opts.DockerClient != nil {
+		imageURI, err := buildAgentImage(ctx, opts.DockerClient, opts.ProjectDir, name)
+		if err != nil {
+			return fail("", fmt.Sprintf("Failed to build and push image for %s: %v", name, err), err)
 		}
 
-		// Если образ собран, добавляем его в запрос
+		// Если образ собран, используем его как источник образа агента
 		if imageURI != "" {
-			if createReq.Options == nil {
-				createReq.Options = make(map[string]interface{})
-			}
-			if createReq.Options["imageSource"] == nil {
-				createReq.Options["imageSource"] = make(map[string]interface{})
+			if imageSource == nil {
+				imageSource = make(map[string]interface{})
 			}
-			imageSource := createReq.Options["imageSource"].(map[string]interface{})
 			imageSource["arImageUri"] = imageURI
+			if existing != nil {
+				changes, err = diffSpecs(ctx, agentSpec(agent, instanceTypeID, imageSource, mcpServers), agentSpecFromLive(existing), registry.secretCompare(KindAgent, agent.StateKey(), !opts.DryRun))
+				if err != nil {
+					return fail(existing.ID, fmt.Sprintf("Failed to compare agent %s: %v", name, err), err)
+				}
+			}
+		}
+	}
+
+	// Секреты разрешаются только для запроса к API
+	options, err := secrets.ResolveMap(ctx, manifest.ToMap(agent.Options))
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve secrets for agent %s: %v", name, err), err)
+	}
+
+	updateReq := &api.UpdateAgentRequest{
+		Name:           name,
+		Description:    agent.Description,
+		InstanceTypeID: instanceTypeID,
+		MCPServers:     mcpServers,
+	}
+	if err := convertModel(map[string]interface{}{
+		"imageSource":        imageSource,
+		"options":            options,
+		"integrationOptions": agent.IntegrationOptions,
+	}, updateReq); err != nil {
+		return fail("", fmt.Sprintf("Invalid configuration of agent %s: %v", name, err), err)
+	}
+
+	if existing == nil {
+		// Агент отсутствует - создаем
+		createReq := &api.CreateAgentRequest{
+			Name:               name,
+			Description:        agent.Description,
+			InstanceTypeID:     instanceTypeID,
+			ExportedPorts:      agent.ExportedPorts,
+			ImageSource:        updateReq.ImageSource,
+			Options:            updateReq.Options,
+			IntegrationOptions: updateReq.IntegrationOptions,
+		}
+		// При создании API принимает только один MCP сервер, остальные добавляются обновлением
+		if len(mcpServers) > 0 {
+			createReq.MCPServerID = mcpServers[0].MCPServerID
 		}
 
-		// Добавляем LLM опции в Options если они есть
-		if llmOptions != nil {
-			if createReq.Options == nil {
-				createReq.Options = make(map[string]interface{})
+		created, err := d.api.Agents.CreateAgent(ctx, createReq)
+		if err != nil {
+			return fail("", fmt.Sprintf("Failed to create agent %s: %v", name, err), err)
+		}
+
+		if len(mcpServers) > 1 {
+			if _, err := d.api.Agents.UpdateAgent(ctx, created.AgentID, &api.UpdateAgentRequest{MCPServers: mcpServers}); err != nil {
+				result := fail(created.AgentID, fmt.Sprintf("Agent %s created but failed to attach MCP servers: %v", name, err), err)
+				result.Action = ActionCreated
+				return result
 			}
-			createReq.Options["llm"] = llmOptions
 		}
 
-		// TODO: MCP серверы пока не поддерживаются в API создания агентов
-		if len(mcpServerNames) > 0 {
-			fmt.Println(ui.FormatWarning(fmt.Sprintf("MCP servers %v specified but not supported yet", mcpServerNames)))
+		return DeployResult{
+			Success: true,
+			Kind:    KindAgent,
+			Name:    name,
+			ID:      created.AgentID,
+			Action:  ActionCreated,
+			Message: fmt.Sprintf("Successfully created agent %s (ID: %s)", name, shortID(created.AgentID)),
 		}
+	}
 
-		// Создаем агента
-		agent, err := d.api.Agents.Create(ctx, createReq)
+	// Агент существует - обновляем только при наличии изменений
+	if len(changes) == 0 {
+		return DeployResult{
+			Success: true,
+			Kind:    KindAgent,
+			Name:    name,
+			ID:      existing.ID,
+			Action:  ActionUnchanged,
+			Message: fmt.Sprintf("Agent %s is up to date (ID: %s)", name, shortID(existing.ID)),
+		}
+	}
+
+	if _, err := d.api.Agents.UpdateAgent(ctx, existing.ID, updateReq); err != nil {
+		return fail(existing.ID, fmt.Sprintf("Failed to update agent %s: %v", name, err), err)
+	}
+
+	return DeployResult{
+		Success: true,
+		Kind:    KindAgent,
+		Name:    name,
+		ID:      existing.ID,
+		Action:  ActionUpdated,
+		Message: fmt.Sprintf("Successfully updated agent %s (ID: %s, %d changes)", name, shortID(existing.ID), len(changes)),
+
+		previous: existing,
+	}
+}
+
+// resolveMCPServers разрешает ссылки агента на MCP серверы в их ID
+func resolveMCPServers(ctx context.Context, registry *Registry, refs []string) ([]api.AgentMCPServer, error) {
+	servers := make([]api.AgentMCPServer, 0, len(refs))
+	for _, ref := range refs {
+		id, err := registry.Resolve(ctx, KindMCPServer, ref)
 		if err != nil {
-			results = append(results, DeployResult{
-				Success: false,
-				Message: fmt.Sprintf("Failed to create agent %s: %v", name, err),
-			})
-			fmt.Println(ui.FormatError(fmt.Sprintf("[%d/%d] Failed to deploy agent %s: %v", i+1, len(agentsConfig), name, err)))
-			continue
+			return nil, err
 		}
+		servers = append(servers, api.AgentMCPServer{MCPServerID: id, Name: ref})
+	}
+	return servers, nil
+}
 
-		results = append(results, DeployResult{
-			Success: true,
-			Message: fmt.Sprintf("Successfully deployed agent %s (ID: %s)", name, agent.ID[:8]),
-		})
-		fmt.Println(ui.FormatSuccess(fmt.Sprintf("[%d/%d] Successfully deployed agent %s (ID: %s)", i+1, len(agentsConfig), name, agent.ID[:8])))
+// newDockerClient создает Docker клиент для сборки и загрузки образов
+func newDockerClient() *docker.Client {
+	// Получаем URL registry из конфигурации или переменных окружения
+	registryURL := os.Getenv("ARTIFACT_REGISTRY_URL")
+	if registryURL == "" {
+		registryURL = "cr.cloud.ru"
 	}
+	return docker.NewClient(registryURL)
+}
 
-	return results, nil
+// agentProjectDir определяет путь к директории проекта (где находится configFile)
+func agentProjectDir(configFile string) string {
+	projectDir, err := filepath.Abs(filepath.Dir(configFile))
+	if err != nil {
+		return "."
+	}
+	return projectDir
 }
 
-// findMCPServerID находит ID MCP сервера по имени
-func (d *AgentDeployer) findMCPServerID(ctx context.Context, serverName string) (string, error) {
-	// Получаем список MCP серверов с разумным лимитом
-	mcpServers, err := d.api.MCPServers.List(ctx, 100, 0)
+// buildAgentImage собирает и загружает Docker образ агента.
+// Если Dockerfile не найден, возвращает пустую строку без ошибки.
+func buildAgentImage(ctx context.Context, dockerClient *docker.Client, projectDir, name string) (string, error) {
+	// Ищем Dockerfile в директории проекта
+	dockerfilePath, err := docker.FindDockerfile(projectDir)
 	if err != nil {
-		return "", fmt.Errorf("failed to list MCP servers: %w", err)
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("Dockerfile not found for %s: %v", name, err)))
+		return "", nil
+	}
+
+	// Формируем имя образа
+	registryURL := os.Getenv("ARTIFACT_REGISTRY_URL")
+	if registryURL == "" {
+		registryURL = "cr.cloud.ru"
+	}
+	imageName := fmt.Sprintf("%s:latest", name)
+
+	// Собираем и загружаем образ
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Building and pushing Docker image for %s...", name)))
+	if err := dockerClient.BuildAndPush(ctx, dockerfilePath, projectDir, imageName, registryURL); err != nil {
+		return "", fmt.Errorf("failed to build and push image for %s: %w", name, err)
 	}
 
-	// Ищем сервер по имени
-	for _, server := range mcpServers.Data {
-		if server.Name == serverName {
-			return server.ID, nil
+	imageURI := fmt.Sprintf("%s/%s", registryURL, imageName)
+	fmt.Println(ui.FormatSuccess(fmt.Sprintf("Image pushed: %s", imageURI)))
+	return imageURI, nil
+}
+
+// agentSpec возвращает желаемую спецификацию агента для сравнения с проектом.
+// MCP серверы сравниваются по ID, а если ID еще неизвестен - по имени.
+// exportedPorts не сравниваются: API не возвращает их для агента, а
+// дополнительные поля options не передаются в API.
+func agentSpec(agent *manifest.Agent, instanceTypeID string, imageSource map[string]interface{}, mcpServers []api.AgentMCPServer) map[string]interface{} {
+	options := agent.Options
+	if options != nil && len(options.Extra) > 0 {
+		supported := *options
+		supported.Extra = nil
+		options = &supported
+	}
+
+	servers := make([]string, 0, len(mcpServers))
+	for _, server := range mcpServers {
+		if server.MCPServerID != "" {
+			servers = append(servers, server.MCPServerID)
+		} else {
+			servers = append(servers, server.Name)
 		}
 	}
+	sort.Strings(servers)
+
+	return map[string]interface{}{
+		"name":               agent.Name,
+		"description":        agent.Description,
+		"instanceTypeId":     instanceTypeID,
+		"imageSource":        imageSource,
+		"options":            manifest.ToMap(options),
+		"integrationOptions": agent.IntegrationOptions,
+		"mcpServers":         servers,
+	}
+}
 
-	return "", fmt.Errorf("MCP server '%s' not found", serverName)
+// agentSpecFromLive возвращает спецификацию агента из проекта в том же виде, что и agentSpec
+func agentSpecFromLive(agent *api.Agent) map[string]interface{} {
+	servers := make([]string, 0, len(agent.MCPServers))
+	for _, server := range agent.MCPServers {
+		servers = append(servers, server.MCPServerID)
+	}
+	sort.Strings(servers)
+
+	return map[string]interface{}{
+		"name":               agent.Name,
+		"description":        agent.Description,
+		"instanceTypeId":     agent.InstanceType.ID,
+		"imageSource":        agent.ImageSource,
+		"options":            agent.Options,
+		"integrationOptions": agent.IntegrationOptions,
+		"mcpServers":         servers,
+	}
 }
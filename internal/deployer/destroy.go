@@ -0,0 +1,204 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+)
+
+// bulkDeleteLimit - максимальное количество ресурсов в одном запросе массового удаления
+const bulkDeleteLimit = 100
+
+// DestroyOptions содержит параметры удаления ресурсов конфигурации
+type DestroyOptions struct {
+	// Wait - ожидать, пока ресурсы каждой группы будут удалены, прежде чем
+	// удалять ресурсы, от которых они зависят
+	Wait bool
+	// Timeout - максимальное время ожидания удаления одного ресурса
+	Timeout time.Duration
+}
+
+// BuildDestroyPlan строит план удаления ресурсов, описанных в конфигурации.
+// Ресурсы перечисляются в порядке, обратном порядку развертывания; ресурсы,
+// которых нет в проекте, пропускаются. Если передан файл состояния, ресурсы
+// ищутся по ID из него, как при развертывании, поэтому переименованные через
+// key и импортированные ресурсы удаляются по их ID, а не по совпадению имени.
+func BuildDestroyPlan(ctx context.Context, client *api.API, m *manifest.Manifest, st *state.State) (*Plan, error) {
+	graph, err := BuildGraph(m)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := graph.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	registry := NewRegistry(client)
+	registry.UseState(st)
+
+	plan := &Plan{}
+	for i := len(levels) - 1; i >= 0; i-- {
+		for _, node := range levels[i] {
+			id, err := findNodeID(ctx, client, node.Kind, registry.stateID(node.Kind, node.StateKey), node.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up %s %s: %w", node.Kind, node.Name, err)
+			}
+			if id == "" {
+				log.Info("Resource not found in project, skipping", "kind", node.Kind, "name", node.Name)
+				continue
+			}
+			plan.Items = append(plan.Items, PlanItem{Kind: node.Kind, Name: node.Name, ID: id, Action: PlanDelete})
+		}
+	}
+
+	return plan, nil
+}
+
+// Destroyer удаляет ресурсы по плану удаления
+type Destroyer struct {
+	api    *api.API
+	waiter *Waiter
+}
+
+// NewDestroyer создает новый объект удаления ресурсов
+func NewDestroyer(client *api.API) *Destroyer {
+	return &Destroyer{
+		api:    client,
+		waiter: NewWaiter(client),
+	}
+}
+
+// Destroy удаляет ресурсы из плана. Подряд идущие ресурсы одного типа
+// удаляются одним запросом массового удаления: ресурсы одного типа не зависят
+// друг от друга, а порядок плана уже обратен порядку зависимостей. После
+// неудачной группы следующие группы не удаляются: на их ресурсы могут
+// ссылаться оставшиеся ресурсы, они возвращаются как пропущенные.
+func (d *Destroyer) Destroy(ctx context.Context, plan *Plan, opts DestroyOptions) []DeployResult {
+	var results []DeployResult
+
+	var failed *PlanItem
+	for _, batch := range deleteBatches(plan.Items) {
+		if failed != nil {
+			for _, item := range batch {
+				results = append(results, DeployResult{
+					Success: false,
+					Kind:    item.Kind,
+					Name:    item.Name,
+					ID:      item.ID,
+					Message: fmt.Sprintf("Skipped %s %s: not attempted because %s %s was not deleted", item.Kind, item.Name, failed.Kind, failed.Name),
+				})
+			}
+			continue
+		}
+
+		ids := make([]string, 0, len(batch))
+		for _, item := range batch {
+			ids = append(ids, item.ID)
+		}
+
+		kind := batch[0].Kind
+		fmt.Println(ui.FormatInfo(fmt.Sprintf("Deleting %d %s resources...", len(batch), kind)))
+
+		err := d.deleteMany(ctx, kind, ids)
+		batchResults := make([]DeployResult, 0, len(batch))
+		for _, item := range batch {
+			result := DeployResult{
+				Success: err == nil,
+				Kind:    item.Kind,
+				Name:    item.Name,
+				ID:      item.ID,
+				Action:  ActionDeleted,
+				Error:   err,
+			}
+			if err != nil {
+				result.Message = fmt.Sprintf("Failed to delete %s %s (ID: %s)", item.Kind, item.Name, shortID(item.ID))
+			} else {
+				result.Message = fmt.Sprintf("Deleted %s %s (ID: %s)", item.Kind, item.Name, shortID(item.ID))
+			}
+			batchResults = append(batchResults, result)
+		}
+
+		if err == nil && opts.Wait {
+			waitResults := d.waiter.WaitDeleted(ctx, batchResults, WaitOptions{Timeout: opts.Timeout})
+			for i, waitResult := range waitResults {
+				if waitResult.State == WaitReady {
+					continue
+				}
+				batchResults[i].Success = false
+				batchResults[i].Error = waitResult.Error
+				batchResults[i].Message = fmt.Sprintf("%s %s was not deleted within the timeout (last status: %s)",
+					waitResult.Kind, waitResult.Name, statusOrUnknown(waitResult.Status))
+			}
+		}
+
+		results = append(results, batchResults...)
+		for i, result := range batchResults {
+			if !result.Success {
+				failed = &batch[i]
+				break
+			}
+		}
+	}
+
+	return results
+}
+
+// deleteMany удаляет ресурсы одного типа через эндпоинт массового удаления
+func (d *Destroyer) deleteMany(ctx context.Context, kind ResourceKind, ids []string) error {
+	switch kind {
+	case KindMCPServer:
+		return d.api.MCPServers.DeleteMany(ctx, ids)
+	case KindAgent:
+		return d.api.Agents.DeleteMany(ctx, ids)
+	case KindAgentSystem:
+		return d.api.AgentSystems.DeleteMany(ctx, ids)
+	default:
+		return fmt.Errorf("unknown resource kind: %s", kind)
+	}
+}
+
+// deleteBatches разбивает элементы плана на группы подряд идущих ресурсов
+// одного типа, не превышающие лимит массового удаления
+func deleteBatches(items []PlanItem) [][]PlanItem {
+	var batches [][]PlanItem
+	for _, item := range items {
+		if item.Action != PlanDelete {
+			continue
+		}
+		last := len(batches) - 1
+		if last >= 0 && batches[last][0].Kind == item.Kind && len(batches[last]) < bulkDeleteLimit {
+			batches[last] = append(batches[last], item)
+			continue
+		}
+		batches = append(batches, []PlanItem{item})
+	}
+	return batches
+}
+
+// ShowDestroyResults отображает результаты удаления
+func ShowDestroyResults(results []DeployResult) {
+	deleted := 0
+	for _, result := range results {
+		if result.Success {
+			deleted++
+			fmt.Println(ui.FormatSuccess(result.Message))
+			continue
+		}
+		fmt.Println(ui.FormatError(result.Message))
+		if result.Error != nil {
+			fmt.Printf("  Error: %v\n", result.Error)
+		}
+	}
+
+	fmt.Printf("\n📊 Destroy Summary:\n")
+	fmt.Printf("  🗑️  Deleted: %d\n", deleted)
+	fmt.Printf("  ❌ Failed: %d\n", len(results)-deleted)
+	fmt.Printf("  📋 Total: %d\n", len(results))
+}
@@ -0,0 +1,151 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+func TestDeleteBatches(t *testing.T) {
+	items := []PlanItem{
+		{Kind: KindAgentSystem, Name: "system", ID: "s1", Action: PlanDelete},
+		{Kind: KindAgent, Name: "first", ID: "a1", Action: PlanDelete},
+		{Kind: KindAgent, Name: "second", ID: "a2", Action: PlanDelete},
+		{Kind: KindMCPServer, Name: "mcp", ID: "m1", Action: PlanDelete},
+	}
+	for i := 0; i < bulkDeleteLimit+1; i++ {
+		items = append(items, PlanItem{Kind: KindMCPServer, Name: fmt.Sprintf("mcp-%d", i), ID: fmt.Sprintf("m%d", i+2), Action: PlanDelete})
+	}
+
+	batches := deleteBatches(items)
+
+	expected := []int{1, 2, bulkDeleteLimit, 2}
+	if len(batches) != len(expected) {
+		t.Fatalf("Expected %d batches, got %d", len(expected), len(batches))
+	}
+	for i, batch := range batches {
+		if len(batch) != expected[i] {
+			t.Errorf("Expected batch %d to have %d items, got %d", i, expected[i], len(batch))
+		}
+	}
+}
+
+func TestDestroyer_Destroy(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := api.NewAPI(server.URL, "project", &api.MockIAMService{})
+	plan := &Plan{Items: []PlanItem{
+		{Kind: KindAgentSystem, Name: "system", ID: "s1", Action: PlanDelete},
+		{Kind: KindAgent, Name: "first", ID: "a1", Action: PlanDelete},
+		{Kind: KindAgent, Name: "second", ID: "a2", Action: PlanDelete},
+	}}
+
+	results := NewDestroyer(client).Destroy(context.Background(), plan, DestroyOptions{})
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if !result.Success || result.Action != ActionDeleted {
+			t.Errorf("Expected %s to be deleted, got %+v", result.Name, result)
+		}
+	}
+
+	expected := []string{
+		"DELETE /api/v1/project/agentSystems?agentSystemIds=s1",
+		"DELETE /api/v1/project/agents?agentIds=a1&agentIds=a2",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected %d requests, got %v", len(expected), requests)
+	}
+	for i, request := range requests {
+		if request != expected[i] {
+			t.Errorf("Expected request %q, got %q", expected[i], request)
+		}
+	}
+}
+
+func TestBuildDestroyPlan_UsesState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/project/agents/a1":
+			w.Write([]byte(`{"agent": {"id": "a1", "name": "assistant"}}`))
+		case "/api/v1/project/agents":
+			// Посторонний агент с тем же именем, что и в конфигурации
+			w.Write([]byte(`{"total": 1, "data": [{"id": "a9", "name": "shop-assistant"}]}`))
+		default:
+			w.Write([]byte(`{"total": 0, "data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewAPI(server.URL, "project", &api.MockIAMService{})
+
+	// Агент переименован в конфигурации, key указывает на развернутый ресурс
+	m := &manifest.Manifest{Agents: []manifest.Agent{{Name: "shop-assistant", Key: "assistant"}}}
+
+	st, err := state.Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	st.Set(state.Resource{Kind: string(KindAgent), Key: "assistant", ID: "a1"})
+
+	plan, err := BuildDestroyPlan(context.Background(), client, m, st)
+	if err != nil {
+		t.Fatalf("BuildDestroyPlan failed: %v", err)
+	}
+	if len(plan.Items) != 1 || plan.Items[0].ID != "a1" {
+		t.Fatalf("Expected to delete a1 from state, got %+v", plan.Items)
+	}
+}
+
+func TestDestroyer_Destroy_StopsAfterFailedBatch(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"system is busy"}`))
+	}))
+	defer server.Close()
+
+	client := api.NewAPI(server.URL, "project", &api.MockIAMService{})
+	plan := &Plan{Items: []PlanItem{
+		{Kind: KindAgentSystem, Name: "system", ID: "s1", Action: PlanDelete},
+		{Kind: KindAgent, Name: "agent", ID: "a1", Action: PlanDelete},
+		{Kind: KindMCPServer, Name: "mcp", ID: "m1", Action: PlanDelete},
+	}}
+
+	results := NewDestroyer(client).Destroy(context.Background(), plan, DestroyOptions{})
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if results[0].Success || results[0].Error == nil {
+		t.Errorf("Expected system deletion to fail, got %+v", results[0])
+	}
+	for _, result := range results[1:] {
+		if result.Success || result.Error != nil || result.Action != "" {
+			t.Errorf("Expected %s to be skipped, got %+v", result.Name, result)
+		}
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected only the system delete request, got %v", requests)
+	}
+}
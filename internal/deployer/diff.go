@@ -0,0 +1,144 @@
+package deployer
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeKind определяет тип изменения поля
+type ChangeKind string
+
+const (
+	// ChangeAdded - поле задано в конфигурации, но отсутствует в проекте
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved - поле есть в проекте, но удалено из конфигурации
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified - значение поля отличается
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change описывает различие одного поля между конфигурацией и проектом
+type Change struct {
+	Path []string
+	Kind ChangeKind
+	Old  interface{}
+	New  interface{}
+}
+
+// PathString возвращает путь к полю через точку
+func (c Change) PathString() string {
+	return strings.Join(c.Path, ".")
+}
+
+// exhaustiveKeys - словари, ключи которых целиком задает пользователь.
+// Для них отсутствие ключа в конфигурации означает удаление, а не
+// значение по умолчанию, подставленное сервером.
+var exhaustiveKeys = map[string]bool{
+	"rawEnvs":    true,
+	"secretEnvs": true,
+}
+
+// Diff сравнивает желаемую спецификацию ресурса с текущей.
+// Поля, которые есть только в текущей спецификации, считаются значениями
+// по умолчанию сервера и не попадают в результат (кроме exhaustiveKeys).
+func Diff(desired, live map[string]interface{}) []Change {
+	d, _ := normalize(desired).(map[string]interface{})
+	l, _ := normalize(live).(map[string]interface{})
+	return diffMaps(nil, d, l, false)
+}
+
+func diffMaps(path []string, desired, live map[string]interface{}, exhaustive bool) []Change {
+	var changes []Change
+
+	for _, key := range sortedKeys(desired) {
+		dv := desired[key]
+		lv, ok := live[key]
+		keyPath := appendPath(path, key)
+
+		if isZero(dv) && (!ok || isZero(lv)) {
+			continue
+		}
+		if !ok || isZero(lv) {
+			changes = append(changes, Change{Path: keyPath, Kind: ChangeAdded, New: dv})
+			continue
+		}
+
+		dm, dIsMap := dv.(map[string]interface{})
+		lm, lIsMap := lv.(map[string]interface{})
+		if dIsMap && lIsMap {
+			changes = append(changes, diffMaps(keyPath, dm, lm, exhaustiveKeys[key])...)
+			continue
+		}
+
+		if !reflect.DeepEqual(dv, lv) {
+			changes = append(changes, Change{Path: keyPath, Kind: ChangeModified, Old: lv, New: dv})
+		}
+	}
+
+	if exhaustive {
+		for _, key := range sortedKeys(live) {
+			if _, ok := desired[key]; ok || isZero(live[key]) {
+				continue
+			}
+			changes = append(changes, Change{Path: appendPath(path, key), Kind: ChangeRemoved, Old: live[key]})
+		}
+	}
+
+	return changes
+}
+
+// normalize приводит значение к виду, который дает encoding/json,
+// чтобы числа из YAML и из ответа API сравнивались одинаково
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return v
+	}
+	return result
+}
+
+// isZero проверяет, что значение пустое или равно значению по умолчанию
+func isZero(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case float64:
+		return val == 0
+	case bool:
+		return !val
+	case map[string]interface{}:
+		for _, item := range val {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendPath(path []string, key string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, key)
+}
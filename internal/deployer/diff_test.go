@@ -0,0 +1,135 @@
+package deployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string]interface{}
+		live    map[string]interface{}
+		want    []string
+	}{
+		{
+			name: "identical specs",
+			desired: map[string]interface{}{
+				"description": "Test agent",
+				"options":     map[string]interface{}{"replicas": 1},
+			},
+			live: map[string]interface{}{
+				"description": "Test agent",
+				"options":     map[string]interface{}{"replicas": float64(1)},
+			},
+			want: nil,
+		},
+		{
+			name: "server defaults are ignored",
+			desired: map[string]interface{}{
+				"options": map[string]interface{}{"replicas": 1},
+			},
+			live: map[string]interface{}{
+				"options": map[string]interface{}{"replicas": 1, "timeout": 30},
+			},
+			want: nil,
+		},
+		{
+			name: "modified and added fields",
+			desired: map[string]interface{}{
+				"description": "New description",
+				"options":     map[string]interface{}{"llm": map[string]interface{}{"model": "gpt-4"}},
+			},
+			live: map[string]interface{}{
+				"description": "Old description",
+			},
+			want: []string{"description:modified", "options:added"},
+		},
+		{
+			name: "removed env variable",
+			desired: map[string]interface{}{
+				"options": map[string]interface{}{"rawEnvs": map[string]interface{}{"A": "1"}},
+			},
+			live: map[string]interface{}{
+				"options": map[string]interface{}{"rawEnvs": map[string]interface{}{"A": "1", "B": "2"}},
+			},
+			want: []string{"options.rawEnvs.B:removed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(tt.desired, tt.live)
+
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.PathString()+":"+string(change.Kind))
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected changes %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected change %s, got %s", tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAgentSpec_IgnoresExtraOptions(t *testing.T) {
+	agent := &manifest.Agent{
+		Name: "assistant",
+		Options: &manifest.AgentOptions{
+			SystemPrompt: "Be helpful",
+			Extra:        map[string]interface{}{"temperature": 0.2},
+		},
+	}
+	live := &api.Agent{
+		Name:    "assistant",
+		Options: api.AgentOptions{SystemPrompt: "Be helpful"},
+	}
+
+	changes, err := diffSpecs(context.Background(), agentSpec(agent, "", nil, nil), agentSpecFromLive(live), secretCompare{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
+
+func TestSystemSpec_AgentScaling(t *testing.T) {
+	system := &manifest.AgentSystem{Name: "support"}
+	live := &api.AgentSystem{
+		Name: "support",
+		Agents: []api.AgentSystemAgent{
+			{AgentID: "a1", Scaling: api.Scaling{MinScale: 1, MaxScale: 2}},
+			{AgentID: "a2"},
+		},
+	}
+	agents := []api.AgentSystemAgent{
+		{AgentID: "a1", Scaling: api.Scaling{MinScale: 1, MaxScale: 5}},
+		{AgentID: "a2"},
+	}
+
+	changes, err := diffSpecs(context.Background(), systemSpec(system, "", agents), systemSpecFromLive(live), secretCompare{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].PathString() != "agentScaling.a1.maxScale" {
+		t.Errorf("Expected agentScaling.a1.maxScale change, got %v", changes)
+	}
+
+	changes, err = diffSpecs(context.Background(), systemSpec(system, "", live.Agents), systemSpecFromLive(live), secretCompare{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
@@ -0,0 +1,326 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+// DriftStatus определяет состояние ресурса относительно конфигурации
+type DriftStatus string
+
+const (
+	// DriftInSync - ресурс совпадает с конфигурацией
+	DriftInSync DriftStatus = "in-sync"
+	// DriftChanged - ресурс изменен в проекте
+	DriftChanged DriftStatus = "changed"
+	// DriftMissing - ресурс описан в конфигурации, но отсутствует в проекте
+	DriftMissing DriftStatus = "missing"
+	// DriftUnmanaged - ресурс есть в проекте, но не описан в конфигурации
+	DriftUnmanaged DriftStatus = "unmanaged"
+)
+
+// nameSeparators - символы, отделяющие префикс в имени ресурса
+const nameSeparators = "-_."
+
+// DriftItem описывает состояние одного ресурса
+type DriftItem struct {
+	Kind    ResourceKind
+	Name    string
+	ID      string
+	Status  DriftStatus
+	Changes []Change
+}
+
+// DriftReport содержит результат сравнения конфигурации с проектом
+type DriftReport struct {
+	Items []DriftItem
+}
+
+// DetectDrift сравнивает ресурсы конфигурации с проектом поле за полем.
+// Кроме измененных и отсутствующих ресурсов, в отчет попадают ресурсы
+// проекта, которых нет в конфигурации, но имя которых начинается с того же
+// префикса (часть имени до первого '-', '_' или '.'), что и у управляемых.
+// Файл состояния st (может быть nil) используется для поиска ресурсов по ID.
+func DetectDrift(ctx context.Context, client *api.API, m *manifest.Manifest, st *state.State) (*DriftReport, error) {
+	plan, err := BuildPlan(ctx, client, m, st)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{}
+	managed := make(map[ResourceKind]map[string]bool)
+	prefixes := make(map[string]bool)
+
+	for _, item := range plan.Items {
+		driftItem := DriftItem{Kind: item.Kind, Name: item.Name, ID: item.ID, Changes: item.Changes}
+		switch item.Action {
+		case PlanCreate:
+			driftItem.Status = DriftMissing
+			driftItem.Changes = nil
+		case PlanUpdate:
+			driftItem.Status = DriftChanged
+		default:
+			driftItem.Status = DriftInSync
+		}
+		report.Items = append(report.Items, driftItem)
+
+		if managed[item.Kind] == nil {
+			managed[item.Kind] = make(map[string]bool)
+		}
+		managed[item.Kind][item.Name] = true
+		if prefix := namePrefix(item.Name); prefix != "" {
+			prefixes[prefix] = true
+		}
+	}
+
+	if len(prefixes) == 0 {
+		return report, nil
+	}
+
+	live, err := listLiveNames(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, kind := range AllKinds {
+		for _, resource := range live[kind] {
+			if managed[kind][resource.name] || !prefixes[namePrefix(resource.name)] {
+				continue
+			}
+			report.Items = append(report.Items, DriftItem{Kind: kind, Name: resource.name, ID: resource.id, Status: DriftUnmanaged})
+		}
+	}
+
+	return report, nil
+}
+
+// liveName - имя и ID ресурса проекта
+type liveName struct {
+	id   string
+	name string
+}
+
+// listLiveNames возвращает имена всех ресурсов проекта по типам
+func listLiveNames(ctx context.Context, client *api.API) (map[ResourceKind][]liveName, error) {
+	names := make(map[ResourceKind][]liveName)
+
+	servers, err := listAllMCPServers(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range servers {
+		names[KindMCPServer] = append(names[KindMCPServer], liveName{id: server.ID, name: server.Name})
+	}
+
+	agents, err := listAllAgents(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, agent := range agents {
+		names[KindAgent] = append(names[KindAgent], liveName{id: agent.ID, name: agent.Name})
+	}
+
+	systems, err := listAllAgentSystems(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, system := range systems {
+		names[KindAgentSystem] = append(names[KindAgentSystem], liveName{id: system.ID, name: system.Name})
+	}
+
+	for kind := range names {
+		sort.Slice(names[kind], func(i, j int) bool { return names[kind][i].name < names[kind][j].name })
+	}
+	return names, nil
+}
+
+// namePrefix возвращает префикс имени до первого разделителя включительно
+// или пустую строку, если разделителя нет
+func namePrefix(name string) string {
+	i := strings.IndexAny(name, nameSeparators)
+	if i <= 0 {
+		return ""
+	}
+	return name[:i+1]
+}
+
+// HasDrift проверяет, отличается ли проект от конфигурации
+func (r *DriftReport) HasDrift() bool {
+	for _, item := range r.Items {
+		if item.Status != DriftInSync {
+			return true
+		}
+	}
+	return false
+}
+
+// Counts возвращает количество ресурсов для каждого состояния
+func (r *DriftReport) Counts() map[DriftStatus]int {
+	counts := make(map[DriftStatus]int)
+	for _, item := range r.Items {
+		counts[item.Status]++
+	}
+	return counts
+}
+
+// Summary возвращает итоговую строку отчета
+func (r *DriftReport) Summary() string {
+	counts := r.Counts()
+	return fmt.Sprintf("Drift: %d changed, %d missing, %d unmanaged, %d in sync.",
+		counts[DriftChanged], counts[DriftMissing], counts[DriftUnmanaged], counts[DriftInSync])
+}
+
+// Render возвращает отчет в текстовом виде с цветовой разметкой
+func (r *DriftReport) Render() string {
+	var b strings.Builder
+
+	for _, item := range r.Items {
+		header := fmt.Sprintf("%s %q", item.Kind, item.Name)
+		if item.ID != "" {
+			header += fmt.Sprintf(" (ID: %s)", shortID(item.ID))
+		}
+
+		switch item.Status {
+		case DriftInSync:
+			b.WriteString(planNoopStyle.Render("    "+header+" - in sync") + "\n")
+		case DriftMissing:
+			b.WriteString(planDeleteStyle.Render("  - "+header+" - missing in project") + "\n")
+		case DriftUnmanaged:
+			b.WriteString(planCreateStyle.Render("  + "+header+" - not in configuration") + "\n")
+		case DriftChanged:
+			b.WriteString(planUpdateStyle.Render("  ~ "+header+" - changed in project") + " {\n")
+			renderChanges(&b, item.Changes, 0, 3)
+			b.WriteString("    }\n")
+		}
+	}
+
+	b.WriteString("\n" + planHeaderStyle.Render(r.Summary()) + "\n")
+	return b.String()
+}
+
+// driftItemJSON - представление ресурса в JSON отчете
+type driftItemJSON struct {
+	Kind    ResourceKind      `json:"kind"`
+	Name    string            `json:"name"`
+	ID      string            `json:"id,omitempty"`
+	Status  DriftStatus       `json:"status"`
+	Changes []driftChangeJSON `json:"changes,omitempty"`
+}
+
+// driftChangeJSON - представление изменения поля в JSON отчете.
+// Old - значение в проекте, New - значение в конфигурации.
+type driftChangeJSON struct {
+	Path string      `json:"path"`
+	Kind ChangeKind  `json:"kind"`
+	Old  interface{} `json:"live,omitempty"`
+	New  interface{} `json:"desired,omitempty"`
+}
+
+// WriteJSON записывает отчет в формате JSON
+func (r *DriftReport) WriteJSON(w io.Writer) error {
+	report := struct {
+		Drift     bool            `json:"drift"`
+		Summary   string          `json:"summary"`
+		Resources []driftItemJSON `json:"resources"`
+	}{
+		Drift:     r.HasDrift(),
+		Summary:   r.Summary(),
+		Resources: make([]driftItemJSON, 0, len(r.Items)),
+	}
+
+	for _, item := range r.Items {
+		out := driftItemJSON{Kind: item.Kind, Name: item.Name, ID: item.ID, Status: item.Status}
+		for _, change := range item.Changes {
+			out.Changes = append(out.Changes, driftChangeJSON{
+				Path: change.PathString(),
+				Kind: change.Kind,
+				Old:  change.Old,
+				New:  change.New,
+			})
+		}
+		report.Resources = append(report.Resources, out)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// junitTestSuites - корневой элемент отчета JUnit
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit записывает отчет в формате JUnit XML: каждый ресурс - отдельный
+// тест, ресурсы с расхождениями - упавшие тесты
+func (r *DriftReport) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{Name: "drift", Tests: len(r.Items)}
+
+	for _, item := range r.Items {
+		testCase := junitTestCase{ClassName: string(item.Kind), Name: item.Name}
+		if item.Status != DriftInSync {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%s %s is %s", item.Kind, item.Name, item.Status),
+				Type:    string(item.Status),
+				Text:    driftDetails(item),
+			}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// driftDetails возвращает список измененных полей ресурса без цветовой разметки
+func driftDetails(item DriftItem) string {
+	var lines []string
+	for _, change := range item.Changes {
+		switch change.Kind {
+		case ChangeAdded:
+			lines = append(lines, fmt.Sprintf("+ %s = %s", change.PathString(), formatValue(change.New)))
+		case ChangeRemoved:
+			lines = append(lines, fmt.Sprintf("- %s = %s", change.PathString(), formatValue(change.Old)))
+		default:
+			lines = append(lines, fmt.Sprintf("~ %s = %s -> %s", change.PathString(), formatValue(change.Old), formatValue(change.New)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
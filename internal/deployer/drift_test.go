@@ -0,0 +1,149 @@
+package deployer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+func TestNamePrefix(t *testing.T) {
+	tests := map[string]string{
+		"shop-assistant": "shop-",
+		"shop_router":    "shop_",
+		"assistant":      "",
+		"-hidden":        "",
+	}
+	for name, expected := range tests {
+		if got := namePrefix(name); got != expected {
+			t.Errorf("namePrefix(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	responses := map[string]string{
+		"/api/v1/project/mcpServers":   `{"total": 0, "data": []}`,
+		"/api/v1/project/agentSystems": `{"total": 0, "data": []}`,
+		"/api/v1/project/agents": `{"total": 3, "data": [
+			{"id": "a1", "name": "shop-assistant"},
+			{"id": "a2", "name": "shop-legacy"},
+			{"id": "a3", "name": "billing-bot"}]}`,
+		"/api/v1/project/agents/a1": `{"agent": {"id": "a1", "name": "shop-assistant", "description": "Edited in console"}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := api.NewAPI(server.URL, "project", &api.MockIAMService{})
+	m := &manifest.Manifest{Agents: []manifest.Agent{
+		{Name: "shop-assistant", Description: "Shop assistant"},
+		{Name: "shop-new"},
+	}}
+
+	report, err := DetectDrift(context.Background(), client, m, nil)
+	if err != nil {
+		t.Fatalf("DetectDrift failed: %v", err)
+	}
+
+	statuses := make(map[string]DriftStatus)
+	for _, item := range report.Items {
+		statuses[item.Name] = item.Status
+	}
+	expected := map[string]DriftStatus{
+		"shop-assistant": DriftChanged,
+		"shop-new":       DriftMissing,
+		"shop-legacy":    DriftUnmanaged,
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Expected %d resources in report, got %v", len(expected), statuses)
+	}
+	for name, status := range expected {
+		if statuses[name] != status {
+			t.Errorf("Expected %s to be %s, got %s", name, status, statuses[name])
+		}
+	}
+	if !report.HasDrift() {
+		t.Error("Expected drift to be reported")
+	}
+
+	var jsonOut bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded struct {
+		Drift     bool `json:"drift"`
+		Resources []struct {
+			Changes []struct {
+				Path string `json:"path"`
+			} `json:"changes"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON report: %v", err)
+	}
+	if !decoded.Drift || len(decoded.Resources) != 3 || decoded.Resources[0].Changes[0].Path != "description" {
+		t.Errorf("Unexpected JSON report: %s", jsonOut.String())
+	}
+
+	var junitOut bytes.Buffer
+	if err := report.WriteJUnit(&junitOut); err != nil {
+		t.Fatalf("WriteJUnit failed: %v", err)
+	}
+	if !strings.Contains(junitOut.String(), `<testsuite name="drift" tests="3" failures="3">`) {
+		t.Errorf("Unexpected JUnit report: %s", junitOut.String())
+	}
+}
+
+func TestBuildPlan_RenameWithState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/project/agents/a1":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"agent": {"id": "a1", "name": "assistant"}}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"total": 0, "data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewAPI(server.URL, "project", &api.MockIAMService{})
+	m := &manifest.Manifest{Agents: []manifest.Agent{{Key: "assistant", Name: "assistant-v2"}}}
+
+	st, err := state.Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	st.Set(state.Resource{Kind: string(KindAgent), Key: "assistant", Name: "assistant", ID: "a1"})
+
+	plan, err := BuildPlan(context.Background(), client, m, st)
+	if err != nil {
+		t.Fatalf("BuildPlan failed: %v", err)
+	}
+
+	item := plan.Items[0]
+	if item.Action != PlanUpdate || item.ID != "a1" {
+		t.Fatalf("Expected rename to update a1, got %+v", item)
+	}
+	if len(item.Changes) != 1 || item.Changes[0].PathString() != "name" {
+		t.Errorf("Expected only name to change, got %+v", item.Changes)
+	}
+}
@@ -0,0 +1,255 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+// AllKinds - все типы ресурсов в порядке развертывания
+var AllKinds = []ResourceKind{KindMCPServer, KindAgent, KindAgentSystem}
+
+// Export читает ресурсы проекта указанных типов и строит из них конфигурацию,
+// которую можно развернуть командой deploy. Серверные поля (ID, статусы,
+// даты, авторы) отбрасываются, ссылки на MCP серверы и агентов
+// записываются по имени. Вместе с конфигурацией возвращаются предупреждения
+// о ресурсах, которые нельзя однозначно сослаться по имени.
+//
+// Если задан файл состояния st, значения, которые при развертывании были
+// взяты из секретов, записываются ссылками secret://, а не значениями из проекта.
+func Export(ctx context.Context, client *api.API, kinds []ResourceKind, st *state.State) (*manifest.Manifest, []string, error) {
+	exporter := &exporter{api: client, kinds: make(map[ResourceKind]bool), secretRefs: make(map[string]map[string]string)}
+	for _, kind := range kinds {
+		exporter.kinds[kind] = true
+	}
+	if st != nil {
+		for _, resource := range st.List() {
+			if len(resource.Secrets) > 0 {
+				exporter.secretRefs[resource.ID] = resource.Secrets
+			}
+		}
+	}
+	return exporter.export(ctx)
+}
+
+// exporter хранит состояние одного экспорта: имена ресурсов по ID
+// для записи ссылок и накопленные предупреждения
+type exporter struct {
+	api        *api.API
+	kinds      map[ResourceKind]bool
+	mcpNames   map[string]string
+	agentNames map[string]string
+	// secretRefs - ссылки на секреты из файла состояния по ID ресурса
+	secretRefs map[string]map[string]string
+	warnings   []string
+}
+
+func (e *exporter) export(ctx context.Context) (*manifest.Manifest, []string, error) {
+	m := &manifest.Manifest{}
+
+	if e.kinds[KindMCPServer] || e.kinds[KindAgent] {
+		servers, err := listAllMCPServers(ctx, e.api)
+		if err != nil {
+			return nil, nil, err
+		}
+		e.mcpNames = e.names(KindMCPServer, len(servers), func(i int) (string, string) {
+			return servers[i].ID, servers[i].Name
+		})
+
+		if e.kinds[KindMCPServer] {
+			for _, item := range servers {
+				server, err := e.api.MCPServers.Get(ctx, item.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get MCP server %s: %w", item.Name, err)
+				}
+				exported, err := exportMCPServer(server)
+				if err == nil {
+					err = restoreSecretRefs(&exported, e.secretRefs[item.ID])
+				}
+				if err != nil {
+					return nil, nil, fmt.Errorf("MCP server %s: %w", item.Name, err)
+				}
+				m.MCPServers = append(m.MCPServers, exported)
+			}
+		}
+	}
+
+	if e.kinds[KindAgent] || e.kinds[KindAgentSystem] {
+		agents, err := listAllAgents(ctx, e.api)
+		if err != nil {
+			return nil, nil, err
+		}
+		e.agentNames = e.names(KindAgent, len(agents), func(i int) (string, string) {
+			return agents[i].ID, agents[i].Name
+		})
+
+		if e.kinds[KindAgent] {
+			for _, item := range agents {
+				agent, err := e.api.Agents.Get(ctx, item.ID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get agent %s: %w", item.Name, err)
+				}
+				exported, err := e.exportAgent(agent)
+				if err == nil {
+					err = restoreSecretRefs(&exported, e.secretRefs[item.ID])
+				}
+				if err != nil {
+					return nil, nil, fmt.Errorf("agent %s: %w", item.Name, err)
+				}
+				m.Agents = append(m.Agents, exported)
+			}
+		}
+	}
+
+	if e.kinds[KindAgentSystem] {
+		systems, err := listAllAgentSystems(ctx, e.api)
+		if err != nil {
+			return nil, nil, err
+		}
+		e.names(KindAgentSystem, len(systems), func(i int) (string, string) {
+			return systems[i].ID, systems[i].Name
+		})
+
+		for _, item := range systems {
+			system, err := e.api.AgentSystems.Get(ctx, item.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get agent system %s: %w", item.Name, err)
+			}
+			exported, err := e.exportSystem(system)
+			if err == nil {
+				err = restoreSecretRefs(&exported, e.secretRefs[item.ID])
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("agent system %s: %w", item.Name, err)
+			}
+			m.AgentSystems = append(m.AgentSystems, exported)
+		}
+	}
+
+	sort.Strings(e.warnings)
+	return m, e.warnings, nil
+}
+
+// names строит отображение ID -> имя и добавляет предупреждение для имен,
+// которые встречаются несколько раз: такие ресурсы deploy не различит
+func (e *exporter) names(kind ResourceKind, count int, item func(int) (id, name string)) map[string]string {
+	names := make(map[string]string, count)
+	seen := make(map[string]int)
+	for i := 0; i < count; i++ {
+		id, name := item(i)
+		names[id] = name
+		seen[name]++
+	}
+	for name, n := range seen {
+		if n > 1 {
+			e.warnings = append(e.warnings, fmt.Sprintf("%d %s resources are named '%s'; deploy will only match one of them", n, kind, name))
+		}
+	}
+	return names
+}
+
+// exportMCPServer преобразует MCP сервер проекта в описание для конфигурации
+func exportMCPServer(server *api.MCPServer) (manifest.MCPServer, error) {
+	exported := manifest.MCPServer{
+		Name:               server.Name,
+		Description:        server.Description,
+		InstanceType:       server.InstanceType.ID,
+		ExposedPorts:       server.ExposedPorts,
+		IntegrationOptions: manifest.ToMap(server.IntegrationOptions),
+	}
+
+	if err := convertModel(server.ImageSource, &exported.ImageSource); err != nil {
+		return exported, fmt.Errorf("invalid imageSource: %w", err)
+	}
+	if err := convertModel(server.EnvironmentOptions, &exported.EnvironmentOptions); err != nil {
+		return exported, fmt.Errorf("invalid environmentOptions: %w", err)
+	}
+	if err := convertModel(server.Scaling, &exported.Scaling); err != nil {
+		return exported, fmt.Errorf("invalid scaling: %w", err)
+	}
+
+	return exported, nil
+}
+
+// exportAgent преобразует агента проекта в описание для конфигурации.
+// MCP серверы записываются по имени.
+func (e *exporter) exportAgent(agent *api.Agent) (manifest.Agent, error) {
+	exported := manifest.Agent{
+		Name:               agent.Name,
+		Description:        agent.Description,
+		InstanceType:       agent.InstanceType.ID,
+		IntegrationOptions: manifest.ToMap(agent.IntegrationOptions),
+	}
+
+	if err := convertModel(agent.ImageSource, &exported.ImageSource); err != nil {
+		return exported, fmt.Errorf("invalid imageSource: %w", err)
+	}
+	if err := convertModel(agent.Options, &exported.Options); err != nil {
+		return exported, fmt.Errorf("invalid options: %w", err)
+	}
+
+	for _, server := range agent.MCPServers {
+		exported.MCPServers = append(exported.MCPServers, e.reference(e.mcpNames, server.MCPServerID, server.Name))
+	}
+
+	return exported, nil
+}
+
+// exportSystem преобразует систему агентов проекта в описание для конфигурации.
+// Агенты записываются по имени вместе с настройками масштабирования.
+func (e *exporter) exportSystem(system *api.AgentSystem) (manifest.AgentSystem, error) {
+	exported := manifest.AgentSystem{
+		Name:               system.Name,
+		Description:        system.Description,
+		InstanceType:       system.InstanceType.ID,
+		IntegrationOptions: manifest.ToMap(system.IntegrationOptions),
+	}
+
+	if err := convertModel(system.OrchestratorOptions, &exported.OrchestratorOptions); err != nil {
+		return exported, fmt.Errorf("invalid orchestratorOptions: %w", err)
+	}
+	if err := convertModel(system.Options, &exported.Options); err != nil {
+		return exported, fmt.Errorf("invalid options: %w", err)
+	}
+
+	for _, agent := range system.Agents {
+		ref := manifest.SystemAgent{Name: e.reference(e.agentNames, agent.AgentID, agent.Name)}
+		if err := convertModel(agent.Scaling, &ref.Scaling); err != nil {
+			return exported, fmt.Errorf("invalid scaling of agent %s: %w", ref.Name, err)
+		}
+		exported.Agents = append(exported.Agents, ref)
+	}
+
+	return exported, nil
+}
+
+// reference возвращает имя ресурса по ID. Если ресурс не найден в проекте,
+// используется имя из ответа API, а если нет и его - сам ID.
+func (e *exporter) reference(names map[string]string, id, name string) string {
+	if found, ok := names[id]; ok && found != "" {
+		return found
+	}
+	if name != "" {
+		return name
+	}
+	return id
+}
+
+// convertModel преобразует значение между моделями конфигурации и API
+// через JSON. Пустые значения оставляют dst без изменений; поля, которых
+// нет в целевой модели, отбрасываются.
+func convertModel(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	if string(data) == "null" || string(data) == "{}" {
+		return nil
+	}
+	return json.Unmarshal(data, dst)
+}
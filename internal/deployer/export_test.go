@@ -0,0 +1,123 @@
+package deployer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
+	"gopkg.in/yaml.v3"
+)
+
+const exportInstanceTypeID = "11111111-2222-3333-4444-555555555555"
+
+var exportResponses = map[string]string{
+	"/api/v1/project/mcpServers": `{"total": 1, "data": [{"id": "m1", "name": "weather"}]}`,
+	"/api/v1/project/mcpServers/m1": `{"mcpServer": {
+		"id": "m1", "name": "weather", "description": "Weather MCP", "status": "MCP_SERVER_STATUS_RUNNING",
+		"instanceType": {"id": "` + exportInstanceTypeID + `", "name": "small"},
+		"imageSource": {"arImageUri": "cr.cloud.ru/weather:1"},
+		"exposedPorts": [8080],
+		"environmentOptions": {"rawEnvs": {"LEVEL": "debug"}},
+		"scaling": {"minScale": 1, "maxScale": 2},
+		"createdAt": "2025-01-01T00:00:00Z", "createdBy": "user"}}`,
+	"/api/v1/project/agents": `{"total": 1, "data": [{"id": "a1", "name": "assistant"}]}`,
+	"/api/v1/project/agents/a1": `{"agent": {
+		"id": "a1", "name": "assistant", "description": "Assistant", "status": "AGENT_STATUS_RUNNING",
+		"instanceType": {"id": "` + exportInstanceTypeID + `"},
+		"imageSource": {"arImageUri": "cr.cloud.ru/assistant:1"},
+		"options": {"systemPrompt": "Be helpful", "llm": {"foundationModels": {"modelName": "GigaChat"}}},
+		"mcpServers": [{"mcpServerId": "m1", "status": "MCP_SERVER_STATUS_RUNNING"}],
+		"createdAt": "2025-01-01T00:00:00Z", "createdBy": "user"}}`,
+	"/api/v1/project/agentSystems": `{"total": 1, "data": [{"id": "s1", "name": "team"}]}`,
+	"/api/v1/project/agentSystems/s1": `{"agentSystem": {
+		"id": "s1", "name": "team", "description": "Team", "status": "AGENT_SYSTEM_STATUS_RUNNING",
+		"instanceType": {"id": "` + exportInstanceTypeID + `"},
+		"agents": [{"agentId": "a1", "scaling": {"minScale": 1}}],
+		"orchestratorOptions": {"systemPrompt": "Route requests"},
+		"createdAt": "2025-01-01T00:00:00Z", "createdBy": "user"}}`,
+}
+
+func newExportServer(t *testing.T) *api.API {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := exportResponses[r.URL.Path]
+		if !ok || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return api.NewAPI(server.URL, "project", &api.MockIAMService{})
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	client := newExportServer(t)
+	ctx := context.Background()
+
+	exported, warnings, err := Export(ctx, client, AllKinds, nil)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+
+	data, err := manifest.Marshal(exported)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, field := range []string{"status", "createdAt", "createdBy", "a1", "m1"} {
+		if strings.Contains(string(data), field) {
+			t.Errorf("Expected server-only value %q to be removed, got:\n%s", field, data)
+		}
+	}
+
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("Failed to parse exported YAML: %v", err)
+	}
+	if err := validator.ValidateConfig(config, "../../schemas/schema.json"); err != nil {
+		t.Fatalf("Exported YAML does not pass the schema: %v", err)
+	}
+
+	m, _, err := manifest.FromMap(config)
+	if err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+	if got := m.Agents[0].MCPServers; len(got) != 1 || got[0] != "weather" {
+		t.Errorf("Expected MCP server reference by name, got %v", got)
+	}
+	if got := m.AgentSystems[0].Agents; len(got) != 1 || got[0].Name != "assistant" || got[0].Scaling == nil {
+		t.Errorf("Expected agent reference by name with scaling, got %+v", got)
+	}
+
+	plan, err := BuildPlan(ctx, client, m, nil)
+	if err != nil {
+		t.Fatalf("BuildPlan failed: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("Expected no changes after export, got:\n%s", plan.Render())
+	}
+}
+
+func TestExport_Kinds(t *testing.T) {
+	client := newExportServer(t)
+
+	exported, _, err := Export(context.Background(), client, []ResourceKind{KindAgent}, nil)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(exported.Agents) != 1 || len(exported.MCPServers) != 0 || len(exported.AgentSystems) != 0 {
+		t.Errorf("Expected only agents to be exported, got %+v", exported)
+	}
+}
@@ -0,0 +1,223 @@
+package deployer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+)
+
+// Node представляет ресурс из конфигурации в графе зависимостей
+type Node struct {
+	Kind ResourceKind
+	Name string
+	// StateKey - ключ ресурса в файле состояния (поле key или имя)
+	StateKey string
+	// Resource - описание ресурса из конфигурации:
+	// *manifest.MCPServer, *manifest.Agent или *manifest.AgentSystem
+	Resource interface{}
+}
+
+// Key возвращает уникальный ключ узла
+func (n *Node) Key() string {
+	return nodeKey(n.Kind, n.Name)
+}
+
+// Reference описывает ссылку одного ресурса на другой по имени
+type Reference struct {
+	From *Node
+	Kind ResourceKind
+	Name string
+}
+
+// CycleError возвращается, если ресурсы конфигурации ссылаются друг на друга по кругу
+type CycleError struct {
+	Nodes []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle detected between: %s", strings.Join(e.Nodes, ", "))
+}
+
+// DanglingReferenceError возвращается, если ресурс ссылается на отсутствующий ресурс
+type DanglingReferenceError struct {
+	Reference Reference
+}
+
+func (e *DanglingReferenceError) Error() string {
+	return fmt.Sprintf("%s '%s' references %s '%s' which is neither defined in configuration nor exists in project",
+		e.Reference.From.Kind, e.Reference.From.Name, e.Reference.Kind, e.Reference.Name)
+}
+
+// Graph - граф зависимостей между ресурсами конфигурации
+type Graph struct {
+	nodes    map[string]*Node
+	keys     map[string]bool
+	order    []string
+	deps     map[string][]string
+	external []Reference
+}
+
+// BuildGraph строит граф зависимостей из конфигурации. Агенты зависят от
+// MCP серверов из mcpServers, системы - от агентов из agents. Ссылки на ресурсы,
+// которых нет в конфигурации, считаются внешними и проверяются отдельно
+// (см. ExternalReferences).
+func BuildGraph(m *manifest.Manifest) (*Graph, error) {
+	g := &Graph{
+		nodes: make(map[string]*Node),
+		keys:  make(map[string]bool),
+		deps:  make(map[string][]string),
+	}
+
+	for i := range m.MCPServers {
+		if err := g.addNode(&Node{Kind: KindMCPServer, Name: m.MCPServers[i].Name, StateKey: m.MCPServers[i].StateKey(), Resource: &m.MCPServers[i]}); err != nil {
+			return nil, err
+		}
+	}
+	for i := range m.Agents {
+		if err := g.addNode(&Node{Kind: KindAgent, Name: m.Agents[i].Name, StateKey: m.Agents[i].StateKey(), Resource: &m.Agents[i]}); err != nil {
+			return nil, err
+		}
+	}
+	for i := range m.AgentSystems {
+		if err := g.addNode(&Node{Kind: KindAgentSystem, Name: m.AgentSystems[i].Name, StateKey: m.AgentSystems[i].StateKey(), Resource: &m.AgentSystems[i]}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range g.order {
+		node := g.nodes[key]
+		for _, ref := range nodeReferences(node) {
+			target := nodeKey(ref.Kind, ref.Name)
+			if _, ok := g.nodes[target]; ok {
+				g.deps[key] = append(g.deps[key], target)
+			} else {
+				g.external = append(g.external, ref)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// addNode добавляет узел в граф, проверяя уникальность имени и ключа состояния
+func (g *Graph) addNode(node *Node) error {
+	if _, exists := g.nodes[node.Key()]; exists {
+		return fmt.Errorf("duplicate %s '%s' in configuration", node.Kind, node.Name)
+	}
+	stateKey := nodeKey(node.Kind, node.StateKey)
+	if g.keys[stateKey] {
+		return fmt.Errorf("duplicate %s key '%s' in configuration", node.Kind, node.StateKey)
+	}
+	g.keys[stateKey] = true
+	g.nodes[node.Key()] = node
+	g.order = append(g.order, node.Key())
+	return nil
+}
+
+// Levels возвращает узлы, сгруппированные по уровням: каждый уровень зависит
+// только от предыдущих. Порядок внутри уровня совпадает с порядком в конфигурации.
+func (g *Graph) Levels() ([][]*Node, error) {
+	inDegree := make(map[string]int, len(g.nodes))
+	dependents := make(map[string][]string)
+	for _, key := range g.order {
+		inDegree[key] = len(g.deps[key])
+		for _, dep := range g.deps[key] {
+			dependents[dep] = append(dependents[dep], key)
+		}
+	}
+
+	var levels [][]*Node
+	var current []string
+	for _, key := range g.order {
+		if inDegree[key] == 0 {
+			current = append(current, key)
+		}
+	}
+
+	processed := 0
+	for len(current) > 0 {
+		level := make([]*Node, 0, len(current))
+		next := make(map[string]bool)
+		for _, key := range current {
+			level = append(level, g.nodes[key])
+			processed++
+			for _, dependent := range dependents[key] {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					next[dependent] = true
+				}
+			}
+		}
+		levels = append(levels, level)
+
+		current = current[:0]
+		for _, key := range g.order {
+			if next[key] {
+				current = append(current, key)
+			}
+		}
+	}
+
+	if processed != len(g.nodes) {
+		var cycle []string
+		for _, key := range g.order {
+			if inDegree[key] > 0 {
+				cycle = append(cycle, key)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, &CycleError{Nodes: cycle}
+	}
+
+	return levels, nil
+}
+
+// Dependencies возвращает узлы, от которых зависит указанный узел
+func (g *Graph) Dependencies(node *Node) []*Node {
+	deps := make([]*Node, 0, len(g.deps[node.Key()]))
+	for _, key := range g.deps[node.Key()] {
+		deps = append(deps, g.nodes[key])
+	}
+	return deps
+}
+
+// ExternalReferences возвращает ссылки на ресурсы, отсутствующие в конфигурации
+func (g *Graph) ExternalReferences() []Reference {
+	return g.external
+}
+
+// Nodes возвращает узлы графа в порядке их описания в конфигурации
+func (g *Graph) Nodes() []*Node {
+	nodes := make([]*Node, 0, len(g.order))
+	for _, key := range g.order {
+		nodes = append(nodes, g.nodes[key])
+	}
+	return nodes
+}
+
+// Len возвращает количество ресурсов в графе
+func (g *Graph) Len() int {
+	return len(g.nodes)
+}
+
+// nodeReferences возвращает ссылки ресурса на другие ресурсы
+func nodeReferences(node *Node) []Reference {
+	var refs []Reference
+	switch resource := node.Resource.(type) {
+	case *manifest.Agent:
+		for _, name := range resource.MCPServers {
+			refs = append(refs, Reference{From: node, Kind: KindMCPServer, Name: name})
+		}
+	case *manifest.AgentSystem:
+		for _, agent := range resource.Agents {
+			refs = append(refs, Reference{From: node, Kind: KindAgent, Name: agent.Name})
+		}
+	}
+	return refs
+}
+
+func nodeKey(kind ResourceKind, name string) string {
+	return string(kind) + "/" + name
+}
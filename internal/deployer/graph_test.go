@@ -0,0 +1,101 @@
+package deployer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+)
+
+func mustManifest(t *testing.T, config map[string]interface{}) *manifest.Manifest {
+	t.Helper()
+	m, _, err := manifest.FromMap(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return m
+}
+
+func TestBuildGraph_Levels(t *testing.T) {
+	config := map[string]interface{}{
+		"agent-systems": []interface{}{
+			map[string]interface{}{
+				"name": "support-system",
+				"agents": []interface{}{
+					map[string]interface{}{"name": "support-agent"},
+					"external-agent",
+				},
+			},
+		},
+		"agents": []interface{}{
+			map[string]interface{}{
+				"name":       "support-agent",
+				"mcpServers": []interface{}{"weather"},
+			},
+		},
+		"mcp-servers": []interface{}{
+			map[string]interface{}{"name": "weather"},
+		},
+	}
+
+	graph, err := BuildGraph(mustManifest(t, config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	levels, err := graph.Levels()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"mcp-server/weather", "agent/support-agent", "agent-system/support-system"}
+	if len(levels) != len(expected) {
+		t.Fatalf("Expected %d levels, got %d", len(expected), len(levels))
+	}
+	for i, level := range levels {
+		if len(level) != 1 || level[0].Key() != expected[i] {
+			t.Errorf("Expected level %d to be [%s], got %v", i, expected[i], level)
+		}
+	}
+
+	external := graph.ExternalReferences()
+	if len(external) != 1 || external[0].Name != "external-agent" || external[0].Kind != KindAgent {
+		t.Errorf("Expected external reference to agent 'external-agent', got %v", external)
+	}
+}
+
+func TestBuildGraph_Duplicate(t *testing.T) {
+	config := map[string]interface{}{
+		"agents": []interface{}{
+			map[string]interface{}{"name": "agent"},
+			map[string]interface{}{"name": "agent"},
+		},
+	}
+
+	if _, err := BuildGraph(mustManifest(t, config)); err == nil {
+		t.Error("Expected error for duplicate agent names")
+	}
+}
+
+func TestGraph_Cycle(t *testing.T) {
+	a := &Node{Kind: KindAgent, Name: "a"}
+	b := &Node{Kind: KindAgent, Name: "b"}
+	graph := &Graph{
+		nodes: map[string]*Node{a.Key(): a, b.Key(): b},
+		order: []string{a.Key(), b.Key()},
+		deps: map[string][]string{
+			a.Key(): {b.Key()},
+			b.Key(): {a.Key()},
+		},
+	}
+
+	_, err := graph.Levels()
+
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected CycleError, got %v", err)
+	}
+	if len(cycleErr.Nodes) != 2 {
+		t.Errorf("Expected 2 nodes in cycle, got %v", cycleErr.Nodes)
+	}
+}
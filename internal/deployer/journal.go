@@ -0,0 +1,199 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+)
+
+// JournalEntry - запись журнала развертывания об одном изменении в проекте
+type JournalEntry struct {
+	Kind   ResourceKind
+	Name   string
+	ID     string
+	Action DeployAction
+	// Previous - состояние ресурса до обновления (*api.MCPServer, *api.Agent
+	// или *api.AgentSystem); для созданных ресурсов nil
+	Previous interface{}
+}
+
+// Journal записывает созданные и обновленные ресурсы, чтобы при ошибке
+// развертывание можно было откатить
+type Journal struct {
+	entries []JournalEntry
+}
+
+// Record добавляет в журнал результат развертывания, если ресурс был создан
+// или изменен. Результаты с ошибкой тоже учитываются: ресурс мог быть создан
+// до того, как операция завершилась неудачей.
+func (j *Journal) Record(result DeployResult) {
+	if result.ID == "" {
+		return
+	}
+	switch result.Action {
+	case ActionCreated:
+		j.entries = append(j.entries, JournalEntry{Kind: result.Kind, Name: result.Name, ID: result.ID, Action: ActionCreated})
+	case ActionUpdated:
+		if result.previous == nil {
+			return
+		}
+		j.entries = append(j.entries, JournalEntry{
+			Kind:     result.Kind,
+			Name:     result.Name,
+			ID:       result.ID,
+			Action:   ActionUpdated,
+			Previous: result.previous,
+		})
+	}
+}
+
+// Entries возвращает записи журнала в порядке развертывания
+func (j *Journal) Entries() []JournalEntry {
+	return j.entries
+}
+
+// Len возвращает количество записей в журнале
+func (j *Journal) Len() int {
+	return len(j.entries)
+}
+
+// RolledBack проверяет, было ли развертывание откачено
+func RolledBack(results []DeployResult) bool {
+	for _, result := range results {
+		if result.Action.isRollback() {
+			return true
+		}
+	}
+	return false
+}
+
+// rollback откатывает изменения из журнала в обратном порядке: созданные
+// ресурсы удаляются, обновленные возвращаются к прежней спецификации.
+// Обратный порядок развертывания гарантирует, что зависимые ресурсы
+// откатываются раньше тех, от которых они зависят.
+func (o *Orchestrator) rollback(ctx context.Context, journal *Journal) []DeployResult {
+	entries := journal.Entries()
+	results := make([]DeployResult, 0, len(entries))
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		log.Debug("Rolling back", "kind", entry.Kind, "name", entry.Name, "action", entry.Action)
+
+		var result DeployResult
+		if entry.Action == ActionCreated {
+			result = o.rollbackCreate(ctx, entry)
+		} else {
+			result = o.rollbackUpdate(ctx, entry)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// rollbackCreate удаляет ресурс, созданный при развертывании
+func (o *Orchestrator) rollbackCreate(ctx context.Context, entry JournalEntry) DeployResult {
+	var err error
+	switch entry.Kind {
+	case KindMCPServer:
+		err = o.api.MCPServers.Delete(ctx, entry.ID)
+	case KindAgent:
+		err = o.api.Agents.Delete(ctx, entry.ID)
+	case KindAgentSystem:
+		err = o.api.AgentSystems.Delete(ctx, entry.ID)
+	default:
+		err = fmt.Errorf("unknown resource kind: %s", entry.Kind)
+	}
+
+	result := DeployResult{
+		Success: err == nil,
+		Kind:    entry.Kind,
+		Name:    entry.Name,
+		ID:      entry.ID,
+		Action:  ActionDeleted,
+		Error:   err,
+	}
+	if err != nil {
+		result.Message = fmt.Sprintf("Rollback failed: could not delete %s %s (ID: %s)", entry.Kind, entry.Name, shortID(entry.ID))
+	} else {
+		result.Message = fmt.Sprintf("Rolled back: deleted %s %s (ID: %s)", entry.Kind, entry.Name, shortID(entry.ID))
+	}
+	return result
+}
+
+// rollbackUpdate возвращает обновленный ресурс к спецификации, которая была
+// в проекте до развертывания
+func (o *Orchestrator) rollbackUpdate(ctx context.Context, entry JournalEntry) DeployResult {
+	var err error
+	switch previous := entry.Previous.(type) {
+	case *api.MCPServer:
+		_, err = o.api.MCPServers.UpdateMCPServer(ctx, entry.ID, &api.UpdateMCPServerRequest{
+			Name:               previous.Name,
+			Description:        previous.Description,
+			InstanceTypeID:     previous.InstanceType.ID,
+			ImageSource:        previous.ImageSource,
+			ExposedPorts:       previous.ExposedPorts,
+			EnvironmentOptions: previous.EnvironmentOptions,
+			Scaling:            previous.Scaling,
+			IntegrationOptions: previous.IntegrationOptions,
+		})
+	case *api.Agent:
+		_, err = o.api.Agents.UpdateAgent(ctx, entry.ID, &api.UpdateAgentRequest{
+			Name:               previous.Name,
+			Description:        previous.Description,
+			InstanceTypeID:     previous.InstanceType.ID,
+			ImageSource:        previous.ImageSource,
+			Options:            previous.Options,
+			MCPServers:         previous.MCPServers,
+			IntegrationOptions: previous.IntegrationOptions,
+		})
+	case *api.AgentSystem:
+		err = o.restoreSystem(ctx, entry.ID, previous)
+	default:
+		err = fmt.Errorf("no previous state recorded for %s %s", entry.Kind, entry.Name)
+	}
+
+	result := DeployResult{
+		Success: err == nil,
+		Kind:    entry.Kind,
+		Name:    entry.Name,
+		ID:      entry.ID,
+		Action:  ActionRestored,
+		Error:   err,
+	}
+	if err != nil {
+		result.Message = fmt.Sprintf("Rollback failed: could not restore %s %s (ID: %s)", entry.Kind, entry.Name, shortID(entry.ID))
+	} else {
+		result.Message = fmt.Sprintf("Rolled back: restored previous spec of %s %s (ID: %s)", entry.Kind, entry.Name, shortID(entry.ID))
+	}
+	return result
+}
+
+// restoreSystem возвращает систему агентов к прежней спецификации и составу
+func (o *Orchestrator) restoreSystem(ctx context.Context, id string, previous *api.AgentSystem) error {
+	agents := make([]api.AgentSystemAgent, 0, len(previous.Agents))
+	for _, agent := range previous.Agents {
+		agents = append(agents, api.AgentSystemAgent{AgentID: agent.AgentID, Name: agent.Name, Scaling: agent.Scaling})
+	}
+
+	current, err := o.api.AgentSystems.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get agent system: %w", err)
+	}
+	if err := o.systems.syncAgents(ctx, id, current.Agents, agents); err != nil {
+		return err
+	}
+
+	_, err = o.api.AgentSystems.UpdateAgentSystem(ctx, id, &api.UpdateAgentSystemRequest{
+		Name:                previous.Name,
+		Description:         previous.Description,
+		InstanceTypeID:      previous.InstanceType.ID,
+		OrchestratorOptions: previous.OrchestratorOptions,
+		Options:             previous.Options,
+		IntegrationOptions:  previous.IntegrationOptions,
+		Agents:              agents,
+	})
+	return err
+}
@@ -0,0 +1,95 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+)
+
+func TestJournal_Record(t *testing.T) {
+	journal := &Journal{}
+
+	journal.Record(DeployResult{Success: true, Kind: KindMCPServer, Name: "mcp", ID: "m1", Action: ActionCreated})
+	journal.Record(DeployResult{Success: true, Kind: KindAgent, Name: "same", ID: "a0", Action: ActionUnchanged})
+	journal.Record(DeployResult{Success: false, Kind: KindAgent, Name: "partial", ID: "a1", Action: ActionCreated})
+	journal.Record(DeployResult{Success: false, Kind: KindAgent, Name: "failed"})
+	journal.Record(DeployResult{Success: true, Kind: KindAgent, Name: "updated", ID: "a2", Action: ActionUpdated, previous: &api.Agent{ID: "a2"}})
+
+	entries := journal.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 journal entries, got %d", len(entries))
+	}
+	if entries[1].Name != "partial" || entries[1].Action != ActionCreated {
+		t.Errorf("Expected partially created agent to be journaled, got %+v", entries[1])
+	}
+	if entries[2].Previous == nil {
+		t.Error("Expected previous state for updated agent")
+	}
+}
+
+func TestOrchestrator_Rollback(t *testing.T) {
+	type call struct {
+		Method string
+		Path   string
+		Body   map[string]interface{}
+	}
+	var calls []call
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{Method: r.Method, Path: r.URL.Path}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			json.Unmarshal(data, &c.Body)
+		}
+		calls = append(calls, c)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := api.NewAPI(server.URL, "project", &api.MockIAMService{})
+	orchestrator := NewOrchestrator(client)
+
+	journal := &Journal{}
+	journal.Record(DeployResult{Success: true, Kind: KindMCPServer, Name: "mcp", ID: "m1", Action: ActionCreated})
+	journal.Record(DeployResult{
+		Success:  true,
+		Kind:     KindAgent,
+		Name:     "agent",
+		ID:       "a1",
+		Action:   ActionUpdated,
+		previous: &api.Agent{ID: "a1", Description: "old description"},
+	})
+
+	results := orchestrator.rollback(context.Background(), journal)
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 rollback results, got %d", len(results))
+	}
+	if results[0].Action != ActionRestored || results[1].Action != ActionDeleted {
+		t.Errorf("Expected restore then delete, got %s then %s", results[0].Action, results[1].Action)
+	}
+	for _, result := range results {
+		if !result.Success {
+			t.Errorf("Expected rollback of %s to succeed: %v", result.Name, result.Error)
+		}
+	}
+	if !RolledBack(results) {
+		t.Error("Expected results to be reported as rolled back")
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 API calls, got %d", len(calls))
+	}
+	if calls[0].Path != "/api/v1/project/agents/a1" || calls[0].Body["description"] != "old description" {
+		t.Errorf("Expected agent to be restored first, got %+v", calls[0])
+	}
+	if calls[1].Method != http.MethodDelete || calls[1].Path != "/api/v1/project/mcpServers/m1" {
+		t.Errorf("Expected MCP server to be deleted last, got %+v", calls[1])
+	}
+}
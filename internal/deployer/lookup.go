@@ -0,0 +1,226 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+)
+
+// findAgentByName ищет агента по имени и возвращает его полное описание.
+// Если агент не найден, возвращает nil без ошибки.
+func findAgentByName(ctx context.Context, client *api.API, name string) (*api.Agent, error) {
+	for agent, err := range client.Agents.All(ctx, &api.SearchAgentParams{Name: name}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list agents: %w", err)
+		}
+		// Фильтр name на сервере может искать по подстроке, поэтому
+		// имя сравнивается точно
+		if agent.Name == name {
+			return client.Agents.Get(ctx, agent.ID)
+		}
+	}
+	return nil, nil
+}
+
+// findMCPServerByName ищет MCP сервер по имени и возвращает его полное описание.
+// Если сервер не найден, возвращает nil без ошибки.
+func findMCPServerByName(ctx context.Context, client *api.API, name string) (*api.MCPServer, error) {
+	for server, err := range client.MCPServers.All(ctx, &api.SearchMCPServerParams{Name: name}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list MCP servers: %w", err)
+		}
+		if server.Name == name {
+			return client.MCPServers.Get(ctx, server.ID)
+		}
+	}
+	return nil, nil
+}
+
+// findAgentSystemByName ищет систему агентов по имени и возвращает ее полное описание.
+// Если система не найдена, возвращает nil без ошибки.
+func findAgentSystemByName(ctx context.Context, client *api.API, name string) (*api.AgentSystem, error) {
+	for system, err := range client.AgentSystems.All(ctx, &api.SearchAgentSystemParams{Name: name}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list agent systems: %w", err)
+		}
+		if system.Name == name {
+			return client.AgentSystems.Get(ctx, system.ID)
+		}
+	}
+	return nil, nil
+}
+
+// findMCPServer ищет MCP сервер по ID из файла состояния, а если ID не задан
+// или сервер с этим ID удален из проекта - по имени
+func findMCPServer(ctx context.Context, client *api.API, id, name string) (*api.MCPServer, error) {
+	if id != "" {
+		server, err := client.MCPServers.Get(ctx, id)
+		if err == nil {
+			return server, nil
+		}
+		if !api.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get MCP server %s: %w", id, err)
+		}
+		log.Warn("MCP server from state no longer exists, looking up by name", "id", id, "name", name)
+	}
+	return findMCPServerByName(ctx, client, name)
+}
+
+// findAgent ищет агента по ID из файла состояния, а если ID не задан
+// или агент с этим ID удален из проекта - по имени
+func findAgent(ctx context.Context, client *api.API, id, name string) (*api.Agent, error) {
+	if id != "" {
+		agent, err := client.Agents.Get(ctx, id)
+		if err == nil {
+			return agent, nil
+		}
+		if !api.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get agent %s: %w", id, err)
+		}
+		log.Warn("Agent from state no longer exists, looking up by name", "id", id, "name", name)
+	}
+	return findAgentByName(ctx, client, name)
+}
+
+// findAgentSystem ищет систему агентов по ID из файла состояния, а если ID
+// не задан или система с этим ID удалена из проекта - по имени
+func findAgentSystem(ctx context.Context, client *api.API, id, name string) (*api.AgentSystem, error) {
+	if id != "" {
+		system, err := client.AgentSystems.Get(ctx, id)
+		if err == nil {
+			return system, nil
+		}
+		if !api.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get agent system %s: %w", id, err)
+		}
+		log.Warn("Agent system from state no longer exists, looking up by name", "id", id, "name", name)
+	}
+	return findAgentSystemByName(ctx, client, name)
+}
+
+// findResourceID возвращает ID ресурса с указанным именем или пустую строку,
+// если ресурса нет в проекте
+func findResourceID(ctx context.Context, client *api.API, kind ResourceKind, name string) (string, error) {
+	switch kind {
+	case KindMCPServer:
+		server, err := findMCPServerByName(ctx, client, name)
+		if err != nil || server == nil {
+			return "", err
+		}
+		return server.ID, nil
+	case KindAgent:
+		agent, err := findAgentByName(ctx, client, name)
+		if err != nil || agent == nil {
+			return "", err
+		}
+		return agent.ID, nil
+	case KindAgentSystem:
+		system, err := findAgentSystemByName(ctx, client, name)
+		if err != nil || system == nil {
+			return "", err
+		}
+		return system.ID, nil
+	default:
+		return "", fmt.Errorf("unknown resource kind: %s", kind)
+	}
+}
+
+// findNodeID возвращает ID ресурса конфигурации: по ID из файла состояния,
+// а если ID не задан или ресурс с этим ID удален из проекта - по имени.
+// Если ресурса нет в проекте, возвращает пустую строку.
+func findNodeID(ctx context.Context, client *api.API, kind ResourceKind, stateID, name string) (string, error) {
+	switch kind {
+	case KindMCPServer:
+		server, err := findMCPServer(ctx, client, stateID, name)
+		if err != nil || server == nil {
+			return "", err
+		}
+		return server.ID, nil
+	case KindAgent:
+		agent, err := findAgent(ctx, client, stateID, name)
+		if err != nil || agent == nil {
+			return "", err
+		}
+		return agent.ID, nil
+	case KindAgentSystem:
+		system, err := findAgentSystem(ctx, client, stateID, name)
+		if err != nil || system == nil {
+			return "", err
+		}
+		return system.ID, nil
+	default:
+		return "", fmt.Errorf("unknown resource kind: %s", kind)
+	}
+}
+
+// listAllMCPServers возвращает все MCP серверы проекта, обходя страницы списка
+func listAllMCPServers(ctx context.Context, client *api.API) ([]api.MCPServerPreview, error) {
+	servers, err := api.Collect(client.MCPServers.All(ctx, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MCP servers: %w", err)
+	}
+	return servers, nil
+}
+
+// listAllAgents возвращает всех агентов проекта, обходя страницы списка
+func listAllAgents(ctx context.Context, client *api.API) ([]api.AgentPreview, error) {
+	agents, err := api.Collect(client.Agents.All(ctx, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	return agents, nil
+}
+
+// listAllAgentSystems возвращает все системы агентов проекта, обходя страницы списка
+func listAllAgentSystems(ctx context.Context, client *api.API) ([]api.AgentSystemPreview, error) {
+	systems, err := api.Collect(client.AgentSystems.All(ctx, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent systems: %w", err)
+	}
+	return systems, nil
+}
+
+// ProjectIndex - имена и ID MCP серверов и агентов проекта для проверки
+// ссылок в конфигурации (validate --online)
+type ProjectIndex struct {
+	mcpServers map[string]bool
+	agents     map[string]bool
+}
+
+// LoadProjectIndex загружает имена и ID MCP серверов и агентов проекта
+func LoadProjectIndex(ctx context.Context, client *api.API) (*ProjectIndex, error) {
+	servers, err := listAllMCPServers(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	agents, err := listAllAgents(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &ProjectIndex{
+		mcpServers: make(map[string]bool, 2*len(servers)),
+		agents:     make(map[string]bool, 2*len(agents)),
+	}
+	for _, server := range servers {
+		index.mcpServers[server.ID] = true
+		index.mcpServers[server.Name] = true
+	}
+	for _, agent := range agents {
+		index.agents[agent.ID] = true
+		index.agents[agent.Name] = true
+	}
+	return index, nil
+}
+
+// HasMCPServer проверяет, есть ли в проекте MCP сервер с таким именем или ID
+func (p *ProjectIndex) HasMCPServer(ref string) bool {
+	return p.mcpServers[ref]
+}
+
+// HasAgent проверяет, есть ли в проекте агент с таким именем или ID
+func (p *ProjectIndex) HasAgent(ref string) bool {
+	return p.agents[ref]
+}
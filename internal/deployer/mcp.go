@@ -3,23 +3,21 @@ package deployer
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/charmbracelet/log"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
 )
 
-// MCPServerConfig представляет конфигурацию MCP сервера из YAML
-type MCPServerConfig struct {
-	Name        string                 `yaml:"name"`
-	Description string                 `yaml:"description"`
-	Options     map[string]interface{} `yaml:"options"`
-}
-
 // MCPDeployer обрабатывает развертывание MCP серверов
 type MCPDeployer struct {
-	api *api.API
+	api      *api.API
+	parallel ParallelOptions
 }
 
 // NewMCPDeployer создает новый MCP деплойер
@@ -29,11 +27,23 @@ func NewMCPDeployer(api *api.API) *MCPDeployer {
 	}
 }
 
+// SetParallel задает параллельное развертывание MCP серверов
+func (d *MCPDeployer) SetParallel(opts ParallelOptions) {
+	d.parallel = opts
+}
+
 // DeployResult представляет результат развертывания
 type DeployResult struct {
 	Success bool
+	Kind    ResourceKind
+	Name    string
+	ID      string
+	Action  DeployAction
 	Message string
 	Error   error
+
+	// previous - состояние ресурса в проекте до обновления, используется для отката
+	previous interface{}
 }
 
 // DeployMCPServers развертывает MCP серверы из YAML файла
@@ -41,95 +51,197 @@ func (d *MCPDeployer) DeployMCPServers(ctx context.Context, filePath string, dry
 	log.Info("Starting MCP servers deployment", "file", filePath, "dry_run", dryRun)
 
 	// Парсим YAML файл с includes
-	config, err := parser.ProcessYAMLFile(filePath)
+	m, err := loadManifest(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process YAML file: %w", err)
+		return nil, err
 	}
 
-	// Извлекаем MCP серверы
-	mcpServers, ok := config["mcp-servers"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("mcp-servers section not found or invalid in YAML file")
-	}
+	// Пути secret://file отсчитываются от директории конфигурации
+	ctx = secrets.WithBaseDir(ctx, filepath.Dir(filePath))
 
-	if len(mcpServers) == 0 {
+	if len(m.MCPServers) == 0 {
 		return []DeployResult{{
 			Success: true,
 			Message: "No MCP servers to deploy",
 		}}, nil
 	}
 
-	var results []DeployResult
+	registry := NewRegistry(d.api)
 
-	// Обрабатываем каждый MCP сервер
-	for i, serverData := range mcpServers {
-		serverMap, ok := serverData.(map[string]interface{})
-		if !ok {
-			results = append(results, DeployResult{
-				Success: false,
-				Message: fmt.Sprintf("Invalid MCP server configuration at index %d", i),
-				Error:   fmt.Errorf("expected map, got %T", serverData),
-			})
-			continue
-		}
+	// Обрабатываем MCP серверы; они не зависят друг от друга
+	tasks := make([]task, len(m.MCPServers))
+	for i := range m.MCPServers {
+		server := &m.MCPServers[i]
+		tasks[i] = task{kind: KindMCPServer, name: server.Name, run: func(ctx context.Context) DeployResult {
+			return d.deployMCPServer(ctx, server, dryRun, registry)
+		}}
+	}
 
-		// Конвертируем в структуру
-		serverConfig := MCPServerConfig{
-			Name:        getString(serverMap, "name"),
-			Description: getString(serverMap, "description"),
-			Options:     getMap(serverMap, "options"),
-		}
+	results := make([]DeployResult, len(tasks))
+	runTasks(ctx, tasks, d.parallel, func(int, task) {}, func(i int, result DeployResult) {
+		results[i] = result
+	})
 
-		if serverConfig.Name == "" {
-			results = append(results, DeployResult{
-				Success: false,
-				Message: fmt.Sprintf("MCP server at index %d missing name", i),
-				Error:   fmt.Errorf("name is required"),
-			})
-			continue
+	return results, nil
+}
+
+// deployMCPServer развертывает один MCP сервер: создает его, если сервера с таким
+// именем нет в проекте, или обновляет, если конфигурация отличается
+func (d *MCPDeployer) deployMCPServer(ctx context.Context, server *manifest.MCPServer, dryRun bool, registry *Registry) DeployResult {
+	log.Info("Deploying MCP server", "name", server.Name, "dry_run", dryRun)
+
+	fail := func(id, message string, err error) DeployResult {
+		log.Error(message, "name", server.Name, "error", err)
+		return DeployResult{
+			Success: false,
+			Kind:    KindMCPServer,
+			Name:    server.Name,
+			ID:      id,
+			Message: message,
+			Error:   err,
 		}
+	}
 
-		// Развертываем сервер
-		result := d.deployMCPServer(ctx, serverConfig, dryRun)
-		results = append(results, result)
+	// API MCP серверов не принимает options (AIA011)
+	if len(server.Options) > 0 {
+		err := fmt.Errorf("MCP server '%s' sets options, which the API does not accept", server.Name)
+		return fail("", err.Error(), err)
 	}
 
-	return results, nil
-}
+	instanceTypeID, err := registry.ResolveInstanceType(ctx, server.InstanceType)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve instance type for MCP server: %s", server.Name), err)
+	}
 
-// deployMCPServer развертывает один MCP сервер
-func (d *MCPDeployer) deployMCPServer(ctx context.Context, config MCPServerConfig, dryRun bool) DeployResult {
-	log.Info("Deploying MCP server", "name", config.Name, "dry_run", dryRun)
+	existing, err := findMCPServer(ctx, d.api, registry.stateID(KindMCPServer, server.StateKey()), server.Name)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to look up MCP server: %s", server.Name), err)
+	}
+
+	var changes []Change
+	if existing != nil {
+		changes, err = diffSpecs(ctx, mcpServerSpec(server, instanceTypeID), mcpServerSpecFromLive(existing), registry.secretCompare(KindMCPServer, server.StateKey(), !dryRun))
+		if err != nil {
+			return fail(existing.ID, fmt.Sprintf("Failed to compare MCP server: %s", server.Name), err)
+		}
+	}
 
 	if dryRun {
+		action := ActionCreated
+		id := ""
+		if existing != nil {
+			id = existing.ID
+			action = ActionUnchanged
+			if len(changes) > 0 {
+				action = ActionUpdated
+			}
+		}
 		return DeployResult{
 			Success: true,
-			Message: fmt.Sprintf("Would deploy MCP server: %s", config.Name),
+			Kind:    KindMCPServer,
+			Name:    server.Name,
+			ID:      id,
+			Action:  action,
+			Message: fmt.Sprintf("Would deploy MCP server: %s (%s)", server.Name, action),
 		}
 	}
 
-	// Создаем запрос для API
-	createReq := &api.MCPServerCreateRequest{
-		Name:        config.Name,
-		Description: config.Description,
-		Options:     config.Options,
+	// Секреты разрешаются только для запроса к API
+	environmentOptions, err := secrets.ResolveMap(ctx, manifest.ToMap(server.EnvironmentOptions))
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve secrets for MCP server: %s", server.Name), err)
 	}
 
-	// Вызываем API
-	server, err := d.api.MCPServers.Create(ctx, createReq)
-	if err != nil {
-		log.Error("Failed to create MCP server", "name", config.Name, "error", err)
+	// Запрос для API собирается из словарей конфигурации после разрешения секретов
+	updateReq := &api.UpdateMCPServerRequest{
+		Name:           server.Name,
+		Description:    server.Description,
+		InstanceTypeID: instanceTypeID,
+		ExposedPorts:   server.ExposedPorts,
+	}
+	if err := convertModel(map[string]interface{}{
+		"imageSource":        manifest.ToMap(server.ImageSource),
+		"environmentOptions": environmentOptions,
+		"scaling":            manifest.ToMap(server.Scaling),
+		"integrationOptions": server.IntegrationOptions,
+	}, updateReq); err != nil {
+		return fail("", fmt.Sprintf("Invalid configuration of MCP server: %s", server.Name), err)
+	}
+
+	if existing == nil {
+		createReq := api.CreateMCPServerRequest(*updateReq)
+
+		// Вызываем API
+		created, err := d.api.MCPServers.CreateMCPServer(ctx, &createReq)
+		if err != nil {
+			return fail("", fmt.Sprintf("Failed to create MCP server: %s", server.Name), err)
+		}
+
+		log.Info("MCP server created successfully", "name", server.Name, "id", created.MCPServerID)
 		return DeployResult{
-			Success: false,
-			Message: fmt.Sprintf("Failed to create MCP server: %s", config.Name),
-			Error:   err,
+			Success: true,
+			Kind:    KindMCPServer,
+			Name:    server.Name,
+			ID:      created.MCPServerID,
+			Action:  ActionCreated,
+			Message: fmt.Sprintf("Successfully created MCP server: %s (ID: %s)", server.Name, created.MCPServerID),
 		}
 	}
 
-	log.Info("MCP server created successfully", "name", config.Name, "id", server.ID)
+	if len(changes) == 0 {
+		log.Info("MCP server is up to date", "name", server.Name, "id", existing.ID)
+		return DeployResult{
+			Success: true,
+			Kind:    KindMCPServer,
+			Name:    server.Name,
+			ID:      existing.ID,
+			Action:  ActionUnchanged,
+			Message: fmt.Sprintf("MCP server is up to date: %s (ID: %s)", server.Name, existing.ID),
+		}
+	}
+
+	if _, err := d.api.MCPServers.UpdateMCPServer(ctx, existing.ID, updateReq); err != nil {
+		return fail(existing.ID, fmt.Sprintf("Failed to update MCP server: %s", server.Name), err)
+	}
+
+	log.Info("MCP server updated successfully", "name", server.Name, "id", existing.ID, "changes", len(changes))
 	return DeployResult{
 		Success: true,
-		Message: fmt.Sprintf("Successfully created MCP server: %s (ID: %s)", config.Name, server.ID),
+		Kind:    KindMCPServer,
+		Name:    server.Name,
+		ID:      existing.ID,
+		Action:  ActionUpdated,
+		Message: fmt.Sprintf("Successfully updated MCP server: %s (ID: %s, %d changes)", server.Name, existing.ID, len(changes)),
+
+		previous: existing,
+	}
+}
+
+// mcpServerSpec возвращает желаемую спецификацию MCP сервера для сравнения с проектом
+func mcpServerSpec(server *manifest.MCPServer, instanceTypeID string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":               server.Name,
+		"description":        server.Description,
+		"instanceTypeId":     instanceTypeID,
+		"imageSource":        manifest.ToMap(server.ImageSource),
+		"exposedPorts":       server.ExposedPorts,
+		"environmentOptions": manifest.ToMap(server.EnvironmentOptions),
+		"scaling":            manifest.ToMap(server.Scaling),
+		"integrationOptions": server.IntegrationOptions,
+	}
+}
+
+// mcpServerSpecFromLive возвращает спецификацию MCP сервера из проекта в том же виде, что и mcpServerSpec
+func mcpServerSpecFromLive(server *api.MCPServer) map[string]interface{} {
+	return map[string]interface{}{
+		"name":               server.Name,
+		"description":        server.Description,
+		"instanceTypeId":     server.InstanceType.ID,
+		"imageSource":        server.ImageSource,
+		"exposedPorts":       server.ExposedPorts,
+		"environmentOptions": server.EnvironmentOptions,
+		"scaling":            server.Scaling,
+		"integrationOptions": server.IntegrationOptions,
 	}
 }
 
@@ -176,6 +288,10 @@ func (d *MCPDeployer) ValidateMCPServers(filePath string) error {
 		}
 	}
 
+	if err := validateConfigFile(filePath); err != nil {
+		return err
+	}
+
 	log.Info("MCP servers configuration is valid", "count", len(mcpServers))
 	return nil
 }
@@ -184,10 +300,22 @@ func (d *MCPDeployer) ValidateMCPServers(filePath string) error {
 func ShowDeployResults(results []DeployResult) {
 	successCount := 0
 	errorCount := 0
+	total := 0
+	actionCounts := make(map[DeployAction]int)
+	var rollback []DeployResult
 
 	for _, result := range results {
+		if result.Action.isRollback() {
+			rollback = append(rollback, result)
+			continue
+		}
+
+		total++
 		if result.Success {
 			successCount++
+			if result.Action != "" {
+				actionCounts[result.Action]++
+			}
 			fmt.Println(ui.FormatSuccess(result.Message))
 		} else {
 			errorCount++
@@ -201,8 +329,105 @@ func ShowDeployResults(results []DeployResult) {
 	// Итоговая статистика
 	fmt.Printf("\n📊 Deployment Summary:\n")
 	fmt.Printf("  ✅ Successful: %d\n", successCount)
+	fmt.Printf("  🆕 Created: %d\n", actionCounts[ActionCreated])
+	fmt.Printf("  🔄 Updated: %d\n", actionCounts[ActionUpdated])
+	fmt.Printf("  ⏸️  Unchanged: %d\n", actionCounts[ActionUnchanged])
 	fmt.Printf("  ❌ Failed: %d\n", errorCount)
-	fmt.Printf("  📋 Total: %d\n", len(results))
+	fmt.Printf("  📋 Total: %d\n", total)
+
+	if len(rollback) == 0 {
+		return
+	}
+
+	// Результаты отката атомарного развертывания
+	rollbackFailed := 0
+	fmt.Printf("\n↩️  Rollback:\n")
+	for _, result := range rollback {
+		if result.Success {
+			fmt.Println(ui.FormatSuccess(result.Message))
+			continue
+		}
+		rollbackFailed++
+		fmt.Println(ui.FormatError(result.Message))
+		if result.Error != nil {
+			fmt.Printf("  Error: %v\n", result.Error)
+		}
+	}
+	fmt.Printf("  🗑️  Deleted: %d\n", countAction(rollback, ActionDeleted))
+	fmt.Printf("  ⏪ Restored: %d\n", countAction(rollback, ActionRestored))
+	fmt.Printf("  ❌ Failed: %d\n", rollbackFailed)
+}
+
+// countAction возвращает количество успешных результатов с указанным действием
+func countAction(results []DeployResult, action DeployAction) int {
+	count := 0
+	for _, result := range results {
+		if result.Success && result.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// printResult выводит результат развертывания одного ресурса с его номером
+func printResult(index, total int, result DeployResult) {
+	message := fmt.Sprintf("[%d/%d] %s", index, total, result.Message)
+	switch {
+	case !result.Success:
+		fmt.Println(ui.FormatError(message))
+	case result.Action == ActionUnchanged:
+		fmt.Println(ui.FormatInfo(message))
+	default:
+		fmt.Println(ui.FormatSuccess(message))
+	}
+}
+
+// validateConfigFile обрабатывает YAML файл с includes и проверяет итоговую
+// конфигурацию по схеме и семантическим правилам. Предупреждения правил
+// выводятся в лог, ошибки возвращаются как *validator.SemanticError.
+func validateConfigFile(filePath string) error {
+	doc, err := parser.ProcessYAMLDocument(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to process YAML file with includes: %w", err)
+	}
+
+	if err := validator.ValidateDocument(doc); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	findings, err := validator.CheckSemantics(doc, nil)
+	if err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+	for _, finding := range findings {
+		if finding.Rule.Severity == validator.SeverityWarning {
+			log.Warn(finding.String())
+		}
+	}
+	return validator.FindingsError(findings)
+}
+
+// loadManifest обрабатывает YAML файл с includes и строит типизированную модель
+// конфигурации. Предупреждения об устаревших ключах выводятся в лог.
+func loadManifest(filePath string) (*manifest.Manifest, error) {
+	config, err := parser.ProcessYAMLFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process YAML file with includes: %w", err)
+	}
+
+	return manifestFromConfig(config)
+}
+
+// manifestFromConfig строит типизированную модель из обработанной конфигурации
+func manifestFromConfig(config map[string]interface{}) (*manifest.Manifest, error) {
+	m, warnings, err := manifest.FromMap(config)
+	for _, warning := range warnings {
+		log.Warn(warning)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 // getString извлекает строку из map с проверкой типа
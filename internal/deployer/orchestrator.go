@@ -0,0 +1,252 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
+)
+
+// DeployOptions содержит параметры развертывания конфигурации
+type DeployOptions struct {
+	DryRun      bool
+	BuildImages bool
+	// Atomic - при ошибке откатить все изменения, сделанные при развертывании
+	Atomic bool
+	// ProjectDir - директория конфигурации: в ней ищется Dockerfile для сборки
+	// образов агентов, от нее отсчитываются пути secret://file
+	ProjectDir string
+	// State - файл состояния. Ресурсы ищутся по ID из него, а успешно
+	// развернутые ресурсы записываются в него. nil - состояние не ведется.
+	State *state.State
+	// Parallel - параллельное развертывание ресурсов одного уровня графа
+	// зависимостей. Атомарное развертывание всегда прерывается при первой ошибке.
+	Parallel ParallelOptions
+}
+
+// Orchestrator развертывает ресурсы всех типов из одной конфигурации
+// в порядке, определяемом графом зависимостей
+type Orchestrator struct {
+	api      *api.API
+	mcp      *MCPDeployer
+	agents   *AgentDeployer
+	systems  *SystemDeployer
+	registry *Registry
+}
+
+// NewOrchestrator создает новый оркестратор развертывания
+func NewOrchestrator(client *api.API) *Orchestrator {
+	return &Orchestrator{
+		api:      client,
+		mcp:      NewMCPDeployer(client),
+		agents:   NewAgentDeployer(client),
+		systems:  NewSystemDeployer(client),
+		registry: NewRegistry(client),
+	}
+}
+
+// Deploy развертывает ресурсы из модели конфигурации.
+// Ссылки на ресурсы по имени проверяются до начала развертывания; если ресурс
+// не удалось развернуть, зависящие от него ресурсы пропускаются. Независимые
+// ресурсы одного уровня графа развертываются параллельно (opts.Parallel).
+// При отмене ctx ресурсы, развертывание которых еще не началось, пропускаются.
+func (o *Orchestrator) Deploy(ctx context.Context, m *manifest.Manifest, opts DeployOptions) ([]DeployResult, error) {
+	graph, err := BuildGraph(m)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := graph.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	o.registry.UseState(opts.State)
+	ctx = secrets.WithBaseDir(ctx, opts.ProjectDir)
+
+	if err := o.checkReferences(ctx, graph); err != nil {
+		return nil, err
+	}
+
+	agentOpts := agentDeployOptions{
+		DryRun:     opts.DryRun,
+		ProjectDir: opts.ProjectDir,
+	}
+	if opts.BuildImages {
+		agentOpts.DockerClient = newDockerClient()
+	}
+
+	var results []DeployResult
+	failed := make(map[string]bool)
+	index := 0
+
+	var journal *Journal
+	parallel := opts.Parallel
+	if opts.Atomic && !opts.DryRun {
+		journal = &Journal{}
+		parallel.FailFast = true
+	}
+
+	// Записи состояния применяются после развертывания, чтобы откаченные
+	// атомарные развертывания не попадали в файл состояния
+	var applied []state.Resource
+
+	// halted - причина, по которой оставшиеся уровни не развертываются
+	halted := ""
+
+	for _, level := range levels {
+		tasks := make([]task, len(level))
+		for i, node := range level {
+			tasks[i] = task{
+				kind: node.Kind,
+				name: node.Name,
+				run: func(ctx context.Context) DeployResult {
+					return o.deployNode(ctx, node, agentOpts)
+				},
+			}
+			if dep := failedDependency(graph, node, failed); dep != nil {
+				tasks[i].skip = fmt.Sprintf("dependency %s %s failed", dep.Kind, dep.Name)
+			} else if halted != "" {
+				tasks[i].skip = halted
+			}
+		}
+
+		levelFailed := false
+		runTasks(ctx, tasks, parallel, func(i int, t task) {
+			if t.skip != "" {
+				return
+			}
+			if opts.DryRun {
+				fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Dry run for %s: %s", index+i+1, graph.Len(), t.kind, t.name)))
+			} else {
+				fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Deploying %s: %s", index+i+1, graph.Len(), t.kind, t.name)))
+			}
+		}, func(i int, result DeployResult) {
+			node := level[i]
+			printResult(index+i+1, graph.Len(), result)
+			results = append(results, result)
+
+			if journal != nil {
+				journal.Record(result)
+			}
+
+			if !result.Success {
+				failed[node.Key()] = true
+				levelFailed = true
+				return
+			}
+			o.registry.Register(node.Kind, node.Name, result.ID)
+
+			if opts.State != nil && !opts.DryRun {
+				applied = append(applied, stateEntry(ctx, node, result.ID, opts.State))
+			}
+		})
+		index += len(level)
+
+		if journal != nil && levelFailed {
+			// Откат выполняется и после отмены развертывания пользователем
+			return append(results, o.abort(context.WithoutCancel(ctx), journal, graph.Len()-index)...), nil
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			halted = "deployment cancelled"
+		case parallel.FailFast && levelFailed:
+			halted = "deployment stopped after an error"
+		}
+	}
+
+	for _, resource := range applied {
+		opts.State.Set(resource)
+	}
+
+	return results, nil
+}
+
+// stateEntry возвращает запись файла состояния для развернутого ресурса.
+// Маски секретов переносятся из предыдущей записи в st, если ссылки
+// не разрешались в этом запуске.
+func stateEntry(ctx context.Context, node *Node, id string, st *state.State) state.Resource {
+	hash, err := state.HashSpec(node.Resource)
+	if err != nil {
+		log.Warn("Failed to hash resource spec", "kind", node.Kind, "name", node.Name, "error", err)
+	}
+	previous, _ := st.Get(string(node.Kind), node.StateKey)
+	refs := secrets.Refs(manifest.ToMap(node.Resource))
+	return state.Resource{
+		Kind:         string(node.Kind),
+		Key:          node.StateKey,
+		Name:         node.Name,
+		ID:           id,
+		SpecHash:     hash,
+		Secrets:      refs,
+		SecretHashes: secretHashes(ctx, refs, previous),
+		AppliedAt:    time.Now().UTC(),
+	}
+}
+
+// deployNode развертывает один ресурс соответствующим деплойером
+func (o *Orchestrator) deployNode(ctx context.Context, node *Node, agentOpts agentDeployOptions) DeployResult {
+	switch resource := node.Resource.(type) {
+	case *manifest.MCPServer:
+		return o.mcp.deployMCPServer(ctx, resource, agentOpts.DryRun, o.registry)
+	case *manifest.Agent:
+		return o.agents.deployAgent(ctx, resource, agentOpts, o.registry)
+	case *manifest.AgentSystem:
+		return o.systems.deploySystem(ctx, resource, agentOpts.DryRun, o.registry)
+	default:
+		err := fmt.Errorf("unknown resource kind: %s", node.Kind)
+		return DeployResult{Success: false, Kind: node.Kind, Name: node.Name, Message: err.Error(), Error: err}
+	}
+}
+
+// abort прерывает атомарное развертывание после ошибки и откатывает журнал
+func (o *Orchestrator) abort(ctx context.Context, journal *Journal, remaining int) []DeployResult {
+	if remaining > 0 {
+		fmt.Println(ui.FormatWarning(fmt.Sprintf("Deployment aborted, %d remaining resources were not deployed", remaining)))
+	}
+	if journal.Len() == 0 {
+		return nil
+	}
+
+	fmt.Println(ui.FormatWarning(fmt.Sprintf("Rolling back %d changes...", journal.Len())))
+	rollback := o.rollback(ctx, journal)
+	for i, result := range rollback {
+		printResult(i+1, len(rollback), result)
+	}
+	return rollback
+}
+
+// checkReferences проверяет, что ресурсы, на которые ссылается конфигурация,
+// но которые в ней не описаны, существуют в проекте
+func (o *Orchestrator) checkReferences(ctx context.Context, graph *Graph) error {
+	var errs []error
+	for _, ref := range graph.ExternalReferences() {
+		log.Debug("Resolving external reference", "from", ref.From.Key(), "kind", ref.Kind, "name", ref.Name)
+		if _, err := o.registry.Resolve(ctx, ref.Kind, ref.Name); err != nil {
+			var notFound *NotFoundError
+			if !errors.As(err, &notFound) {
+				return err
+			}
+			errs = append(errs, &DanglingReferenceError{Reference: ref})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// failedDependency возвращает первую зависимость узла, развертывание которой не удалось
+func failedDependency(graph *Graph, node *Node, failed map[string]bool) *Node {
+	for _, dep := range graph.Dependencies(node) {
+		if failed[dep.Key()] {
+			return dep
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/config"
+)
+
+// ParallelOptions задает параллельное развертывание независимых ресурсов
+type ParallelOptions struct {
+	// Parallel - максимальное количество одновременно развертываемых ресурсов.
+	// 0 или 1 - ресурсы развертываются по одному.
+	Parallel int
+	// FailFast - при первой ошибке не начинать развертывание остальных ресурсов.
+	// Уже начатые развертывания завершаются, чтобы созданные ресурсы получили
+	// ID и могли быть откачены.
+	FailFast bool
+}
+
+// ResolveParallelOptions возвращает параметры параллельного развертывания:
+// parallel из флага --parallel, а если флаг не задан - BULK_OPERATIONS_CONCURRENCY
+func ResolveParallelOptions(parallel int, failFast bool, getConfig func() (*config.Config, error)) ParallelOptions {
+	if parallel <= 0 {
+		cfg, err := getConfig()
+		if err != nil {
+			log.Warn("Failed to load configuration, deploying resources one at a time", "error", err)
+		} else {
+			parallel = cfg.BulkOperationsConcurrencyFactor
+		}
+	}
+	return ParallelOptions{Parallel: parallel, FailFast: failFast}
+}
+
+// task - развертывание одного ресурса в пуле
+type task struct {
+	kind ResourceKind
+	name string
+	// skip - причина, по которой ресурс пропускается без развертывания
+	skip string
+	run  func(ctx context.Context) DeployResult
+}
+
+// skipped возвращает результат пропущенного ресурса
+func (t task) skipped(reason string) DeployResult {
+	return DeployResult{
+		Success: false,
+		Kind:    t.kind,
+		Name:    t.name,
+		Message: fmt.Sprintf("Skipped %s %s: %s", t.kind, t.name, reason),
+	}
+}
+
+// runTasks выполняет задачи в пуле из opts.Parallel потоков. Функции start
+// и done вызываются из вызывающей горутины строго в порядке задач: start -
+// перед ожиданием результата задачи, done - с ее результатом, поэтому вывод
+// по каждому ресурсу не перемешивается. С opts.FailFast после ошибки одной
+// задачи новые задачи не запускаются, а выполняющиеся не прерываются: отмена
+// их запросов оставила бы ресурс, уже принятый сервером, без ID в журнале
+// отката. При отмене ctx задачи, которые еще не начались, пропускаются.
+func runTasks(ctx context.Context, tasks []task, opts ParallelOptions, start func(i int, t task), done func(i int, result DeployResult)) {
+	workers := opts.Parallel
+	if workers < 1 {
+		workers = 1
+	}
+
+	// stopped закрывается после первой ошибки с opts.FailFast
+	stopped := make(chan struct{})
+	var stopOnce sync.Once
+
+	results := make([]DeployResult, len(tasks))
+	finished := make([]chan struct{}, len(tasks))
+	for i := range finished {
+		finished[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	go func() {
+		sem := make(chan struct{}, workers)
+		for i, t := range tasks {
+			if t.skip != "" {
+				results[i] = t.skipped(t.skip)
+				close(finished[i])
+				continue
+			}
+
+			acquired := false
+			select {
+			case sem <- struct{}{}:
+				acquired = true
+			case <-ctx.Done():
+			case <-stopped:
+			}
+			if ctx.Err() != nil || isClosed(stopped) {
+				if acquired {
+					<-sem
+				}
+				results[i] = t.skipped("deployment cancelled")
+				close(finished[i])
+				continue
+			}
+
+			wg.Go(func() {
+				defer func() { <-sem }()
+				results[i] = t.run(ctx)
+				if !results[i].Success && opts.FailFast {
+					stopOnce.Do(func() { close(stopped) })
+				}
+				close(finished[i])
+			})
+		}
+	}()
+
+	for i, t := range tasks {
+		start(i, t)
+		<-finished[i]
+		done(i, results[i])
+	}
+	wg.Wait()
+}
+
+// isClosed проверяет, закрыт ли канал
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunTasks_OrderAndLimit(t *testing.T) {
+	var running, peak int32
+
+	tasks := make([]task, 8)
+	for i := range tasks {
+		tasks[i] = task{kind: KindAgent, name: fmt.Sprintf("agent-%d", i), run: func(ctx context.Context) DeployResult {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+					break
+				}
+			}
+			// Первые задачи выполняются дольше, чтобы завершиться позже остальных
+			time.Sleep(time.Duration(len(tasks)-i) * 5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return DeployResult{Success: true, Kind: KindAgent, Name: fmt.Sprintf("agent-%d", i)}
+		}}
+	}
+
+	var order []string
+	runTasks(context.Background(), tasks, ParallelOptions{Parallel: 3}, func(int, task) {}, func(i int, result DeployResult) {
+		order = append(order, result.Name)
+	})
+
+	for i, name := range order {
+		if name != fmt.Sprintf("agent-%d", i) {
+			t.Fatalf("Expected results in task order, got %v", order)
+		}
+	}
+	if peak > 3 {
+		t.Errorf("Expected at most 3 tasks at once, got %d", peak)
+	}
+	if peak < 2 {
+		t.Errorf("Expected tasks to run in parallel, got peak %d", peak)
+	}
+}
+
+func TestRunTasks_FailFast(t *testing.T) {
+	newTasks := func() []task {
+		return []task{
+			{kind: KindAgent, name: "broken", run: func(ctx context.Context) DeployResult {
+				return DeployResult{Success: false, Kind: KindAgent, Name: "broken"}
+			}},
+			{kind: KindAgent, name: "slow", run: func(ctx context.Context) DeployResult {
+				time.Sleep(20 * time.Millisecond)
+				return DeployResult{Success: true, Kind: KindAgent, Name: "slow"}
+			}},
+			{kind: KindAgent, name: "dependent", skip: "dependency mcp-server weather failed"},
+		}
+	}
+
+	collect := func(opts ParallelOptions) []DeployResult {
+		var results []DeployResult
+		runTasks(context.Background(), newTasks(), opts, func(int, task) {}, func(i int, result DeployResult) {
+			results = append(results, result)
+		})
+		return results
+	}
+
+	results := collect(ParallelOptions{Parallel: 1})
+	if !results[1].Success {
+		t.Errorf("Expected sibling to be deployed without --fail-fast, got %+v", results[1])
+	}
+	if results[2].Success || !strings.Contains(results[2].Message, "dependency mcp-server weather failed") {
+		t.Errorf("Expected skipped task, got %+v", results[2])
+	}
+
+	results = collect(ParallelOptions{Parallel: 1, FailFast: true})
+	if results[1].Success || !strings.Contains(results[1].Message, "deployment cancelled") {
+		t.Errorf("Expected sibling to be cancelled with --fail-fast, got %+v", results[1])
+	}
+}
+
+func TestRunTasks_FailFastKeepsInFlightTasks(t *testing.T) {
+	// Первая задача падает, когда вторая уже выполняется; вторая завершается
+	// только после того, как ошибка первой обработана
+	started := make(chan struct{})
+	inFlight := make(chan struct{})
+
+	tasks := []task{
+		{kind: KindAgent, name: "broken", run: func(ctx context.Context) DeployResult {
+			<-started
+			return DeployResult{Success: false, Kind: KindAgent, Name: "broken"}
+		}},
+		{kind: KindAgent, name: "created", run: func(ctx context.Context) DeployResult {
+			close(started)
+			<-inFlight
+			// Запрос создания, уже принятый сервером, не должен прерываться
+			if ctx.Err() != nil {
+				return DeployResult{Success: false, Kind: KindAgent, Name: "created", Error: ctx.Err()}
+			}
+			return DeployResult{Success: true, Kind: KindAgent, Name: "created", ID: "a1", Action: ActionCreated}
+		}},
+		{kind: KindAgent, name: "pending", run: func(ctx context.Context) DeployResult {
+			return DeployResult{Success: true, Kind: KindAgent, Name: "pending"}
+		}},
+	}
+
+	var results []DeployResult
+	runTasks(context.Background(), tasks, ParallelOptions{Parallel: 2, FailFast: true}, func(int, task) {}, func(i int, result DeployResult) {
+		if i == 0 {
+			close(inFlight)
+		}
+		results = append(results, result)
+	})
+
+	if !results[1].Success || results[1].ID != "a1" {
+		t.Errorf("Expected in-flight create to finish with its ID, got %+v", results[1])
+	}
+	if results[2].Success || !strings.Contains(results[2].Message, "deployment cancelled") {
+		t.Errorf("Expected pending task not to start after the error, got %+v", results[2])
+	}
+
+	// Созданный ресурс попадает в журнал отката
+	journal := &Journal{}
+	for _, result := range results {
+		journal.Record(result)
+	}
+	if len(journal.Entries()) != 1 {
+		t.Errorf("Expected created resource to be journaled, got %+v", journal.Entries())
+	}
+}
@@ -0,0 +1,346 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+// PlanAction определяет действие, которое будет выполнено с ресурсом
+type PlanAction string
+
+const (
+	// PlanCreate - ресурс будет создан
+	PlanCreate PlanAction = "create"
+	// PlanUpdate - ресурс будет обновлен
+	PlanUpdate PlanAction = "update"
+	// PlanDelete - ресурс будет удален
+	PlanDelete PlanAction = "delete"
+	// PlanNoop - ресурс совпадает с конфигурацией
+	PlanNoop PlanAction = "no-op"
+)
+
+// PlanItem описывает планируемое изменение одного ресурса
+type PlanItem struct {
+	Kind    ResourceKind
+	Name    string
+	ID      string
+	Action  PlanAction
+	Changes []Change
+}
+
+// Plan представляет набор изменений, необходимых для приведения проекта
+// к состоянию из конфигурации
+type Plan struct {
+	Items []PlanItem
+}
+
+var (
+	planCreateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	planDeleteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	planUpdateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	planNoopStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	planHeaderStyle = lipgloss.NewStyle().Bold(true)
+)
+
+// BuildPlan сравнивает обработанную YAML конфигурацию с ресурсами проекта
+// и возвращает план изменений. Ресурсы перечисляются в порядке развертывания,
+// который определяется графом зависимостей. Если передан файл состояния,
+// ресурсы ищутся по ID из него, поэтому переименование планируется как обновление.
+func BuildPlan(ctx context.Context, client *api.API, m *manifest.Manifest, st *state.State) (*Plan, error) {
+	graph, err := BuildGraph(m)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := graph.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	registry := NewRegistry(client)
+	registry.UseState(st)
+	plan := &Plan{}
+
+	for _, level := range levels {
+		for _, node := range level {
+			if err := plan.addNode(ctx, client, registry, node); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// addNode сравнивает ресурс из конфигурации с проектом и добавляет его в план.
+// Ресурс регистрируется в реестре, чтобы ссылки на него из следующих
+// уровней графа разрешались, даже если он еще не создан.
+func (p *Plan) addNode(ctx context.Context, client *api.API, registry *Registry, node *Node) error {
+	switch resource := node.Resource.(type) {
+	case *manifest.MCPServer:
+		instanceTypeID, err := registry.ResolveInstanceType(ctx, resource.InstanceType)
+		if err != nil {
+			return fmt.Errorf("MCP server %s: %w", node.Name, err)
+		}
+
+		existing, err := findMCPServer(ctx, client, registry.stateID(node.Kind, node.StateKey), node.Name)
+		if err != nil {
+			return fmt.Errorf("failed to look up MCP server %s: %w", node.Name, err)
+		}
+
+		desired := mcpServerSpec(resource, instanceTypeID)
+		if existing == nil {
+			err = p.add(ctx, node.Kind, node.Name, "", desired, nil, registry.secretCompare(node.Kind, node.StateKey, false))
+		} else {
+			err = p.add(ctx, node.Kind, node.Name, existing.ID, desired, mcpServerSpecFromLive(existing), registry.secretCompare(node.Kind, node.StateKey, false))
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", node.Kind, node.Name, err)
+		}
+
+	case *manifest.Agent:
+		instanceTypeID, err := registry.ResolveInstanceType(ctx, resource.InstanceType)
+		if err != nil {
+			return fmt.Errorf("agent %s: %w", node.Name, err)
+		}
+
+		mcpServers, err := resolveMCPServers(ctx, registry, resource.MCPServers)
+		if err != nil {
+			return fmt.Errorf("agent %s: %w", node.Name, err)
+		}
+
+		existing, err := findAgent(ctx, client, registry.stateID(node.Kind, node.StateKey), node.Name)
+		if err != nil {
+			return fmt.Errorf("failed to look up agent %s: %w", node.Name, err)
+		}
+
+		desired := agentSpec(resource, instanceTypeID, manifest.ToMap(resource.ImageSource), mcpServers)
+		if existing == nil {
+			err = p.add(ctx, node.Kind, node.Name, "", desired, nil, registry.secretCompare(node.Kind, node.StateKey, false))
+		} else {
+			err = p.add(ctx, node.Kind, node.Name, existing.ID, desired, agentSpecFromLive(existing), registry.secretCompare(node.Kind, node.StateKey, false))
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", node.Kind, node.Name, err)
+		}
+
+	case *manifest.AgentSystem:
+		instanceTypeID, err := registry.ResolveInstanceType(ctx, resource.InstanceType)
+		if err != nil {
+			return fmt.Errorf("agent system %s: %w", node.Name, err)
+		}
+
+		agents, err := resolveAgents(ctx, registry, resource.Agents)
+		if err != nil {
+			return fmt.Errorf("agent system %s: %w", node.Name, err)
+		}
+
+		existing, err := findAgentSystem(ctx, client, registry.stateID(node.Kind, node.StateKey), node.Name)
+		if err != nil {
+			return fmt.Errorf("failed to look up agent system %s: %w", node.Name, err)
+		}
+
+		desired := systemSpec(resource, instanceTypeID, agents)
+		if existing == nil {
+			err = p.add(ctx, node.Kind, node.Name, "", desired, nil, registry.secretCompare(node.Kind, node.StateKey, false))
+		} else {
+			err = p.add(ctx, node.Kind, node.Name, existing.ID, desired, systemSpecFromLive(existing), registry.secretCompare(node.Kind, node.StateKey, false))
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", node.Kind, node.Name, err)
+		}
+
+	default:
+		return fmt.Errorf("unknown resource kind: %s", node.Kind)
+	}
+
+	registry.Register(node.Kind, node.Name, p.Items[len(p.Items)-1].ID)
+	return nil
+}
+
+// add добавляет в план ресурс, вычисляя действие по разнице спецификаций.
+// live == nil означает, что ресурс отсутствует в проекте. Секреты
+// сравниваются по хешу и выводятся маской (см. diffSpecs); ссылки на
+// секреты при построении плана не разрешаются.
+func (p *Plan) add(ctx context.Context, kind ResourceKind, name, id string, desired, live map[string]interface{}, cmp secretCompare) error {
+	item := PlanItem{Kind: kind, Name: name, ID: id}
+
+	changes, err := diffSpecs(ctx, desired, live, cmp)
+	if err != nil {
+		return err
+	}
+	item.Changes = changes
+
+	switch {
+	case live == nil:
+		item.Action = PlanCreate
+	default:
+		item.Action = PlanNoop
+		if len(item.Changes) > 0 {
+			item.Action = PlanUpdate
+		}
+	}
+
+	p.Items = append(p.Items, item)
+	return nil
+}
+
+// Counts возвращает количество ресурсов для каждого действия
+func (p *Plan) Counts() map[PlanAction]int {
+	counts := make(map[PlanAction]int)
+	for _, item := range p.Items {
+		counts[item.Action]++
+	}
+	return counts
+}
+
+// HasChanges проверяет, содержит ли план хотя бы одно изменение
+func (p *Plan) HasChanges() bool {
+	for _, item := range p.Items {
+		if item.Action != PlanNoop {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary возвращает итоговую строку плана
+func (p *Plan) Summary() string {
+	counts := p.Counts()
+	return fmt.Sprintf("Plan: %d to create, %d to update, %d to delete, %d unchanged.",
+		counts[PlanCreate], counts[PlanUpdate], counts[PlanDelete], counts[PlanNoop])
+}
+
+// Render возвращает план в виде дерева изменений с цветовой разметкой
+func (p *Plan) Render() string {
+	var b strings.Builder
+
+	for _, item := range p.Items {
+		symbol, style := planSymbol(item.Action)
+
+		header := fmt.Sprintf("%s %s %q", symbol, item.Kind, item.Name)
+		if item.ID != "" {
+			header += fmt.Sprintf(" (ID: %s)", shortID(item.ID))
+		}
+		if item.Action == PlanNoop {
+			b.WriteString(planNoopStyle.Render("  "+header+" - no changes") + "\n")
+			continue
+		}
+
+		if len(item.Changes) == 0 {
+			b.WriteString(style.Render("  "+header) + "\n")
+			continue
+		}
+
+		b.WriteString(style.Render("  "+header) + " {\n")
+		renderChanges(&b, item.Changes, 0, 3)
+		b.WriteString("    }\n")
+	}
+
+	b.WriteString("\n" + planHeaderStyle.Render(p.Summary()) + "\n")
+	return b.String()
+}
+
+// renderChanges выводит изменения, группируя их по общему префиксу пути,
+// чтобы вложенные поля (options.llm.model) отображались деревом
+func renderChanges(b *strings.Builder, changes []Change, depth, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	for i := 0; i < len(changes); {
+		key := changes[i].Path[depth]
+
+		j := i
+		for j < len(changes) && changes[j].Path[depth] == key {
+			j++
+		}
+		group := changes[i:j]
+		i = j
+
+		if len(group) == 1 && len(group[0].Path) == depth+1 {
+			renderLeaf(b, group[0], key, indent)
+			continue
+		}
+
+		b.WriteString(planUpdateStyle.Render(pad+"~ "+key) + " {\n")
+		renderChanges(b, group, depth+1, indent+2)
+		b.WriteString(pad + "  }\n")
+	}
+}
+
+// renderLeaf выводит изменение одного поля
+func renderLeaf(b *strings.Builder, change Change, key string, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch change.Kind {
+	case ChangeAdded:
+		renderValue(b, "+", planCreateStyle, key, change.New, indent)
+	case ChangeRemoved:
+		renderValue(b, "-", planDeleteStyle, key, change.Old, indent)
+	default:
+		line := fmt.Sprintf("%s~ %s = %s -> %s", pad, key, formatValue(change.Old), formatValue(change.New))
+		b.WriteString(planUpdateStyle.Render(line) + "\n")
+	}
+}
+
+// renderValue выводит добавляемое или удаляемое значение, раскрывая вложенные словари
+func renderValue(b *strings.Builder, symbol string, style lipgloss.Style, key string, value interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	nested, ok := value.(map[string]interface{})
+	if !ok {
+		b.WriteString(style.Render(fmt.Sprintf("%s%s %s = %s", pad, symbol, key, formatValue(value))) + "\n")
+		return
+	}
+
+	b.WriteString(style.Render(fmt.Sprintf("%s%s %s", pad, symbol, key)) + " {\n")
+	keys := make([]string, 0, len(nested))
+	for k := range nested {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if isZero(nested[k]) {
+			continue
+		}
+		renderValue(b, symbol, style, k, nested[k], indent+2)
+	}
+	b.WriteString(pad + "  }\n")
+}
+
+// planSymbol возвращает символ и стиль для действия плана
+func planSymbol(action PlanAction) (string, lipgloss.Style) {
+	switch action {
+	case PlanCreate:
+		return "+", planCreateStyle
+	case PlanDelete:
+		return "-", planDeleteStyle
+	case PlanUpdate:
+		return "~", planUpdateStyle
+	default:
+		return " ", planNoopStyle
+	}
+}
+
+// formatValue форматирует значение поля для вывода в плане
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q", val)
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,146 @@
+package deployer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+// PruneOptions определяет, какие ресурсы проекта принадлежат конфигурации
+type PruneOptions struct {
+	// State - файл состояния: ресурсы из него, которых больше нет
+	// в конфигурации, подлежат удалению
+	State *state.State
+	// Prefix - префикс имени: ресурсы проекта с этим префиксом, которых нет
+	// в конфигурации, подлежат удалению. Пустая строка - не использовать префикс.
+	Prefix string
+	// Protected - ресурсы, которые никогда не удаляются: имя или <kind>/<имя>
+	Protected []string
+}
+
+// PrunePlan - план удаления ресурсов, которые принадлежат конфигурации,
+// но больше в ней не описаны
+type PrunePlan struct {
+	Plan
+	// Protected - ресурсы, которые подлежали бы удалению, но защищены
+	Protected []PlanItem
+}
+
+// pruneOrder - порядок удаления типов ресурсов, обратный порядку зависимостей
+var pruneOrder = []ResourceKind{KindAgentSystem, KindAgent, KindMCPServer}
+
+// BuildPrunePlan находит ресурсы проекта, которые принадлежат конфигурации
+// по файлу состояния или префиксу имени, но не описаны в ней. Ресурсы
+// перечисляются в порядке, обратном порядку зависимостей: системы агентов,
+// агенты, MCP серверы. Записи состояния о ресурсах, которых уже нет в проекте,
+// удаляются из состояния.
+func BuildPrunePlan(ctx context.Context, client *api.API, m *manifest.Manifest, opts PruneOptions) (*PrunePlan, error) {
+	graph, err := BuildGraph(m)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ресурсы конфигурации (по имени и по ID из состояния) и ресурсы,
+	// на которые она ссылается, не удаляются
+	declared := make(map[string]bool)
+	declaredIDs := make(map[string]bool)
+	declaredKeys := make(map[string]bool)
+	for _, node := range graph.Nodes() {
+		declared[node.Key()] = true
+		declaredKeys[nodeKey(node.Kind, node.StateKey)] = true
+		if opts.State != nil {
+			if resource, ok := opts.State.Get(string(node.Kind), node.StateKey); ok {
+				declaredIDs[resource.ID] = true
+			}
+		}
+	}
+	for _, ref := range graph.ExternalReferences() {
+		declared[nodeKey(ref.Kind, ref.Name)] = true
+	}
+
+	// Ресурсы, которыми конфигурация владела по файлу состояния
+	ownedIDs := make(map[string]bool)
+	var stateEntries []state.Resource
+	if opts.State != nil {
+		for _, resource := range opts.State.List() {
+			if declaredKeys[nodeKey(ResourceKind(resource.Kind), resource.Key)] {
+				continue
+			}
+			ownedIDs[resource.ID] = true
+			stateEntries = append(stateEntries, resource)
+		}
+	}
+
+	if len(ownedIDs) == 0 && opts.Prefix == "" {
+		return &PrunePlan{}, nil
+	}
+
+	live, err := listLiveNames(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	liveIDs := make(map[string]bool)
+	for _, resources := range live {
+		for _, resource := range resources {
+			liveIDs[resource.id] = true
+		}
+	}
+	for _, resource := range stateEntries {
+		if !liveIDs[resource.ID] {
+			log.Info("Resource from state no longer exists, removing it from state", "kind", resource.Kind, "key", resource.Key, "id", resource.ID)
+			opts.State.Remove(resource.Kind, resource.Key)
+		}
+	}
+
+	protected := make(map[string]bool, len(opts.Protected))
+	for _, name := range opts.Protected {
+		protected[name] = true
+	}
+
+	plan := &PrunePlan{}
+	for _, kind := range pruneOrder {
+		for _, resource := range live[kind] {
+			if declared[nodeKey(kind, resource.name)] || declaredIDs[resource.id] {
+				continue
+			}
+			owned := ownedIDs[resource.id] || (opts.Prefix != "" && strings.HasPrefix(resource.name, opts.Prefix))
+			if !owned {
+				continue
+			}
+
+			item := PlanItem{Kind: kind, Name: resource.name, ID: resource.id, Action: PlanDelete}
+			if protected[resource.name] || protected[nodeKey(kind, resource.name)] {
+				log.Info("Resource is protected, skipping prune", "kind", kind, "name", resource.name)
+				plan.Protected = append(plan.Protected, item)
+				continue
+			}
+			plan.Items = append(plan.Items, item)
+		}
+	}
+
+	return plan, nil
+}
+
+// ForgetDeleted удаляет из файла состояния записи об успешно удаленных ресурсах
+func ForgetDeleted(st *state.State, results []DeployResult) {
+	if st == nil {
+		return
+	}
+
+	deleted := make(map[string]bool)
+	for _, result := range results {
+		if result.Success && result.Action == ActionDeleted {
+			deleted[result.ID] = true
+		}
+	}
+	for _, resource := range st.List() {
+		if deleted[resource.ID] {
+			st.Remove(resource.Kind, resource.Key)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package deployer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+func TestBuildPrunePlan(t *testing.T) {
+	responses := map[string]string{
+		"/api/v1/project/mcpServers": `{"total": 2, "data": [
+			{"id": "m1", "name": "shop-weather"},
+			{"id": "m2", "name": "shop-search"}]}`,
+		"/api/v1/project/agents": `{"total": 4, "data": [
+			{"id": "a1", "name": "shop-assistant"},
+			{"id": "a2", "name": "shop-legacy"},
+			{"id": "a3", "name": "billing-bot"},
+			{"id": "a4", "name": "shop-admin"}]}`,
+		"/api/v1/project/agentSystems": `{"total": 1, "data": [{"id": "s1", "name": "team"}]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(responses[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	client := api.NewAPI(server.URL, "project", &api.MockIAMService{})
+	m := &manifest.Manifest{Agents: []manifest.Agent{
+		{Name: "shop-assistant", MCPServers: []string{"shop-search"}},
+	}}
+
+	st, err := state.Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	st.Set(state.Resource{Kind: string(KindAgent), Key: "shop-assistant", ID: "a1"})
+	st.Set(state.Resource{Kind: string(KindAgentSystem), Key: "team", ID: "s1"})
+	st.Set(state.Resource{Kind: string(KindAgent), Key: "removed", ID: "gone"})
+
+	plan, err := BuildPrunePlan(context.Background(), client, m, PruneOptions{
+		State:     st,
+		Prefix:    "shop-",
+		Protected: []string{"agent/shop-admin"},
+	})
+	if err != nil {
+		t.Fatalf("BuildPrunePlan failed: %v", err)
+	}
+
+	// Системы удаляются раньше агентов, агенты - раньше MCP серверов.
+	// shop-search не удаляется: на него ссылается агент из конфигурации.
+	expected := []string{"s1", "a2", "m1"}
+	if len(plan.Items) != len(expected) {
+		t.Fatalf("Expected %d items, got %+v", len(expected), plan.Items)
+	}
+	for i, id := range expected {
+		if plan.Items[i].ID != id || plan.Items[i].Action != PlanDelete {
+			t.Errorf("Expected item %d to delete %s, got %+v", i, id, plan.Items[i])
+		}
+	}
+	if len(plan.Protected) != 1 || plan.Protected[0].ID != "a4" {
+		t.Errorf("Expected shop-admin to be protected, got %+v", plan.Protected)
+	}
+	if _, ok := st.Get(string(KindAgent), "removed"); ok {
+		t.Error("Expected stale state entry to be removed")
+	}
+
+	ForgetDeleted(st, []DeployResult{{Success: true, Kind: KindAgentSystem, ID: "s1", Action: ActionDeleted}})
+	if _, ok := st.Get(string(KindAgentSystem), "team"); ok {
+		t.Error("Expected deleted system to be removed from state")
+	}
+}
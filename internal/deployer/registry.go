@@ -0,0 +1,163 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+// uuidPattern проверяет, что ссылка на ресурс задана идентификатором, а не именем
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// NotFoundError возвращается, если ресурс не найден ни в реестре, ни в проекте
+type NotFoundError struct {
+	Kind ResourceKind
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s '%s' not found in configuration or project", e.Kind, e.Name)
+}
+
+// Registry хранит соответствие имен ресурсов их ID в рамках одного запуска.
+// Ресурсы, созданные в текущем запуске, регистрируются сразу после создания,
+// остальные ищутся в проекте при первом обращении.
+type Registry struct {
+	api *api.API
+	// state - файл состояния; nil, если состояние не ведется
+	state *state.State
+
+	mu            sync.Mutex
+	ids           map[ResourceKind]map[string]string
+	instanceTypes map[string]string
+}
+
+// NewRegistry создает новый реестр ресурсов
+func NewRegistry(client *api.API) *Registry {
+	return &Registry{
+		api:           client,
+		ids:           make(map[ResourceKind]map[string]string),
+		instanceTypes: make(map[string]string),
+	}
+}
+
+// Register запоминает ID ресурса. Пустой ID означает, что ресурс будет
+// создан, но его ID еще неизвестен (режим dry-run).
+func (r *Registry) Register(kind ResourceKind, name, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ids[kind] == nil {
+		r.ids[kind] = make(map[string]string)
+	}
+	r.ids[kind][name] = id
+}
+
+// UseState подключает файл состояния: ресурсы конфигурации сначала ищутся
+// в проекте по ID из него и только затем по имени
+func (r *Registry) UseState(st *state.State) {
+	r.state = st
+}
+
+// stateID возвращает ID ресурса из файла состояния или пустую строку
+func (r *Registry) stateID(kind ResourceKind, key string) string {
+	if r.state == nil {
+		return ""
+	}
+	resource, ok := r.state.Get(string(kind), key)
+	if !ok {
+		return ""
+	}
+	return resource.ID
+}
+
+// secretCompare возвращает параметры сравнения секретов ресурса с маскам
+// значений из файла состояния. resolve разрешает ссылки при сравнении.
+func (r *Registry) secretCompare(kind ResourceKind, key string, resolve bool) secretCompare {
+	cmp := secretCompare{resolve: resolve}
+	if r.state != nil {
+		cmp.applied, _ = r.state.Get(string(kind), key)
+	}
+	return cmp
+}
+
+// Lookup возвращает ID ранее зарегистрированного ресурса
+func (r *Registry) Lookup(kind ResourceKind, name string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.ids[kind][name]
+	return id, ok
+}
+
+// Resolve возвращает ID ресурса по ссылке из конфигурации. Ссылка может быть
+// именем ресурса или его UUID. Если ресурс не найден ни в реестре, ни в проекте,
+// возвращается ошибка.
+func (r *Registry) Resolve(ctx context.Context, kind ResourceKind, ref string) (string, error) {
+	if id, ok := r.Lookup(kind, ref); ok {
+		return id, nil
+	}
+
+	id, err := r.find(ctx, kind, ref)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		if uuidPattern.MatchString(ref) {
+			return ref, nil
+		}
+		return "", &NotFoundError{Kind: kind, Name: ref}
+	}
+
+	r.Register(kind, ref, id)
+	return id, nil
+}
+
+// find ищет ресурс по имени в проекте и возвращает его ID
+func (r *Registry) find(ctx context.Context, kind ResourceKind, name string) (string, error) {
+	return findResourceID(ctx, r.api, kind, name)
+}
+
+// ResolveInstanceType возвращает ID типа вычислительной конфигурации.
+// Ссылка может быть UUID или именем типа, которое ищется через /instanceTypes.
+// Пустая ссылка возвращается как есть.
+func (r *Registry) ResolveInstanceType(ctx context.Context, ref string) (string, error) {
+	if ref == "" || uuidPattern.MatchString(ref) {
+		return ref, nil
+	}
+
+	r.mu.Lock()
+	id, ok := r.instanceTypes[ref]
+	r.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var matches []string
+	for instanceType, err := range r.api.InstanceTypes.All(ctx, &api.SearchInstanceTypesParams{Name: ref}) {
+		if err != nil {
+			return "", fmt.Errorf("failed to search instance types: %w", err)
+		}
+		if instanceType.Name == ref {
+			matches = append(matches, instanceType.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("instance type '%s' not found", ref)
+	case 1:
+		id = matches[0]
+	default:
+		return "", fmt.Errorf("instance type name '%s' is ambiguous (%d matches), use its ID instead", ref, len(matches))
+	}
+
+	r.mu.Lock()
+	r.instanceTypes[ref] = id
+	r.mu.Unlock()
+	return id, nil
+}
@@ -0,0 +1,45 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+)
+
+func TestResolveInstanceType_AllPages(t *testing.T) {
+	// Фильтр name на сервере ищет по подстроке, точное совпадение - на второй странице
+	var types []api.InstanceType
+	for i := range api.DefaultPageSize {
+		types = append(types, api.InstanceType{ID: fmt.Sprintf("t%d", i), Name: fmt.Sprintf("cpu-small-%d", i)})
+	}
+	types = append(types, api.InstanceType{ID: "exact", Name: "cpu-small"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("name") != "cpu-small" {
+			t.Errorf("Expected name=cpu-small, got %s", query.Get("name"))
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		page := types[min(offset, len(types)):min(offset+limit, len(types))]
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(api.SearchInstanceTypeResponse{Data: page, Total: len(types)})
+	}))
+	defer server.Close()
+
+	registry := NewRegistry(api.NewAPI(server.URL, "project", &api.MockIAMService{}))
+	id, err := registry.ResolveInstanceType(context.Background(), "cpu-small")
+	if err != nil {
+		t.Fatalf("ResolveInstanceType failed: %v", err)
+	}
+	if id != "exact" {
+		t.Errorf("Expected exact, got %s", id)
+	}
+}
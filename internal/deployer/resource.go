@@ -0,0 +1,75 @@
+package deployer
+
+import (
+	"fmt"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+)
+
+// ResourceKind определяет тип развертываемого ресурса
+type ResourceKind string
+
+const (
+	// KindMCPServer - MCP сервер
+	KindMCPServer ResourceKind = "mcp-server"
+	// KindAgent - агент
+	KindAgent ResourceKind = "agent"
+	// KindAgentSystem - система агентов
+	KindAgentSystem ResourceKind = "agent-system"
+)
+
+// Section возвращает секцию конфигурации с ресурсами этого типа
+func (k ResourceKind) Section() string {
+	switch k {
+	case KindMCPServer:
+		return "mcp-servers"
+	case KindAgent:
+		return "agents"
+	case KindAgentSystem:
+		return "agent-systems"
+	default:
+		return string(k)
+	}
+}
+
+// AnnotateSources добавляет к ошибкам развертывания позицию определения
+// ресурса в исходном файле конфигурации: agents/support.yaml:3:5
+func AnnotateSources(results []DeployResult, sources parser.SourceMap) {
+	for i, result := range results {
+		if result.Error == nil {
+			continue
+		}
+		if pos, ok := sources.Resource(result.Kind.Section(), result.Name); ok {
+			results[i].Error = fmt.Errorf("%s: %w", pos, result.Error)
+		}
+	}
+}
+
+// DeployAction описывает, что было сделано с ресурсом при развертывании
+type DeployAction string
+
+const (
+	// ActionCreated - ресурс отсутствовал и был создан
+	ActionCreated DeployAction = "created"
+	// ActionUpdated - ресурс существовал и был обновлен
+	ActionUpdated DeployAction = "updated"
+	// ActionUnchanged - ресурс существовал и совпадает с конфигурацией
+	ActionUnchanged DeployAction = "unchanged"
+	// ActionDeleted - созданный ресурс удален при откате
+	ActionDeleted DeployAction = "deleted"
+	// ActionRestored - обновленный ресурс возвращен к прежней спецификации при откате
+	ActionRestored DeployAction = "restored"
+)
+
+// isRollback проверяет, относится ли действие к откату развертывания
+func (a DeployAction) isRollback() bool {
+	return a == ActionDeleted || a == ActionRestored
+}
+
+// shortID возвращает сокращенный ID ресурса для вывода
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,155 @@
+package deployer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+// secretCompare задает, как сравниваются ссылки на секреты
+type secretCompare struct {
+	// resolve - разрешать ссылки. Только при развертывании, когда сразу после
+	// сравнения формируется запрос к API; план, dry-run и drift ссылки не
+	// разрешают, чтобы не читать файлы и не выполнять команды secret://exec.
+	resolve bool
+	// applied - запись файла состояния ресурса с масками примененных значений
+	applied state.Resource
+}
+
+// diffSpecs сравнивает спецификации ресурса. Ссылки на секреты и
+// расшифрованные значения в желаемой спецификации, а также значения по тем
+// же путям в текущей заменяются масками (secrets.Mask), поэтому секреты
+// сравниваются по хешу и не попадают в вывод. live == nil означает, что
+// ресурса нет в проекте.
+func diffSpecs(ctx context.Context, desired, live map[string]interface{}, cmp secretCompare) ([]Change, error) {
+	desired, live, err := maskSecrets(ctx, desired, live, cmp)
+	if err != nil {
+		return nil, err
+	}
+	if live == nil {
+		live = map[string]interface{}{}
+	}
+	return Diff(desired, live), nil
+}
+
+// maskSecrets возвращает копии спецификаций с масками вместо секретов.
+// Без cmp.resolve маской ссылки служит маска значения из файла состояния,
+// если ссылка с последнего развертывания не менялась, а иначе - сама ссылка:
+// ее значение неизвестно до развертывания, и изменение попадает в план.
+func maskSecrets(ctx context.Context, desired, live map[string]interface{}, cmp secretCompare) (map[string]interface{}, map[string]interface{}, error) {
+	d, _ := normalize(desired).(map[string]interface{})
+	l, _ := normalize(live).(map[string]interface{})
+
+	var err error
+	walkStrings(d, nil, func(keys []string, value string) bool {
+		var mask string
+		switch {
+		case secrets.IsRef(value) && cmp.resolve:
+			if value, err = secrets.Resolve(ctx, value); err != nil {
+				return false
+			}
+			mask = secrets.Mask(value)
+		case secrets.IsRef(value):
+			path := strings.Join(keys, ".")
+			mask = value
+			if cmp.applied.Secrets[path] == value && cmp.applied.SecretHashes[path] != "" {
+				mask = cmp.applied.SecretHashes[path]
+			}
+		case secrets.IsSecret(value):
+			mask = secrets.Mask(value)
+		default:
+			return true
+		}
+
+		setPath(d, keys, mask)
+		if liveValue, ok := getPath(l, keys).(string); ok {
+			setPath(l, keys, secrets.Mask(liveValue))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, l, nil
+}
+
+// secretHashes возвращает маски значений секретов ресурса для файла
+// состояния. Значения берутся из разрешенных в этом запуске ссылок, а для
+// ссылок, которые не разрешались, - из предыдущей записи, если ссылка
+// не изменилась. Ссылки при этом не разрешаются.
+func secretHashes(ctx context.Context, refs map[string]string, previous state.Resource) map[string]string {
+	hashes := make(map[string]string)
+	for path, ref := range refs {
+		if !secrets.IsRef(ref) {
+			continue
+		}
+		if value, ok := secrets.Resolved(ctx, ref); ok {
+			hashes[path] = secrets.Mask(value)
+		} else if previous.Secrets[path] == ref && previous.SecretHashes[path] != "" {
+			hashes[path] = previous.SecretHashes[path]
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return hashes
+}
+
+// walkStrings вызывает fn для строковых значений вложенных словарей с путем
+// к значению. Обход прекращается, если fn возвращает false.
+func walkStrings(m map[string]interface{}, path []string, fn func(keys []string, value string) bool) bool {
+	for _, key := range sortedKeys(m) {
+		keys := appendPath(path, key)
+		switch v := m[key].(type) {
+		case string:
+			if !fn(keys, v) {
+				return false
+			}
+		case map[string]interface{}:
+			if !walkStrings(v, keys, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// restoreSecretRefs записывает в ресурс конфигурации ссылки на секреты
+// из файла состояния вместо значений, полученных из проекта
+func restoreSecretRefs(resource interface{}, refs map[string]string) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	m := manifest.ToMap(resource)
+	for path, ref := range refs {
+		keys := strings.Split(path, ".")
+		if _, ok := getPath(m, keys).(string); ok {
+			setPath(m, keys, ref)
+		}
+	}
+	return convertModel(m, resource)
+}
+
+// getPath возвращает значение вложенного словаря по пути
+func getPath(m map[string]interface{}, keys []string) interface{} {
+	var value interface{} = m
+	for _, key := range keys {
+		current, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = current[key]
+	}
+	return value
+}
+
+// setPath заменяет значение вложенного словаря по пути, если путь существует
+func setPath(m map[string]interface{}, keys []string, value interface{}) {
+	parent, ok := getPath(m, keys[:len(keys)-1]).(map[string]interface{})
+	if ok {
+		parent[keys[len(keys)-1]] = value
+	}
+}
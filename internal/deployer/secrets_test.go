@@ -0,0 +1,130 @@
+package deployer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/state"
+)
+
+func TestBuildPlan_Secrets(t *testing.T) {
+	client := newExportServer(t)
+	ctx := context.Background()
+
+	m, _, err := Export(ctx, client, []ResourceKind{KindMCPServer}, nil)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	st, err := state.Load(t.TempDir() + "/state.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const path = "environmentOptions.rawEnvs.LEVEL"
+
+	// План не разрешает ссылки: переменная окружения не задана, а команда
+	// exec не должна выполняться
+	ref := "secret://exec/false"
+	m.MCPServers[0].EnvironmentOptions.RawEnvs["LEVEL"] = ref
+
+	// Ссылка не менялась, маска примененного значения совпадает с проектом
+	st.Set(state.Resource{
+		Kind:         string(KindMCPServer),
+		Key:          "weather",
+		ID:           "m1",
+		Secrets:      map[string]string{path: ref},
+		SecretHashes: map[string]string{path: secrets.Mask("debug")},
+	})
+	plan, err := BuildPlan(ctx, client, m, st)
+	if err != nil {
+		t.Fatalf("BuildPlan failed: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("Expected no changes for unchanged secret, got:\n%s", plan.Render())
+	}
+
+	// Значение в проекте отличается от примененного
+	st.Set(state.Resource{
+		Kind:         string(KindMCPServer),
+		Key:          "weather",
+		ID:           "m1",
+		Secrets:      map[string]string{path: ref},
+		SecretHashes: map[string]string{path: secrets.Mask("verbose")},
+	})
+	plan, err = BuildPlan(ctx, client, m, st)
+	if err != nil {
+		t.Fatalf("BuildPlan failed: %v", err)
+	}
+	if !plan.HasChanges() {
+		t.Fatal("Expected changed secret to be reported")
+	}
+	rendered := plan.Render()
+	if strings.Contains(rendered, "debug") || !strings.Contains(rendered, "<secret sha256:") {
+		t.Errorf("Expected secret values to be masked, got:\n%s", rendered)
+	}
+
+	// Новая ссылка без примененного значения попадает в план как изменение
+	m.MCPServers[0].EnvironmentOptions.RawEnvs["LEVEL"] = "secret://env/AI_AGENTS_TEST_UNDEFINED"
+	plan, err = BuildPlan(ctx, client, m, st)
+	if err != nil {
+		t.Fatalf("BuildPlan failed: %v", err)
+	}
+	if !plan.HasChanges() || !strings.Contains(plan.Render(), "secret://env/AI_AGENTS_TEST_UNDEFINED") {
+		t.Errorf("Expected new secret reference to be reported, got:\n%s", plan.Render())
+	}
+}
+
+func TestSecretHashes(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("AI_AGENTS_TEST_TOKEN", "token")
+	if _, err := secrets.Resolve(ctx, "secret://env/AI_AGENTS_TEST_TOKEN"); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := state.Resource{
+		Secrets:      map[string]string{"a": "secret://exec/pass show a", "b": "secret://env/OLD"},
+		SecretHashes: map[string]string{"a": "<secret sha256:aaa>", "b": "<secret sha256:bbb>"},
+	}
+	hashes := secretHashes(ctx, map[string]string{
+		"a": "secret://exec/pass show a",
+		"b": "secret://env/NEW",
+		"c": "secret://env/AI_AGENTS_TEST_TOKEN",
+	}, previous)
+
+	// a - ссылка не изменилась, b - изменилась и не разрешалась, c - разрешена
+	expected := map[string]string{"a": "<secret sha256:aaa>", "c": secrets.Mask("token")}
+	if len(hashes) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, hashes)
+	}
+	for path, hash := range expected {
+		if hashes[path] != hash {
+			t.Errorf("Expected %s hash %s, got %s", path, hash, hashes[path])
+		}
+	}
+}
+
+func TestExport_SecretRefs(t *testing.T) {
+	client := newExportServer(t)
+
+	st, err := state.Load(t.TempDir() + "/state.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.Set(state.Resource{
+		Kind:    string(KindMCPServer),
+		Key:     "weather",
+		Name:    "weather",
+		ID:      "m1",
+		Secrets: map[string]string{"environmentOptions.rawEnvs.LEVEL": "secret://file/.secrets/level"},
+	})
+
+	exported, _, err := Export(context.Background(), client, []ResourceKind{KindMCPServer}, st)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if got := exported.MCPServers[0].EnvironmentOptions.RawEnvs["LEVEL"]; got != "secret://file/.secrets/level" {
+		t.Errorf("Expected secret reference instead of value, got %q", got)
+	}
+}
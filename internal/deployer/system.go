@@ -3,17 +3,19 @@ package deployer
 import (
 	"context"
 	"fmt"
-	"log"
+	"path/filepath"
+	"sort"
 
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
-	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/ui"
-	"github.com/cloud-ru/evo-ai-agents-cli/internal/validator"
 )
 
 // SystemDeployer обрабатывает развертывание систем агентов
 type SystemDeployer struct {
-	api *api.API
+	api      *api.API
+	parallel ParallelOptions
 }
 
 // NewSystemDeployer создает новый деплойер систем агентов
@@ -23,152 +25,333 @@ func NewSystemDeployer(api *api.API) *SystemDeployer {
 	}
 }
 
-// SystemConfig представляет конфигурацию системы агентов из YAML
-type SystemConfig struct {
-	Name        string                 `yaml:"name"`
-	Description string                 `yaml:"description"`
-	Agents      []string               `yaml:"agents"`
-	Options     map[string]interface{} `yaml:"options"`
+// SetParallel задает параллельное развертывание систем агентов
+func (d *SystemDeployer) SetParallel(opts ParallelOptions) {
+	d.parallel = opts
 }
 
 // ValidateSystems валидирует конфигурацию систем агентов
 func (d *SystemDeployer) ValidateSystems(configFile string) error {
-	// Обрабатываем includes
-	processedConfig, err := parser.ProcessYAMLFile(configFile)
-	if err != nil {
-		return fmt.Errorf("failed to process YAML file with includes: %w", err)
-	}
-
-	// Валидируем по схеме
-	schemaPath := "schemas/schema.json"
-	if err := validator.ValidateConfig(processedConfig, schemaPath); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
-	}
-
-	return nil
+	return validateConfigFile(configFile)
 }
 
 // DeploySystems развертывает системы агентов на основе YAML конфигурации
 func (d *SystemDeployer) DeploySystems(ctx context.Context, configFile string, dryRun bool) ([]DeployResult, error) {
 	results := []DeployResult{}
 
-	// Обрабатываем includes
-	processedConfig, err := parser.ProcessYAMLFile(configFile)
+	// Обрабатываем includes и строим модель конфигурации
+	m, err := loadManifest(configFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process YAML file with includes: %w", err)
+		return nil, err
 	}
 
-	// Извлекаем системы агентов из обработанной конфигурации
-	systemsConfig, ok := processedConfig["agent-systems"].([]interface{})
-	if !ok {
+	// Пути secret://file отсчитываются от директории конфигурации
+	ctx = secrets.WithBaseDir(ctx, filepath.Dir(configFile))
+
+	if len(m.AgentSystems) == 0 {
 		return nil, fmt.Errorf("invalid 'agent-systems' section in config file")
 	}
 
-	fmt.Println(ui.FormatInfo(fmt.Sprintf("Found %d agent systems to deploy.", len(systemsConfig))))
+	fmt.Println(ui.FormatInfo(fmt.Sprintf("Found %d agent systems to deploy.", len(m.AgentSystems))))
 
-	for i, systemConfigRaw := range systemsConfig {
-		systemConfigMap, ok := systemConfigRaw.(map[string]interface{})
-		if !ok {
-			results = append(results, DeployResult{
-				Success: false,
-				Message: fmt.Sprintf("Invalid agent system configuration format for system %d", i+1),
-			})
-			continue
+	registry := NewRegistry(d.api)
+
+	tasks := make([]task, len(m.AgentSystems))
+	for i := range m.AgentSystems {
+		system := &m.AgentSystems[i]
+		tasks[i] = task{kind: KindAgentSystem, name: system.Name, run: func(ctx context.Context) DeployResult {
+			return d.deploySystem(ctx, system, dryRun, registry)
+		}}
+	}
+
+	runTasks(ctx, tasks, d.parallel, func(i int, t task) {
+		if dryRun {
+			fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Dry run for agent system: %s", i+1, len(tasks), t.name)))
+		} else {
+			fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Deploying agent system: %s", i+1, len(tasks), t.name)))
 		}
+	}, func(i int, result DeployResult) {
+		printResult(i+1, len(tasks), result)
+		results = append(results, result)
+	})
+
+	return results, nil
+}
 
-		name, _ := systemConfigMap["name"].(string)
-		description, _ := systemConfigMap["description"].(string)
-		options, _ := systemConfigMap["options"].(map[string]interface{})
-		agents, _ := systemConfigMap["agents"].([]interface{})
+// deploySystem развертывает одну систему агентов: создает ее, если системы с таким
+// именем нет в проекте, или обновляет, если конфигурация отличается. Ссылки на
+// агентов разрешаются через реестр, состав системы синхронизируется с конфигурацией.
+func (d *SystemDeployer) deploySystem(ctx context.Context, system *manifest.AgentSystem, dryRun bool, registry *Registry) DeployResult {
+	name := system.Name
 
-		// Преобразуем agents в []string
-		var agentNames []string
-		for _, agent := range agents {
-			if agentName, ok := agent.(string); ok {
-				agentNames = append(agentNames, agentName)
-			}
+	fail := func(id, message string, err error) DeployResult {
+		return DeployResult{
+			Success: false,
+			Kind:    KindAgentSystem,
+			Name:    name,
+			ID:      id,
+			Message: message,
+			Error:   err,
 		}
+	}
 
-		if dryRun {
-			results = append(results, DeployResult{
-				Success: true,
-				Message: fmt.Sprintf("Would deploy agent system: %s", name),
-			})
-			fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Dry run for agent system: %s", i+1, len(systemsConfig), name)))
-			continue
+	instanceTypeID, err := registry.ResolveInstanceType(ctx, system.InstanceType)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve instance type for agent system %s: %v", name, err), err)
+	}
+
+	// Разрешаем ссылки на агентов
+	agents, err := resolveAgents(ctx, registry, system.Agents)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve agents for agent system %s: %v", name, err), err)
+	}
+
+	// Ищем систему в проекте по ID из файла состояния или по имени
+	existing, err := findAgentSystem(ctx, d.api, registry.stateID(KindAgentSystem, system.StateKey()), name)
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to look up agent system %s: %v", name, err), err)
+	}
+
+	var changes []Change
+	if existing != nil {
+		changes, err = diffSpecs(ctx, systemSpec(system, instanceTypeID, agents), systemSpecFromLive(existing), registry.secretCompare(KindAgentSystem, system.StateKey(), !dryRun))
+		if err != nil {
+			return fail(existing.ID, fmt.Sprintf("Failed to compare agent system %s: %v", name, err), err)
+		}
+	}
+
+	if dryRun {
+		action := ActionCreated
+		id := ""
+		if existing != nil {
+			id = existing.ID
+			action = ActionUnchanged
+			if len(changes) > 0 {
+				action = ActionUpdated
+			}
 		}
+		return DeployResult{
+			Success: true,
+			Kind:    KindAgentSystem,
+			Name:    name,
+			ID:      id,
+			Action:  action,
+			Message: fmt.Sprintf("Would deploy agent system: %s (%s)", name, action),
+		}
+	}
 
-		fmt.Println(ui.FormatInfo(fmt.Sprintf("[%d/%d] Deploying agent system: %s", i+1, len(systemsConfig), name)))
+	// Секреты разрешаются только для запроса к API
+	orchestratorOptions, err := secrets.ResolveMap(ctx, manifest.ToMap(system.OrchestratorOptions))
+	if err != nil {
+		return fail("", fmt.Sprintf("Failed to resolve secrets for agent system %s: %v", name, err), err)
+	}
 
+	// Запрос для API собирается из словарей конфигурации после разрешения секретов
+	updateReq := &api.UpdateAgentSystemRequest{
+		Name:           name,
+		Description:    system.Description,
+		InstanceTypeID: instanceTypeID,
+	}
+	if err := convertModel(map[string]interface{}{
+		"orchestratorOptions": orchestratorOptions,
+		"options":             manifest.ToMap(system.Options),
+		"integrationOptions":  system.IntegrationOptions,
+	}, updateReq); err != nil {
+		return fail("", fmt.Sprintf("Invalid configuration of agent system %s: %v", name, err), err)
+	}
+
+	if existing == nil {
 		// Создаем запрос для создания системы агентов
-		createReq := &api.AgentSystemCreateRequest{
-			Name:        name,
-			Description: description,
-			Options:     options,
+		createReq := &api.CreateAgentSystemRequest{
+			Name:                name,
+			Description:         system.Description,
+			InstanceTypeID:      instanceTypeID,
+			OrchestratorOptions: updateReq.OrchestratorOptions,
+			Options:             updateReq.Options,
+			IntegrationOptions:  updateReq.IntegrationOptions,
 		}
 
 		// Создаем систему агентов
-		system, err := d.api.AgentSystems.Create(ctx, createReq)
+		created, err := d.api.AgentSystems.CreateAgentSystem(ctx, createReq)
 		if err != nil {
-			results = append(results, DeployResult{
-				Success: false,
-				Message: fmt.Sprintf("Failed to create agent system %s: %v", name, err),
-			})
-			fmt.Println(ui.FormatError(fmt.Sprintf("[%d/%d] Failed to deploy agent system %s: %v", i+1, len(systemsConfig), name, err)))
-			continue
+			return fail("", fmt.Sprintf("Failed to create agent system %s: %v", name, err), err)
 		}
 
 		// Если есть агенты, привязываем их
-		if len(agentNames) > 0 {
-			err := d.attachAgents(ctx, system.ID, agentNames)
-			if err != nil {
-				results = append(results, DeployResult{
-					Success: false,
-					Message: fmt.Sprintf("Agent system %s created but failed to attach agents: %v", name, err),
-				})
-				fmt.Println(ui.FormatError(fmt.Sprintf("[%d/%d] Agent system %s created but failed to attach agents: %v", i+1, len(systemsConfig), name, err)))
-				continue
+		if err := d.syncAgents(ctx, created.AgentSystemID, nil, agents); err != nil {
+			result := fail(created.AgentSystemID, fmt.Sprintf("Agent system %s created but failed to attach agents: %v", name, err), err)
+			result.Action = ActionCreated
+			return result
+		}
+
+		// Масштабирование агентов в системе задается только обновлением
+		if hasAgentScaling(agents) {
+			if _, err := d.api.AgentSystems.UpdateAgentSystem(ctx, created.AgentSystemID, &api.UpdateAgentSystemRequest{Agents: agents}); err != nil {
+				result := fail(created.AgentSystemID, fmt.Sprintf("Agent system %s created but failed to set agent scaling: %v", name, err), err)
+				result.Action = ActionCreated
+				return result
 			}
 		}
 
-		results = append(results, DeployResult{
+		return DeployResult{
 			Success: true,
-			Message: fmt.Sprintf("Successfully deployed agent system %s (ID: %s)", name, system.ID[:8]),
-		})
-		fmt.Println(ui.FormatSuccess(fmt.Sprintf("[%d/%d] Successfully deployed agent system %s (ID: %s)", i+1, len(systemsConfig), name, system.ID[:8])))
+			Kind:    KindAgentSystem,
+			Name:    name,
+			ID:      created.AgentSystemID,
+			Action:  ActionCreated,
+			Message: fmt.Sprintf("Successfully created agent system %s (ID: %s)", name, shortID(created.AgentSystemID)),
+		}
 	}
 
-	return results, nil
-}
+	if len(changes) == 0 {
+		return DeployResult{
+			Success: true,
+			Kind:    KindAgentSystem,
+			Name:    name,
+			ID:      existing.ID,
+			Action:  ActionUnchanged,
+			Message: fmt.Sprintf("Agent system %s is up to date (ID: %s)", name, shortID(existing.ID)),
+		}
+	}
 
-// attachAgents привязывает агентов к системе агентов
-func (d *SystemDeployer) attachAgents(ctx context.Context, systemID string, agentNames []string) error {
-	// Получаем список всех агентов
-	agents, err := d.api.Agents.List(ctx, 1000, 0) // Получаем много агентов
-	if err != nil {
-		return fmt.Errorf("failed to list agents: %w", err)
+	if _, err := d.api.AgentSystems.UpdateAgentSystem(ctx, existing.ID, updateReq); err != nil {
+		return fail(existing.ID, fmt.Sprintf("Failed to update agent system %s: %v", name, err), err)
 	}
 
-	// Создаем карту имен агентов к их ID
-	agentMap := make(map[string]string)
-	for _, agent := range agents.Data {
-		agentMap[agent.Name] = agent.ID
+	if err := d.syncAgents(ctx, existing.ID, existing.Agents, agents); err != nil {
+		result := fail(existing.ID, fmt.Sprintf("Agent system %s updated but failed to sync agents: %v", name, err), err)
+		result.Action = ActionUpdated
+		result.previous = existing
+		return result
 	}
 
-	// Находим ID агентов по именам
-	var agentIDs []string
-	for _, agentName := range agentNames {
-		if agentID, exists := agentMap[agentName]; exists {
-			agentIDs = append(agentIDs, agentID)
-		} else {
-			return fmt.Errorf("agent '%s' not found", agentName)
+	if hasAgentScaling(agents) {
+		if _, err := d.api.AgentSystems.UpdateAgentSystem(ctx, existing.ID, &api.UpdateAgentSystemRequest{Agents: agents}); err != nil {
+			result := fail(existing.ID, fmt.Sprintf("Agent system %s updated but failed to set agent scaling: %v", name, err), err)
+			result.Action = ActionUpdated
+			result.previous = existing
+			return result
 		}
 	}
 
-	// Привязываем агентов к системе
-	// Здесь нужно будет добавить метод в API для привязки агентов
-	// Пока что просто логируем
-	log.Printf("Would attach agents %v to system %s", agentIDs, systemID)
+	return DeployResult{
+		Success: true,
+		Kind:    KindAgentSystem,
+		Name:    name,
+		ID:      existing.ID,
+		Action:  ActionUpdated,
+		Message: fmt.Sprintf("Successfully updated agent system %s (ID: %s, %d changes)", name, shortID(existing.ID), len(changes)),
+
+		previous: existing,
+	}
+}
+
+// syncAgents приводит состав системы агентов к желаемому: добавляет недостающих
+// агентов и удаляет лишних
+func (d *SystemDeployer) syncAgents(ctx context.Context, systemID string, current, desired []api.AgentSystemAgent) error {
+	currentIDs := make(map[string]bool, len(current))
+	for _, agent := range current {
+		currentIDs[agent.AgentID] = true
+	}
+	desiredIDs := make(map[string]bool, len(desired))
+	for _, agent := range desired {
+		desiredIDs[agent.AgentID] = true
+	}
+
+	for _, agent := range desired {
+		if currentIDs[agent.AgentID] {
+			continue
+		}
+		if err := d.api.AgentSystems.AddAgent(ctx, systemID, agent.AgentID); err != nil {
+			return fmt.Errorf("failed to add agent '%s': %w", agent.Name, err)
+		}
+	}
+
+	for _, agent := range current {
+		if desiredIDs[agent.AgentID] {
+			continue
+		}
+		if err := d.api.AgentSystems.RemoveAgent(ctx, systemID, agent.AgentID); err != nil {
+			return fmt.Errorf("failed to remove agent '%s': %w", agent.Name, err)
+		}
+	}
 
 	return nil
 }
+
+// resolveAgents разрешает ссылки системы на агентов в их ID
+func resolveAgents(ctx context.Context, registry *Registry, refs []manifest.SystemAgent) ([]api.AgentSystemAgent, error) {
+	agents := make([]api.AgentSystemAgent, 0, len(refs))
+	for _, ref := range refs {
+		id, err := registry.Resolve(ctx, KindAgent, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		agent := api.AgentSystemAgent{AgentID: id, Name: ref.Name}
+		if err := convertModel(ref.Scaling, &agent.Scaling); err != nil {
+			return nil, fmt.Errorf("invalid scaling of agent '%s': %w", ref.Name, err)
+		}
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
+// hasAgentScaling проверяет, задано ли масштабирование хотя бы для одного агента системы
+func hasAgentScaling(agents []api.AgentSystemAgent) bool {
+	for _, agent := range agents {
+		if len(manifest.ToMap(agent.Scaling)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// systemSpec возвращает желаемую спецификацию системы агентов для сравнения с проектом.
+// Агенты сравниваются по ID без учета порядка, а если ID еще неизвестен - по имени.
+// Масштабирование агентов в системе сравнивается по тем же ключам.
+func systemSpec(system *manifest.AgentSystem, instanceTypeID string, agents []api.AgentSystemAgent) map[string]interface{} {
+	ids := make([]string, 0, len(agents))
+	scaling := make(map[string]interface{}, len(agents))
+	for _, agent := range agents {
+		id := agent.AgentID
+		if id == "" {
+			id = agent.Name
+		}
+		ids = append(ids, id)
+		scaling[id] = manifest.ToMap(agent.Scaling)
+	}
+	sort.Strings(ids)
+
+	return map[string]interface{}{
+		"name":                system.Name,
+		"description":         system.Description,
+		"instanceTypeId":      instanceTypeID,
+		"orchestratorOptions": manifest.ToMap(system.OrchestratorOptions),
+		"options":             manifest.ToMap(system.Options),
+		"integrationOptions":  system.IntegrationOptions,
+		"agents":              ids,
+		"agentScaling":        scaling,
+	}
+}
+
+// systemSpecFromLive возвращает спецификацию системы агентов из проекта в том же виде, что и systemSpec
+func systemSpecFromLive(system *api.AgentSystem) map[string]interface{} {
+	ids := make([]string, 0, len(system.Agents))
+	scaling := make(map[string]interface{}, len(system.Agents))
+	for _, agent := range system.Agents {
+		ids = append(ids, agent.AgentID)
+		scaling[agent.AgentID] = manifest.ToMap(agent.Scaling)
+	}
+	sort.Strings(ids)
+
+	return map[string]interface{}{
+		"name":                system.Name,
+		"description":         system.Description,
+		"instanceTypeId":      system.InstanceType.ID,
+		"orchestratorOptions": system.OrchestratorOptions,
+		"options":             system.Options,
+		"integrationOptions":  system.IntegrationOptions,
+		"agents":              ids,
+		"agentScaling":        scaling,
+	}
+}
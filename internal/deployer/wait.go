@@ -0,0 +1,433 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
+	"golang.org/x/term"
+)
+
+// Значения по умолчанию для ожидания запуска ресурсов
+const (
+	DefaultWaitTimeout     = 10 * time.Minute
+	defaultWaitInterval    = 2 * time.Second
+	defaultWaitMaxInterval = 30 * time.Second
+)
+
+// readyStatuses - статусы, в которых ресурс считается запущенным
+var readyStatuses = map[ResourceKind][]string{
+	KindMCPServer: {
+		string(api.MCPServerStatusRunning), string(api.MCPServerStatusAvailable), string(api.MCPServerStatusCooled),
+	},
+	KindAgent: {
+		string(api.AgentStatusRunning), string(api.AgentStatusCooled),
+	},
+	KindAgentSystem: {
+		string(api.AgentSystemStatusRunning), string(api.AgentSystemStatusCooled),
+	},
+}
+
+// failedStatuses - статусы, из которых ресурс не перейдет в запущенное состояние сам
+var failedStatuses = map[ResourceKind][]string{
+	KindMCPServer: {
+		string(api.MCPServerStatusFailed), string(api.MCPServerStatusImageUnavailable),
+		string(api.MCPServerStatusSuspended), string(api.MCPServerStatusDeleted),
+	},
+	KindAgent: {
+		string(api.AgentStatusFailed), string(api.AgentStatusLLMUnavailable), string(api.AgentStatusToolUnavailable),
+		string(api.AgentStatusImageUnavailable), string(api.AgentStatusSuspended), string(api.AgentStatusDeleted),
+	},
+	KindAgentSystem: {
+		string(api.AgentSystemStatusFailed), string(api.AgentSystemStatusAgentUnavailable),
+		string(api.AgentSystemStatusSuspended), string(api.AgentSystemStatusDeleted),
+	},
+}
+
+// deletedStatuses - статусы удаленного ресурса
+var deletedStatuses = map[ResourceKind]string{
+	KindMCPServer:   string(api.MCPServerStatusDeleted),
+	KindAgent:       string(api.AgentStatusDeleted),
+	KindAgentSystem: string(api.AgentSystemStatusDeleted),
+}
+
+// WaitState - состояние ожидания ресурса
+type WaitState string
+
+const (
+	WaitPending WaitState = "pending"
+	WaitReady   WaitState = "ready"
+	WaitFailed  WaitState = "failed"
+	WaitTimeout WaitState = "timeout"
+)
+
+// WaitOptions содержит параметры ожидания запуска ресурсов
+type WaitOptions struct {
+	// Timeout - максимальное время ожидания одного ресурса
+	Timeout time.Duration
+	// Interval - начальный интервал опроса, удваивается до MaxInterval
+	Interval    time.Duration
+	MaxInterval time.Duration
+	// Out - куда выводить таблицу статусов (по умолчанию os.Stdout)
+	Out io.Writer
+}
+
+// WaitResult представляет итог ожидания одного ресурса
+type WaitResult struct {
+	Kind    ResourceKind
+	Name    string
+	ID      string
+	State   WaitState
+	Status  string
+	Reason  string
+	Elapsed time.Duration
+	Error   error
+}
+
+// resourceStatus - текущий статус ресурса и причина, сообщенная API
+type resourceStatus struct {
+	Status string
+	Reason string
+}
+
+// statusFunc получает текущий статус ресурса
+type statusFunc func(ctx context.Context, kind ResourceKind, id string) (resourceStatus, error)
+
+// waitCondition определяет состояние ожидания по ответу API. Ошибка, которую
+// возвращает условие, считается временной: опрос продолжается до таймаута.
+type waitCondition func(kind ResourceKind, status *resourceStatus, err error) (WaitState, error)
+
+// Waiter опрашивает API, пока развернутые ресурсы не запустятся или не упадут
+type Waiter struct {
+	status statusFunc
+}
+
+// NewWaiter создает новый объект ожидания запуска ресурсов
+func NewWaiter(client *api.API) *Waiter {
+	return &Waiter{
+		status: func(ctx context.Context, kind ResourceKind, id string) (resourceStatus, error) {
+			return fetchStatus(ctx, client, kind, id)
+		},
+	}
+}
+
+// Wait ожидает запуска всех успешно развернутых ресурсов из results.
+// Ресурсы опрашиваются параллельно с экспоненциально растущим интервалом;
+// таймаут отсчитывается для каждого ресурса отдельно.
+func (w *Waiter) Wait(ctx context.Context, results []DeployResult, opts WaitOptions) []WaitResult {
+	return w.wait(ctx, results, opts, untilRunning)
+}
+
+// WaitDeleted ожидает, пока удаленные ресурсы из results исчезнут из проекта
+func (w *Waiter) WaitDeleted(ctx context.Context, results []DeployResult, opts WaitOptions) []WaitResult {
+	return w.wait(ctx, results, opts, untilDeleted)
+}
+
+func (w *Waiter) wait(ctx context.Context, results []DeployResult, opts WaitOptions, condition waitCondition) []WaitResult {
+	opts = withWaitDefaults(opts)
+
+	var targets []WaitResult
+	for _, result := range results {
+		if !result.Success || result.ID == "" {
+			continue
+		}
+		targets = append(targets, WaitResult{Kind: result.Kind, Name: result.Name, ID: result.ID, State: WaitPending})
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	board := newStatusBoard(opts.Out, targets)
+	board.Render()
+
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			targets[i] = w.waitOne(ctx, targets[i], opts, condition, func(update WaitResult) {
+				board.Update(i, update)
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	board.Finish()
+	return targets
+}
+
+// waitOne опрашивает один ресурс до запуска, ошибки или истечения таймаута
+func (w *Waiter) waitOne(ctx context.Context, target WaitResult, opts WaitOptions, condition waitCondition, update func(WaitResult)) WaitResult {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	interval := opts.Interval
+	var lastErr error
+
+	for {
+		status, err := w.status(ctx, target.Kind, target.ID)
+		target.Elapsed = time.Since(start)
+
+		state, err := condition(target.Kind, &status, err)
+		if err != nil {
+			// Ошибки API считаем временными и продолжаем опрос до таймаута
+			log.Debug("Failed to get resource status", "kind", target.Kind, "name", target.Name, "error", err)
+			lastErr = err
+		} else {
+			lastErr = nil
+			target.Status = status.Status
+			target.Reason = status.Reason
+			target.State = state
+		}
+		update(target)
+
+		if target.State != WaitPending {
+			return target
+		}
+
+		select {
+		case <-ctx.Done():
+			target.Elapsed = time.Since(start)
+			target.State = WaitTimeout
+			target.Error = lastErr
+			if target.Error == nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				target.Error = ctx.Err()
+			}
+			update(target)
+			return target
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+// untilRunning - условие ожидания запуска ресурса
+func untilRunning(kind ResourceKind, status *resourceStatus, err error) (WaitState, error) {
+	if err != nil {
+		return WaitPending, err
+	}
+	return classifyStatus(kind, status.Status), nil
+}
+
+// untilDeleted - условие ожидания удаления ресурса: ресурс удален, когда API
+// отвечает 404 или возвращает статус удаления
+func untilDeleted(kind ResourceKind, status *resourceStatus, err error) (WaitState, error) {
+	if api.IsNotFound(err) {
+		status.Status = "DELETED"
+		return WaitReady, nil
+	}
+	if err != nil {
+		return WaitPending, err
+	}
+	if status.Status == deletedStatuses[kind] {
+		return WaitReady, nil
+	}
+	return WaitPending, nil
+}
+
+// classifyStatus определяет состояние ожидания по статусу ресурса
+func classifyStatus(kind ResourceKind, status string) WaitState {
+	for _, ready := range readyStatuses[kind] {
+		if status == ready {
+			return WaitReady
+		}
+	}
+	for _, failed := range failedStatuses[kind] {
+		if status == failed {
+			return WaitFailed
+		}
+	}
+	return WaitPending
+}
+
+// fetchStatus получает статус ресурса через API
+func fetchStatus(ctx context.Context, client *api.API, kind ResourceKind, id string) (resourceStatus, error) {
+	switch kind {
+	case KindMCPServer:
+		server, err := client.MCPServers.Get(ctx, id)
+		if err != nil {
+			return resourceStatus{}, err
+		}
+		return resourceStatus{Status: string(server.Status), Reason: server.StatusReason.Message}, nil
+	case KindAgent:
+		agent, err := client.Agents.Get(ctx, id)
+		if err != nil {
+			return resourceStatus{}, err
+		}
+		return resourceStatus{Status: string(agent.Status), Reason: agent.StatusReason.Message}, nil
+	case KindAgentSystem:
+		system, err := client.AgentSystems.Get(ctx, id)
+		if err != nil {
+			return resourceStatus{}, err
+		}
+		return resourceStatus{Status: string(system.Status), Reason: system.StatusReason.Message}, nil
+	default:
+		return resourceStatus{}, fmt.Errorf("unknown resource kind: %s", kind)
+	}
+}
+
+func withWaitDefaults(opts WaitOptions) WaitOptions {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultWaitTimeout
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultWaitInterval
+	}
+	if opts.MaxInterval < opts.Interval {
+		opts.MaxInterval = defaultWaitMaxInterval
+		if opts.MaxInterval < opts.Interval {
+			opts.MaxInterval = opts.Interval
+		}
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	return opts
+}
+
+// HasWaitFailures проверяет, есть ли среди результатов ожидания незапустившиеся ресурсы
+func HasWaitFailures(results []WaitResult) bool {
+	for _, result := range results {
+		if result.State != WaitReady {
+			return true
+		}
+	}
+	return false
+}
+
+// ShowWaitResults выводит причины, по которым ресурсы не запустились
+func ShowWaitResults(w io.Writer, results []WaitResult) {
+	ready := 0
+	for _, result := range results {
+		switch result.State {
+		case WaitReady:
+			ready++
+		case WaitFailed:
+			fmt.Fprintln(w, waitFailedStyle.Render(fmt.Sprintf("✗ %s %s failed with status %s", result.Kind, result.Name, result.Status)))
+			if result.Reason != "" {
+				fmt.Fprintf(w, "  Reason: %s\n", result.Reason)
+			}
+		case WaitTimeout:
+			fmt.Fprintln(w, waitFailedStyle.Render(fmt.Sprintf("✗ %s %s did not become ready within the timeout (last status: %s)", result.Kind, result.Name, statusOrUnknown(result.Status))))
+			if result.Reason != "" {
+				fmt.Fprintf(w, "  Reason: %s\n", result.Reason)
+			}
+			if result.Error != nil {
+				fmt.Fprintf(w, "  Error: %v\n", result.Error)
+			}
+		}
+	}
+	fmt.Fprintf(w, "\n⏱️  Ready: %d/%d\n", ready, len(results))
+}
+
+var (
+	waitPendingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	waitReadyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	waitFailedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+// statusBoard выводит таблицу статусов ожидаемых ресурсов. В терминале таблица
+// перерисовывается на месте, иначе (например, в CI) выводится строка при
+// каждом изменении статуса.
+type statusBoard struct {
+	mu       sync.Mutex
+	out      io.Writer
+	live     bool
+	rows     []WaitResult
+	rendered int
+}
+
+func newStatusBoard(out io.Writer, rows []WaitResult) *statusBoard {
+	board := &statusBoard{out: out, rows: append([]WaitResult(nil), rows...)}
+	if f, ok := out.(*os.File); ok {
+		board.live = term.IsTerminal(int(f.Fd()))
+	}
+	return board
+}
+
+// Update обновляет строку ресурса и перерисовывает таблицу
+func (b *statusBoard) Update(i int, row WaitResult) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	previous := b.rows[i]
+	b.rows[i] = row
+
+	if b.live {
+		b.render()
+		return
+	}
+	if previous.Status != row.Status || previous.State != row.State {
+		fmt.Fprintln(b.out, b.line(row))
+	}
+}
+
+// Render выводит таблицу целиком
+func (b *statusBoard) Render() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.live {
+		b.render()
+		return
+	}
+	for _, row := range b.rows {
+		fmt.Fprintln(b.out, b.line(row))
+	}
+}
+
+// Finish завершает вывод таблицы
+func (b *statusBoard) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fmt.Fprintln(b.out)
+}
+
+func (b *statusBoard) render() {
+	var sb strings.Builder
+	if b.rendered > 0 {
+		// Возвращаем курсор к началу таблицы
+		fmt.Fprintf(&sb, "\033[%dA", b.rendered)
+	}
+	for _, row := range b.rows {
+		sb.WriteString("\033[2K" + b.line(row) + "\n")
+	}
+	b.rendered = len(b.rows)
+	fmt.Fprint(b.out, sb.String())
+}
+
+func (b *statusBoard) line(row WaitResult) string {
+	var icon string
+	var style lipgloss.Style
+	switch row.State {
+	case WaitReady:
+		icon, style = "✓", waitReadyStyle
+	case WaitFailed, WaitTimeout:
+		icon, style = "✗", waitFailedStyle
+	default:
+		icon, style = "…", waitPendingStyle
+	}
+
+	line := fmt.Sprintf("%s %-12s %-30s %-40s %s", icon, row.Kind, row.Name, statusOrUnknown(row.Status), row.Elapsed.Round(time.Second))
+	return style.Render(line)
+}
+
+func statusOrUnknown(status string) string {
+	if status == "" {
+		return "UNKNOWN"
+	}
+	return status
+}
@@ -0,0 +1,91 @@
+package deployer
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		kind     ResourceKind
+		status   string
+		expected WaitState
+	}{
+		{KindAgent, "AGENT_STATUS_RUNNING", WaitReady},
+		{KindAgent, "AGENT_STATUS_PULLING", WaitPending},
+		{KindAgent, "AGENT_STATUS_LLM_UNAVAILABLE", WaitFailed},
+		{KindMCPServer, "MCP_SERVER_STATUS_AVAILABLE", WaitReady},
+		{KindMCPServer, "MCP_SERVER_STATUS_IMAGE_UNAVAILABLE", WaitFailed},
+		{KindAgentSystem, "AGENT_SYSTEM_STATUS_RESOURCE_ALLOCATION", WaitPending},
+		{KindAgentSystem, "AGENT_SYSTEM_STATUS_FAILED", WaitFailed},
+	}
+
+	for _, tt := range tests {
+		if got := classifyStatus(tt.kind, tt.status); got != tt.expected {
+			t.Errorf("classifyStatus(%s, %s) = %s, expected %s", tt.kind, tt.status, got, tt.expected)
+		}
+	}
+}
+
+func TestWaiter_Wait(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+
+	waiter := &Waiter{
+		status: func(ctx context.Context, kind ResourceKind, id string) (resourceStatus, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[id]++
+
+			switch id {
+			case "agent-id":
+				if calls[id] < 3 {
+					return resourceStatus{Status: "AGENT_STATUS_PULLING"}, nil
+				}
+				return resourceStatus{Status: "AGENT_STATUS_RUNNING"}, nil
+			case "mcp-id":
+				return resourceStatus{Status: "MCP_SERVER_STATUS_FAILED", Reason: "image not found"}, nil
+			default:
+				return resourceStatus{Status: "AGENT_SYSTEM_STATUS_PULLING"}, nil
+			}
+		},
+	}
+
+	results := []DeployResult{
+		{Success: true, Kind: KindAgent, Name: "agent", ID: "agent-id"},
+		{Success: true, Kind: KindMCPServer, Name: "mcp", ID: "mcp-id"},
+		{Success: true, Kind: KindAgentSystem, Name: "system", ID: "system-id"},
+		{Success: false, Kind: KindAgent, Name: "broken"},
+	}
+
+	var out bytes.Buffer
+	waitResults := waiter.Wait(context.Background(), results, WaitOptions{
+		Timeout:     50 * time.Millisecond,
+		Interval:    time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+		Out:         &out,
+	})
+
+	if len(waitResults) != 3 {
+		t.Fatalf("Expected 3 wait results, got %d", len(waitResults))
+	}
+
+	expected := map[string]WaitState{"agent": WaitReady, "mcp": WaitFailed, "system": WaitTimeout}
+	for _, result := range waitResults {
+		if result.State != expected[result.Name] {
+			t.Errorf("Expected %s to be %s, got %s", result.Name, expected[result.Name], result.State)
+		}
+	}
+	if waitResults[1].Reason != "image not found" {
+		t.Errorf("Expected status reason to be kept, got %q", waitResults[1].Reason)
+	}
+	if !HasWaitFailures(waitResults) {
+		t.Error("Expected wait failures")
+	}
+	if calls["agent-id"] != 3 {
+		t.Errorf("Expected agent to be polled 3 times, got %d", calls["agent-id"])
+	}
+}
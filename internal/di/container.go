@@ -59,7 +59,13 @@ func NewContainer() *Container {
 		}
 
 		baseURL := "https://" + cfg.IntegrationApiGrpcAddr
-		return api.NewAPI(baseURL, cfg.ProjectID, authService), nil
+		apiClient := api.NewAPI(baseURL, cfg.ProjectID, authService)
+
+		retryPolicy := api.DefaultRetryPolicy()
+		retryPolicy.MaxAttempts = cfg.APIMaxAttempts
+		apiClient.Client.SetRetryPolicy(retryPolicy)
+
+		return apiClient, nil
 	})
 
 	return &Container{
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"runtime"
-	"strings"
 
 	"github.com/charmbracelet/log"
 )
@@ -304,24 +303,3 @@ func (h *Handler) GetErrorCode(err error) string {
 func (h *Handler) GetErrorSuggestions(err error) []string {
 	return GetErrorSuggestions(err)
 }
-
-// ShouldRetry определяет, следует ли повторить операцию
-func (h *Handler) ShouldRetry(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
-		// Повторяем для сетевых ошибок и временных ошибок API
-		return appErr.Type == ErrorTypeNetwork || 
-			   appErr.Type == ErrorTypeAPI && 
-			   strings.Contains(strings.ToLower(appErr.Message), "timeout")
-	}
-	return false
-}
-
-// GetRetryDelay возвращает задержку перед повторной попыткой
-func (h *Handler) GetRetryDelay(err error, attempt int) int {
-	// Экспоненциальная задержка: 1s, 2s, 4s, 8s, 16s
-	delay := 1 << uint(attempt-1)
-	if delay > 60 {
-		delay = 60 // Максимум 60 секунд
-	}
-	return delay
-}
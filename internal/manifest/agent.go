@@ -0,0 +1,70 @@
+package manifest
+
+import "encoding/json"
+
+// Agent описывает агента в конфигурации (definitions/agent)
+type Agent struct {
+	Name string `json:"name" yaml:"name"`
+	// Key - стабильный ключ ресурса в файле состояния, по умолчанию совпадает
+	// с именем. Позволяет переименовать ресурс без пересоздания.
+	Key         string `json:"key,omitempty" yaml:"key,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// InstanceType - имя или UUID типа вычислительной конфигурации
+	InstanceType       string             `json:"instanceTypeId,omitempty" yaml:"instanceTypeId,omitempty"`
+	ImageSource        *AgentImageSource  `json:"imageSource,omitempty" yaml:"imageSource,omitempty"`
+	ExportedPorts      []int              `json:"exportedPorts,omitempty" yaml:"exportedPorts,omitempty"`
+	Options            *AgentOptions      `json:"options,omitempty" yaml:"options,omitempty"`
+	IntegrationOptions IntegrationOptions `json:"integrationOptions,omitempty" yaml:"integrationOptions,omitempty"`
+	// MCPServers - имена или UUID MCP серверов, которые использует агент
+	MCPServers []string `json:"mcpServers,omitempty" yaml:"mcpServers,omitempty"`
+}
+
+// StateKey возвращает ключ ресурса в файле состояния
+func (a *Agent) StateKey() string {
+	if a.Key != "" {
+		return a.Key
+	}
+	return a.Name
+}
+
+// AgentImageSource описывает источник образа агента (definitions/agentImageSource)
+type AgentImageSource struct {
+	ARImageURI         string `json:"arImageUri,omitempty" yaml:"arImageUri,omitempty"`
+	AgentID            string `json:"agentId,omitempty" yaml:"agentId,omitempty"`
+	MarketplaceAgentID string `json:"marketplaceAgentId,omitempty" yaml:"marketplaceAgentId,omitempty"`
+}
+
+// AgentOptions описывает опции агента (definitions/agentOptions).
+// Схема допускает дополнительные поля, они сохраняются в Extra.
+type AgentOptions struct {
+	SystemPrompt string                 `json:"systemPrompt,omitempty" yaml:"systemPrompt,omitempty"`
+	LLM          *LLMOptions            `json:"llm,omitempty" yaml:"llm,omitempty"`
+	Env          *EnvironmentOptions    `json:"env,omitempty" yaml:"env,omitempty"`
+	Scaling      *Scaling               `json:"scaling,omitempty" yaml:"scaling,omitempty"`
+	Extra        map[string]interface{} `json:"-" yaml:",inline"`
+}
+
+// agentOptionsFields - псевдоним без методов для стандартной (де)сериализации
+type agentOptionsFields AgentOptions
+
+// MarshalJSON сериализует опции вместе с дополнительными полями
+func (o AgentOptions) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(agentOptionsFields(o), o.Extra)
+}
+
+// UnmarshalJSON разбирает опции, сохраняя неизвестные поля в Extra
+func (o *AgentOptions) UnmarshalJSON(data []byte) error {
+	var fields agentOptionsFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	extra, err := unmarshalExtra(data, "systemPrompt", "llm", "env", "scaling")
+	if err != nil {
+		return err
+	}
+
+	*o = AgentOptions(fields)
+	o.Extra = extra
+	return nil
+}
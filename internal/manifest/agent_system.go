@@ -0,0 +1,75 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AgentSystem описывает систему агентов в конфигурации (definitions/agentSystem)
+type AgentSystem struct {
+	Name string `json:"name" yaml:"name"`
+	// Key - стабильный ключ ресурса в файле состояния, по умолчанию совпадает
+	// с именем. Позволяет переименовать ресурс без пересоздания.
+	Key         string `json:"key,omitempty" yaml:"key,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// InstanceType - имя или UUID типа вычислительной конфигурации
+	InstanceType        string               `json:"instanceTypeId,omitempty" yaml:"instanceTypeId,omitempty"`
+	Agents              []SystemAgent        `json:"agents,omitempty" yaml:"agents,omitempty"`
+	OrchestratorOptions *OrchestratorOptions `json:"orchestratorOptions,omitempty" yaml:"orchestratorOptions,omitempty"`
+	Options             *SystemOptions       `json:"options,omitempty" yaml:"options,omitempty"`
+	IntegrationOptions  IntegrationOptions   `json:"integrationOptions,omitempty" yaml:"integrationOptions,omitempty"`
+}
+
+// StateKey возвращает ключ ресурса в файле состояния
+func (s *AgentSystem) StateKey() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	return s.Name
+}
+
+// SystemAgent описывает агента в составе системы. В YAML может быть задан
+// строкой с именем агента или объектом с именем и настройками масштабирования.
+type SystemAgent struct {
+	// Name - имя или UUID агента
+	Name    string   `json:"name" yaml:"name"`
+	Scaling *Scaling `json:"scaling,omitempty" yaml:"scaling,omitempty"`
+}
+
+// systemAgentFields - псевдоним без методов для стандартной десериализации
+type systemAgentFields SystemAgent
+
+// UnmarshalJSON поддерживает краткую запись агента строкой
+func (a *SystemAgent) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*a = SystemAgent{Name: name}
+		return nil
+	}
+
+	var fields systemAgentFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("agent must be a name or an object with name: %w", err)
+	}
+	*a = SystemAgent(fields)
+	return nil
+}
+
+// OrchestratorOptions описывает опции оркестратора (definitions/orchestratorOptions)
+type OrchestratorOptions struct {
+	SystemPrompt string              `json:"systemPrompt,omitempty" yaml:"systemPrompt,omitempty"`
+	LLM          *LLMOptions         `json:"llm,omitempty" yaml:"llm,omitempty"`
+	Env          *EnvironmentOptions `json:"env,omitempty" yaml:"env,omitempty"`
+	Scaling      *Scaling            `json:"scaling,omitempty" yaml:"scaling,omitempty"`
+}
+
+// SystemOptions описывает опции системы (definitions/systemOptions)
+type SystemOptions struct {
+	Observability  *Toggle `json:"observability,omitempty" yaml:"observability,omitempty"`
+	ContextStorage *Toggle `json:"contextStorage,omitempty" yaml:"contextStorage,omitempty"`
+}
+
+// Toggle описывает включаемую опцию
+type Toggle struct {
+	IsEnabled bool `json:"isEnabled" yaml:"isEnabled"`
+}
@@ -0,0 +1,49 @@
+package manifest
+
+// LLMOptions описывает настройки LLM (definitions/llmOptions)
+type LLMOptions struct {
+	MLInference      *MLInference      `json:"mlInference,omitempty" yaml:"mlInference,omitempty"`
+	FoundationModels *FoundationModels `json:"foundationModels,omitempty" yaml:"foundationModels,omitempty"`
+}
+
+// MLInference описывает модель, развернутую через ML инференс
+type MLInference struct {
+	ModelRunID string `json:"modelRunId,omitempty" yaml:"modelRunId,omitempty"`
+}
+
+// FoundationModels описывает базовую модель
+type FoundationModels struct {
+	ModelName string `json:"modelName,omitempty" yaml:"modelName,omitempty"`
+}
+
+// EnvironmentOptions описывает переменные окружения (definitions/environmentOptions)
+type EnvironmentOptions struct {
+	RawEnvs    map[string]string    `json:"rawEnvs,omitempty" yaml:"rawEnvs,omitempty"`
+	SecretEnvs map[string]SecretEnv `json:"secretEnvs,omitempty" yaml:"secretEnvs,omitempty"`
+}
+
+// SecretEnv описывает переменную окружения, значение которой берется из секрета
+type SecretEnv struct {
+	ID      string `json:"id" yaml:"id"`
+	Version int    `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// Scaling описывает настройки масштабирования (definitions/scaling)
+type Scaling struct {
+	MinScale           *int      `json:"minScale,omitempty" yaml:"minScale,omitempty"`
+	MaxScale           *int      `json:"maxScale,omitempty" yaml:"maxScale,omitempty"`
+	IsScaleUpAllSystem bool      `json:"isScaleUpAllSystem,omitempty" yaml:"isScaleUpAllSystem,omitempty"`
+	IsKeepAlive        bool      `json:"isKeepAlive,omitempty" yaml:"isKeepAlive,omitempty"`
+	KeepAliveDuration  *Duration `json:"keepAliveDuration,omitempty" yaml:"keepAliveDuration,omitempty"`
+}
+
+// Duration описывает продолжительность в часах, минутах и секундах
+type Duration struct {
+	Hours   int `json:"hours,omitempty" yaml:"hours,omitempty"`
+	Minutes int `json:"minutes,omitempty" yaml:"minutes,omitempty"`
+	Seconds int `json:"seconds,omitempty" yaml:"seconds,omitempty"`
+}
+
+// IntegrationOptions описывает опции интеграции (definitions/integrationOptions).
+// Набор полей зависит от версии API, поэтому они хранятся как словарь.
+type IntegrationOptions map[string]interface{}
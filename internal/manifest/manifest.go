@@ -0,0 +1,151 @@
+// Package manifest содержит типизированную модель YAML конфигурации
+// агентов, MCP серверов и систем агентов, соответствующую schemas/schema.json.
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Manifest - корневая структура конфигурации
+type Manifest struct {
+	MCPServers   []MCPServer   `json:"mcp-servers,omitempty" yaml:"mcp-servers,omitempty"`
+	Agents       []Agent       `json:"agents,omitempty" yaml:"agents,omitempty"`
+	AgentSystems []AgentSystem `json:"agent-systems,omitempty" yaml:"agent-systems,omitempty"`
+}
+
+// legacyAgentKeys - устаревшие ключи агента и их замена по схеме
+var legacyAgentKeys = map[string]string{
+	"llm_options": "options.llm",
+	"mcp_servers": "mcpServers",
+}
+
+// Normalize переносит устаревшие ключи конфигурации (llm_options, mcp_servers)
+// на места, которые ожидает схема, и возвращает предупреждения для каждого
+// найденного ключа. Конфигурация изменяется на месте.
+func Normalize(config map[string]interface{}) []string {
+	var warnings []string
+
+	agents, _ := config["agents"].([]interface{})
+	for i, raw := range agents {
+		agent, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, _ := agent["name"].(string)
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+
+		if llm, ok := agent["llm_options"]; ok {
+			options, _ := agent["options"].(map[string]interface{})
+			if options == nil {
+				options = make(map[string]interface{})
+				agent["options"] = options
+			}
+			if _, exists := options["llm"]; !exists {
+				options["llm"] = llm
+			}
+			delete(agent, "llm_options")
+			warnings = append(warnings, legacyWarning(name, "llm_options"))
+		}
+
+		if servers, ok := agent["mcp_servers"]; ok {
+			if _, exists := agent["mcpServers"]; !exists {
+				agent["mcpServers"] = servers
+			}
+			delete(agent, "mcp_servers")
+			warnings = append(warnings, legacyWarning(name, "mcp_servers"))
+		}
+	}
+
+	return warnings
+}
+
+// FromMap строит типизированную модель из обработанной YAML конфигурации.
+// Устаревшие ключи предварительно нормализуются (см. Normalize).
+func FromMap(config map[string]interface{}) (*Manifest, []string, error) {
+	warnings := Normalize(config)
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, warnings, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	for i, agent := range m.Agents {
+		if agent.Name == "" {
+			return nil, warnings, fmt.Errorf("agent at index %d missing required field: name", i)
+		}
+	}
+	for i, server := range m.MCPServers {
+		if server.Name == "" {
+			return nil, warnings, fmt.Errorf("MCP server at index %d missing required field: name", i)
+		}
+	}
+	for i, system := range m.AgentSystems {
+		if system.Name == "" {
+			return nil, warnings, fmt.Errorf("agent system at index %d missing required field: name", i)
+		}
+	}
+
+	return &m, warnings, nil
+}
+
+// ToMap преобразует часть модели в словарь в том виде, в котором она
+// передается в API. Пустые значения опускаются.
+func ToMap(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func legacyWarning(agent, key string) string {
+	return fmt.Sprintf("agent '%s': '%s' is deprecated, use '%s' instead", agent, key, legacyAgentKeys[key])
+}
+
+// marshalWithExtra сериализует структуру и добавляет к ней дополнительные поля
+func marshalWithExtra(fields interface{}, extra map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(fields)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var merged map[string]interface{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, exists := merged[key]; !exists {
+			merged[key] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// unmarshalExtra возвращает поля JSON объекта, не входящие в список известных
+func unmarshalExtra(data []byte, known ...string) (map[string]interface{}, error) {
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for _, key := range known {
+		delete(all, key)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
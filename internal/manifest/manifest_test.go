@@ -0,0 +1,96 @@
+package manifest
+
+import "testing"
+
+func TestFromMap_LegacyKeys(t *testing.T) {
+	config := map[string]interface{}{
+		"agents": []interface{}{
+			map[string]interface{}{
+				"name": "support-agent",
+				"llm_options": map[string]interface{}{
+					"foundationModels": map[string]interface{}{"modelName": "GigaChat"},
+				},
+				"mcp_servers": []interface{}{"weather"},
+				"options": map[string]interface{}{
+					"systemPrompt": "You are helpful",
+					"maxTokens":    1000,
+				},
+			},
+		},
+	}
+
+	m, warnings, err := FromMap(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("Expected 2 warnings, got %v", warnings)
+	}
+
+	agent := m.Agents[0]
+	if len(agent.MCPServers) != 1 || agent.MCPServers[0] != "weather" {
+		t.Errorf("Expected mcpServers [weather], got %v", agent.MCPServers)
+	}
+	if agent.Options == nil || agent.Options.LLM == nil || agent.Options.LLM.FoundationModels == nil {
+		t.Fatal("Expected llm_options to be moved to options.llm")
+	}
+	if agent.Options.LLM.FoundationModels.ModelName != "GigaChat" {
+		t.Errorf("Expected modelName GigaChat, got %q", agent.Options.LLM.FoundationModels.ModelName)
+	}
+	if agent.Options.SystemPrompt != "You are helpful" {
+		t.Errorf("Expected systemPrompt to be kept, got %q", agent.Options.SystemPrompt)
+	}
+
+	options := ToMap(agent.Options)
+	if options["maxTokens"] != float64(1000) {
+		t.Errorf("Expected extra option maxTokens to be kept, got %v", options["maxTokens"])
+	}
+	if _, ok := options["llm"]; !ok {
+		t.Errorf("Expected llm in serialized options, got %v", options)
+	}
+}
+
+func TestFromMap_SystemAgents(t *testing.T) {
+	config := map[string]interface{}{
+		"agent-systems": []interface{}{
+			map[string]interface{}{
+				"name": "support-system",
+				"agents": []interface{}{
+					"first-agent",
+					map[string]interface{}{
+						"name":    "second-agent",
+						"scaling": map[string]interface{}{"minScale": 1, "maxScale": 3},
+					},
+				},
+			},
+		},
+	}
+
+	m, _, err := FromMap(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	agents := m.AgentSystems[0].Agents
+	if len(agents) != 2 {
+		t.Fatalf("Expected 2 agents, got %d", len(agents))
+	}
+	if agents[0].Name != "first-agent" || agents[0].Scaling != nil {
+		t.Errorf("Expected first-agent without scaling, got %+v", agents[0])
+	}
+	if agents[1].Name != "second-agent" || agents[1].Scaling == nil {
+		t.Errorf("Expected second-agent with scaling, got %+v", agents[1])
+	}
+}
+
+func TestFromMap_MissingName(t *testing.T) {
+	config := map[string]interface{}{
+		"mcp-servers": []interface{}{
+			map[string]interface{}{"description": "no name"},
+		},
+	}
+
+	if _, _, err := FromMap(config); err == nil {
+		t.Error("Expected error for MCP server without name")
+	}
+}
@@ -0,0 +1,33 @@
+package manifest
+
+// MCPServer описывает MCP сервер в конфигурации (definitions/mcpServer)
+type MCPServer struct {
+	Name string `json:"name" yaml:"name"`
+	// Key - стабильный ключ ресурса в файле состояния, по умолчанию совпадает
+	// с именем. Позволяет переименовать ресурс без пересоздания.
+	Key         string `json:"key,omitempty" yaml:"key,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// InstanceType - имя или UUID типа вычислительной конфигурации
+	InstanceType       string                 `json:"instanceTypeId,omitempty" yaml:"instanceTypeId,omitempty"`
+	ImageSource        *MCPImageSource        `json:"imageSource,omitempty" yaml:"imageSource,omitempty"`
+	ExposedPorts       []int                  `json:"exposedPorts,omitempty" yaml:"exposedPorts,omitempty"`
+	Options            map[string]interface{} `json:"options,omitempty" yaml:"options,omitempty"`
+	EnvironmentOptions *EnvironmentOptions    `json:"environmentOptions,omitempty" yaml:"environmentOptions,omitempty"`
+	Scaling            *Scaling               `json:"scaling,omitempty" yaml:"scaling,omitempty"`
+	IntegrationOptions IntegrationOptions     `json:"integrationOptions,omitempty" yaml:"integrationOptions,omitempty"`
+}
+
+// StateKey возвращает ключ ресурса в файле состояния
+func (s *MCPServer) StateKey() string {
+	if s.Key != "" {
+		return s.Key
+	}
+	return s.Name
+}
+
+// MCPImageSource описывает источник образа MCP сервера (definitions/mcpImageSource)
+type MCPImageSource struct {
+	ARImageURI             string `json:"arImageUri,omitempty" yaml:"arImageUri,omitempty"`
+	MCPServerID            string `json:"mcpServerId,omitempty" yaml:"mcpServerId,omitempty"`
+	MarketplaceMCPServerID string `json:"marketplaceMcpServerId,omitempty" yaml:"marketplaceMcpServerId,omitempty"`
+}
@@ -0,0 +1,115 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RootFileName - имя корневого файла конфигурации при записи по отдельным файлам
+const RootFileName = "ai-agents.yaml"
+
+// unsafeFileChars - символы, которые заменяются в именах файлов ресурсов
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Marshal сериализует конфигурацию в YAML с отступом в два пробела
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteSplit записывает каждый ресурс конфигурации в отдельный файл
+// <dir>/<секция>/<имя>.yaml и корневой файл <dir>/ai-agents.yaml, который
+// подключает их через !include. Возвращает пути записанных файлов.
+func WriteSplit(dir string, m *Manifest) ([]string, error) {
+	root := make(map[string]interface{})
+	var written []string
+
+	writeSection := func(section string, count int, item func(int) (string, interface{})) error {
+		if count == 0 {
+			return nil
+		}
+
+		if err := os.MkdirAll(filepath.Join(dir, section), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+
+		used := make(map[string]int)
+		includes := make([]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			name, resource := item(i)
+			relPath := filepath.ToSlash(filepath.Join(section, resourceFileName(name, used)))
+
+			path, err := writeYAML(filepath.Join(dir, relPath), resource)
+			if err != nil {
+				return err
+			}
+			written = append(written, path)
+			includes = append(includes, map[string]interface{}{"!include": relPath})
+		}
+		root[section] = includes
+		return nil
+	}
+
+	if err := writeSection("mcp-servers", len(m.MCPServers), func(i int) (string, interface{}) {
+		return m.MCPServers[i].Name, m.MCPServers[i]
+	}); err != nil {
+		return written, err
+	}
+	if err := writeSection("agents", len(m.Agents), func(i int) (string, interface{}) {
+		return m.Agents[i].Name, m.Agents[i]
+	}); err != nil {
+		return written, err
+	}
+	if err := writeSection("agent-systems", len(m.AgentSystems), func(i int) (string, interface{}) {
+		return m.AgentSystems[i].Name, m.AgentSystems[i]
+	}); err != nil {
+		return written, err
+	}
+
+	path, err := writeYAML(filepath.Join(dir, RootFileName), root)
+	if err != nil {
+		return written, err
+	}
+	return append(written, path), nil
+}
+
+// resourceFileName возвращает имя файла для ресурса. Повторяющиеся имена
+// получают числовой суффикс, чтобы файлы не перезаписывали друг друга.
+func resourceFileName(name string, used map[string]int) string {
+	base := strings.Trim(unsafeFileChars.ReplaceAllString(name, "-"), "-.")
+	if base == "" {
+		base = "resource"
+	}
+
+	used[base]++
+	if used[base] > 1 {
+		base = fmt.Sprintf("%s-%d", base, used[base])
+	}
+	return base + ".yaml"
+}
+
+// writeYAML сериализует значение и записывает его в файл
+func writeYAML(path string, v interface{}) (string, error) {
+	data, err := Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
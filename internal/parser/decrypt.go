@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"filippo.io/age"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// loadIdentities загружает ключи age один раз, при первом зашифрованном значении
+var loadIdentities = sync.OnceValues(func() ([]age.Identity, error) {
+	return secrets.LoadIdentities("")
+})
+
+// DecryptionError - ошибка расшифровки значения с позицией в файле
+type DecryptionError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *DecryptionError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+}
+
+func (e *DecryptionError) Unwrap() error {
+	return e.Err
+}
+
+// decryptNode расшифровывает в памяти значения с тегом !encrypted и значения
+// вида ENC[age,data:...] (см. secrets.Decrypt). Ключи словарей не изменяются.
+func decryptNode(node *yaml.Node, file string) error {
+	var errs []error
+	walkValues(node, func(value *yaml.Node) {
+		if value.Tag != secrets.EncryptedTag && !secrets.IsEncrypted(value.Value) {
+			return
+		}
+
+		identities, err := loadIdentities()
+		if err == nil {
+			value.Value, err = secrets.Decrypt(value.Value, identities)
+		}
+		if err != nil {
+			errs = append(errs, &DecryptionError{File: file, Line: value.Line, Column: value.Column, Err: err})
+			return
+		}
+		value.Tag = "!!str"
+	})
+	return errors.Join(errs...)
+}
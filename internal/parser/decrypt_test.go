@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/secrets"
+)
+
+func TestProcessYAMLFile_Encrypted(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(secrets.KeyEnv, identity.String())
+
+	recipients := []age.Recipient{identity.Recipient()}
+	apiKey, err := secrets.Encrypt("sk-123", recipients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := secrets.Encrypt("8080", recipients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Скаляр с тегом !encrypted содержит шифротекст без обертки ENC[...]
+	tagged := strings.TrimSuffix(strings.TrimPrefix(port, "ENC[age,data:"), "]")
+
+	dir := t.TempDir()
+	config := filepath.Join(dir, "ai-agents.yaml")
+	content := "mcp-servers:\n  - name: weather\n    environmentOptions:\n      rawEnvs:\n        API_KEY: " + apiKey + "\n        PORT: !encrypted " + tagged + "\n"
+	if err := os.WriteFile(config, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ProcessYAMLFile(config)
+	if err != nil {
+		t.Fatalf("ProcessYAMLFile failed: %v", err)
+	}
+	server := result["mcp-servers"].([]interface{})[0].(map[string]interface{})
+	envs := server["environmentOptions"].(map[string]interface{})["rawEnvs"].(map[string]interface{})
+	if envs["API_KEY"] != "sk-123" {
+		t.Errorf("Expected decrypted API_KEY, got %v", envs["API_KEY"])
+	}
+	if envs["PORT"] != "8080" {
+		t.Errorf("Expected decrypted PORT as string, got %#v", envs["PORT"])
+	}
+
+	// Значение, зашифрованное другим ключом, - ошибка с позицией в файле
+	other, _ := age.GenerateX25519Identity()
+	foreign, err := secrets.Encrypt("x", []age.Recipient{other.Recipient()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config, []byte("agents:\n  - name: a\n    description: "+foreign+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ProcessYAMLFile(config)
+	var decryptionErr *DecryptionError
+	if !errors.As(err, &decryptionErr) || decryptionErr.Line != 3 {
+		t.Errorf("Expected DecryptionError at line 3, got %v", err)
+	}
+}
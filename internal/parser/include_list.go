@@ -0,0 +1,254 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// includesKey - список файлов верхнего уровня, секции которых объединяются
+	// с секциями текущего файла
+	includesKey = "includes"
+	// includeTag - тег YAML для включения файла: agents: !include agents.yaml
+	includeTag = "!include"
+)
+
+// concatSections - секции, списки которых объединяются из всех файлов includes
+var concatSections = []string{"mcp-servers", "agents", "agent-systems", "prompts"}
+
+// convertIncludeTags заменяет скаляры с тегом !include словарем
+// {"!include": путь}, который обрабатывает processNode
+func convertIncludeTags(node *yaml.Node) {
+	walkValues(node, func(value *yaml.Node) {
+		if value.Tag != includeTag {
+			return
+		}
+		path := *value
+		path.Tag, path.Style = "!!str", 0
+		*value = yaml.Node{
+			Kind:    yaml.MappingNode,
+			Tag:     "!!map",
+			Line:    path.Line,
+			Column:  path.Column,
+			Content: []*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: includeTag}, &path},
+		}
+	})
+}
+
+// mergeIncludes объединяет содержимое файла с файлами из списка includes.
+// Элементы списка - пути или glob шаблоны (agents/*.yaml) относительно
+// директории корневого файла; файл, подходящий под несколько элементов,
+// включается один раз. Списки concatSections объединяются: сначала из
+// включаемых файлов в порядке списка, затем из текущего файла. Остальные
+// ключи текущего файла переопределяют ключи включаемых файлов. Одинаковые
+// имена ресурсов в объединенной секции - ошибка.
+func (p *IncludeProcessor) mergeIncludes(content map[string]interface{}, contentSrc SourceMap, includes interface{}, currentFile string) (map[string]interface{}, SourceMap, error) {
+	patterns, err := includePatterns(includes)
+	if err != nil {
+		return nil, nil, &IncludeError{File: currentFile, Message: "invalid includes list", Err: err}
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := p.expandInclude(pattern, currentFile)
+		if err != nil {
+			return nil, nil, &IncludeError{File: currentFile, Message: "failed to resolve include path", Err: err}
+		}
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+
+	merged := make(map[string]interface{})
+	mergedSrc := make(SourceMap)
+	// origins - файл, из которого взят каждый элемент объединенных секций
+	origins := make(map[string][]string)
+
+	merge := func(file string, data map[string]interface{}, src SourceMap) error {
+		for key, value := range data {
+			if !isConcatSection(key) {
+				merged[key] = value
+				replaceSources(mergedSrc, key, src)
+				continue
+			}
+			if value == nil {
+				continue
+			}
+			items, ok := value.([]interface{})
+			if !ok {
+				return &IncludeError{File: file, Message: fmt.Sprintf("section '%s' must be a list", key), Err: fmt.Errorf("got %T", value)}
+			}
+			existing, _ := merged[key].([]interface{})
+			merged[key] = append(existing, items...)
+			appendSources(mergedSrc, key, src, items, len(existing))
+			for range items {
+				origins[key] = append(origins[key], file)
+			}
+		}
+		return nil
+	}
+
+	for _, file := range files {
+		included, includedSrc, err := p.process(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := merge(file, included, includedSrc); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := merge(currentFile, content, contentSrc); err != nil {
+		return nil, nil, err
+	}
+	if pos, ok := contentSrc[""]; ok {
+		mergedSrc[""] = pos
+	}
+
+	if err := p.checkDuplicates(merged, origins); err != nil {
+		return nil, nil, &IncludeError{File: currentFile, Message: "duplicate resource names", Err: err}
+	}
+	return merged, mergedSrc, nil
+}
+
+// replaceSources заменяет позиции значений ключа верхнего уровня позициями
+// из другого файла
+func replaceSources(dst SourceMap, key string, src SourceMap) {
+	for path := range dst {
+		if top, _ := topKey(path); top == key {
+			delete(dst, path)
+		}
+	}
+	for path, pos := range src {
+		if top, _ := topKey(path); top == key {
+			dst[path] = pos
+		}
+	}
+}
+
+// appendSources добавляет позиции элементов объединяемой секции; элементы
+// без имени адресуются по индексу, поэтому их индексы сдвигаются на offset
+func appendSources(dst SourceMap, section string, src SourceMap, items []interface{}, offset int) {
+	for path, pos := range src {
+		top, rest := topKey(path)
+		if top != section {
+			continue
+		}
+		if rest == "" {
+			if _, ok := dst[section]; !ok {
+				dst[section] = pos
+			}
+			continue
+		}
+		if end := strings.Index(rest, "]"); strings.HasPrefix(rest, "[") && end > 0 {
+			if i, err := strconv.Atoi(rest[1:end]); err == nil && i < len(items) && itemSegment(items[i], i) == rest[:end+1] {
+				rest = itemSegment(items[i], i+offset) + rest[end+1:]
+			}
+		}
+		dst[sourceKey(section, rest)] = pos
+	}
+}
+
+// includePatterns проверяет список includes: строка или список строк
+func includePatterns(includes interface{}) ([]string, error) {
+	switch v := includes.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		patterns := make([]string, 0, len(v))
+		for i, item := range v {
+			pattern, ok := item.(string)
+			if !ok || pattern == "" {
+				return nil, fmt.Errorf("item %d must be a file path, got %v", i, item)
+			}
+			patterns = append(patterns, pattern)
+		}
+		return patterns, nil
+	default:
+		return nil, fmt.Errorf("expected a list of file paths, got %T", includes)
+	}
+}
+
+// expandInclude возвращает файлы для элемента списка includes. Шаблон,
+// под который не подходит ни один файл, - ошибка.
+func (p *IncludeProcessor) expandInclude(pattern, currentFile string) ([]string, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		path, err := p.resolvePath(pattern, currentFile)
+		if err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}
+
+	baseDir := p.baseDir
+	if baseDir == "" {
+		baseDir = filepath.Dir(currentFile)
+	}
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(baseDir, pattern)
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include pattern %s: %w", pattern, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("include pattern matched no files: %s", pattern)
+	}
+	return matches, nil
+}
+
+// checkDuplicates проверяет уникальность имен в объединенных секциях
+func (p *IncludeProcessor) checkDuplicates(merged map[string]interface{}, origins map[string][]string) error {
+	var errs []error
+	for _, section := range concatSections {
+		items, _ := merged[section].([]interface{})
+		defined := make(map[string]string)
+		for i, item := range items {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, ok := m["name"].(string)
+			if !ok || name == "" {
+				continue
+			}
+			file := p.displayPath(origins[section][i])
+			if first, ok := defined[name]; ok {
+				errs = append(errs, fmt.Errorf("%s: '%s' is defined in %s and %s", section, name, first, file))
+				continue
+			}
+			defined[name] = file
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// displayPath возвращает путь файла относительно директории корневого файла
+func (p *IncludeProcessor) displayPath(file string) string {
+	if p.baseDir != "" {
+		if rel, err := filepath.Rel(p.baseDir, file); err == nil {
+			return rel
+		}
+	}
+	return file
+}
+
+func isConcatSection(key string) bool {
+	for _, section := range concatSections {
+		if key == section {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func names(t *testing.T, result map[string]interface{}, section string) []string {
+	t.Helper()
+	items, ok := result[section].([]interface{})
+	if !ok {
+		t.Fatalf("section %s is %T, want list", section, result[section])
+	}
+	var names []string
+	for _, item := range items {
+		names = append(names, item.(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
+func TestProcessYAMLFile_IncludesList(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ai-agents.yaml": `includes:
+  - mcp-servers.yaml
+  - agents/*.yaml
+  - agents/b.yaml
+agents:
+  - name: main
+agent-systems:
+  - name: support
+    options: !include systems/options.yaml
+`,
+		"mcp-servers.yaml":     "mcp-servers:\n  - name: search\n",
+		"agents/a.yaml":        "agents:\n  - name: a\n",
+		"agents/b.yaml":        "agents:\n  - name: b\nprompts:\n  - name: greeting\n",
+		"systems/options.yaml": "orchestrator: a\n",
+	})
+
+	result, err := ProcessYAMLFile(filepath.Join(dir, "ai-agents.yaml"))
+	if err != nil {
+		t.Fatalf("ProcessYAMLFile() error = %v", err)
+	}
+
+	if _, ok := result["includes"]; ok {
+		t.Error("includes key should be removed from result")
+	}
+	if got := strings.Join(names(t, result, "agents"), ","); got != "a,b,main" {
+		t.Errorf("agents = %s, want a,b,main", got)
+	}
+	if got := strings.Join(names(t, result, "mcp-servers"), ","); got != "search" {
+		t.Errorf("mcp-servers = %s, want search", got)
+	}
+	if got := strings.Join(names(t, result, "prompts"), ","); got != "greeting" {
+		t.Errorf("prompts = %s, want greeting", got)
+	}
+
+	system := result["agent-systems"].([]interface{})[0].(map[string]interface{})
+	options, _ := system["options"].(map[string]interface{})
+	if options["orchestrator"] != "a" {
+		t.Errorf("!include tag: options = %v, want orchestrator: a", system["options"])
+	}
+}
+
+func TestProcessYAMLFile_IncludesDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ai-agents.yaml": "includes: [agents/*.yaml]\nagents:\n  - name: a\n",
+		"agents/a.yaml":  "agents:\n  - name: a\n",
+	})
+
+	_, err := ProcessYAMLFile(filepath.Join(dir, "ai-agents.yaml"))
+	if err == nil {
+		t.Fatal("expected duplicate name error")
+	}
+	for _, want := range []string{"'a'", filepath.Join("agents", "a.yaml"), "ai-agents.yaml"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should contain %q", err, want)
+		}
+	}
+}
+
+func TestProcessYAMLFile_IncludesNoMatches(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ai-agents.yaml": "includes:\n  - agents/*.yaml\n",
+	})
+
+	if _, err := ProcessYAMLFile(filepath.Join(dir, "ai-agents.yaml")); err == nil {
+		t.Fatal("expected error for pattern without matches")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"gopkg.in/yaml.v3"
 )
@@ -24,21 +25,31 @@ func (e *IncludeError) Error() string {
 type IncludeProcessor struct {
 	processedFiles map[string]bool
 	baseDir        string
+	interpolator   *interpolator
 }
 
-// NewIncludeProcessor создает новый процессор includes
+// NewIncludeProcessor создает новый процессор includes. Переменные в значениях
+// подставляются из --var/--var-file и окружения (см. SetVariables).
 func NewIncludeProcessor(baseDir string) *IncludeProcessor {
 	return &IncludeProcessor{
 		processedFiles: make(map[string]bool),
 		baseDir:        baseDir,
+		interpolator:   &interpolator{vars: variables, dotenv: map[string]string{}},
 	}
 }
 
 // ProcessIncludes обрабатывает includes в YAML файле
 func (p *IncludeProcessor) ProcessIncludes(filePath string) (map[string]interface{}, error) {
+	content, _, err := p.process(filePath)
+	return content, err
+}
+
+// process обрабатывает includes в YAML файле и возвращает позиции значений
+// результата в исходных файлах
+func (p *IncludeProcessor) process(filePath string) (map[string]interface{}, SourceMap, error) {
 	// Проверяем на циклические зависимости
 	if p.processedFiles[filePath] {
-		return nil, &IncludeError{
+		return nil, nil, &IncludeError{
 			File:    filePath,
 			Message: "circular dependency detected",
 			Err:     fmt.Errorf("file %s is already being processed", filePath),
@@ -54,7 +65,7 @@ func (p *IncludeProcessor) ProcessIncludes(filePath string) (map[string]interfac
 	// Читаем файл
 	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		return nil, &IncludeError{
+		return nil, nil, &IncludeError{
 			File:    filePath,
 			Message: "failed to read file",
 			Err:     err,
@@ -62,35 +73,77 @@ func (p *IncludeProcessor) ProcessIncludes(filePath string) (map[string]interfac
 	}
 
 	// Парсим YAML
-	var content map[string]interface{}
-	if err := yaml.Unmarshal(data, &content); err != nil {
-		return nil, &IncludeError{
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, &IncludeError{
 			File:    filePath,
 			Message: "failed to parse YAML",
 			Err:     err,
 		}
 	}
 
+	// Подставляем переменные, пока известны позиции значений в файле
+	if err := p.interpolator.interpolateNode(&document, filePath); err != nil {
+		return nil, nil, err
+	}
+
+	// Зашифрованные значения расшифровываются только в памяти
+	if err := decryptNode(&document, filePath); err != nil {
+		return nil, nil, err
+	}
+
+	// Теги !include приводятся к форме с ключом !include
+	convertIncludeTags(&document)
+
+	// Позиции значений файла по путям с индексами элементов списков
+	positions := make(SourceMap)
+	indexNode(&document, filePath, "", positions)
+
+	var content map[string]interface{}
+	if document.Kind != 0 {
+		if err := document.Decode(&content); err != nil {
+			return nil, nil, &IncludeError{
+				File:    filePath,
+				Message: "failed to parse YAML",
+				Err:     err,
+			}
+		}
+	}
+
+	// Список includes верхнего уровня обрабатывается после остального содержимого
+	includes, hasIncludes := content[includesKey]
+	delete(content, includesKey)
+
 	// Обрабатываем includes
-	processedContent, err := p.processNode(content, filePath)
+	processedContent, src, err := p.processNode(content, filePath, positions, "")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Приводим к нужному типу
 	if result, ok := processedContent.(map[string]interface{}); ok {
-		return result, nil
+		if hasIncludes {
+			return p.mergeIncludes(result, src, includes, filePath)
+		}
+		return result, src, nil
 	}
 
-	return nil, &IncludeError{
+	return nil, nil, &IncludeError{
 		File:    filePath,
 		Message: "processed content is not a map",
 		Err:     fmt.Errorf("expected map[string]interface{}, got %T", processedContent),
 	}
 }
 
-// processNode рекурсивно обрабатывает узел YAML на предмет includes
-func (p *IncludeProcessor) processNode(node interface{}, currentFile string) (interface{}, error) {
+// processNode рекурсивно обрабатывает узел YAML на предмет includes.
+// positions - позиции значений текущего файла, path - путь к узлу в нем.
+// Возвращает позиции значений результата относительно узла.
+func (p *IncludeProcessor) processNode(node interface{}, currentFile string, positions SourceMap, path string) (interface{}, SourceMap, error) {
+	src := make(SourceMap)
+	if pos, ok := positions[path]; ok {
+		src[""] = pos
+	}
+
 	switch v := node.(type) {
 	case map[string]interface{}:
 		result := make(map[string]interface{})
@@ -100,7 +153,7 @@ func (p *IncludeProcessor) processNode(node interface{}, currentFile string) (in
 				// Обрабатываем include
 				includePath, ok := value.(string)
 				if !ok {
-					return nil, &IncludeError{
+					return nil, nil, &IncludeError{
 						File:    currentFile,
 						Message: "include path must be a string",
 						Err:     fmt.Errorf("expected string, got %T", value),
@@ -110,7 +163,7 @@ func (p *IncludeProcessor) processNode(node interface{}, currentFile string) (in
 				// Разрешаем путь
 				resolvedPath, err := p.resolvePath(includePath, currentFile)
 				if err != nil {
-					return nil, &IncludeError{
+					return nil, nil, &IncludeError{
 						File:    currentFile,
 						Message: "failed to resolve include path",
 						Err:     err,
@@ -118,38 +171,40 @@ func (p *IncludeProcessor) processNode(node interface{}, currentFile string) (in
 				}
 
 				// Обрабатываем включаемый файл
-				includedContent, err := p.ProcessIncludes(resolvedPath)
+				includedContent, includedSrc, err := p.process(resolvedPath)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 
 				// Возвращаем содержимое включаемого файла
-				return includedContent, nil
+				return includedContent, includedSrc, nil
 			}
 
 			// Обрабатываем значение рекурсивно
-			processedValue, err := p.processNode(value, currentFile)
+			processedValue, valueSrc, err := p.processNode(value, currentFile, positions, sourceKey(path, key))
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			result[key] = processedValue
+			src.add(key, valueSrc)
 		}
-		return result, nil
+		return result, src, nil
 
 	case []interface{}:
 		result := make([]interface{}, len(v))
 		for i, item := range v {
-			processedItem, err := p.processNode(item, currentFile)
+			processedItem, itemSrc, err := p.processNode(item, currentFile, positions, sourceKey(path, "["+strconv.Itoa(i)+"]"))
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			result[i] = processedItem
+			src.add(itemSegment(processedItem, i), itemSrc)
 		}
-		return result, nil
+		return result, src, nil
 
 	default:
 		// Примитивные типы возвращаем как есть
-		return node, nil
+		return node, src, nil
 	}
 }
 
@@ -182,6 +237,16 @@ func (p *IncludeProcessor) resolvePath(includePath, currentFile string) (string,
 
 // ProcessYAMLFile обрабатывает YAML файл с includes
 func ProcessYAMLFile(filePath string) (map[string]interface{}, error) {
+	doc, err := ProcessYAMLDocument(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Config, nil
+}
+
+// ProcessYAMLDocument обрабатывает YAML файл с includes и возвращает итоговую
+// конфигурацию вместе с позициями ее значений в исходных файлах
+func ProcessYAMLDocument(filePath string) (*Document, error) {
 	// Получаем абсолютный путь к файлу
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
@@ -191,11 +256,19 @@ func ProcessYAMLFile(filePath string) (map[string]interface{}, error) {
 	// Получаем базовую директорию
 	baseDir := filepath.Dir(absPath)
 
-	// Создаем процессор includes
+	// Создаем процессор includes; переменные дополняются файлом .env рядом с конфигурацией
 	processor := NewIncludeProcessor(baseDir)
+	interpolator, err := newInterpolator(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	processor.interpolator = interpolator
 
-	// Обрабатываем файл
-	return processor.ProcessIncludes(absPath)
+	result, src, err := processor.process(absPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{Config: result, Sources: src}, nil
 }
 
 // ValidateIncludes проверяет, что все includes в файле корректны
@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// variables - значения переменных из --var и --var-file (см. SetVariables)
+var variables = map[string]string{}
+
+// variableName проверяет имя переменной в выражении ${...}
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// InterpolationError - ошибка подстановки переменной с позицией в файле
+type InterpolationError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *InterpolationError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// SetVariables задает переменные, заданные через --var и --var-file.
+// Они имеют приоритет над переменными окружения.
+func SetVariables(vars map[string]string) {
+	variables = vars
+}
+
+// LoadVariables собирает переменные из файлов в формате .env и пар KEY=VALUE.
+// Пары применяются после файлов и переопределяют их значения.
+func LoadVariables(pairs, files []string) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	for _, file := range files {
+		values, err := godotenv.Read(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read variables file %s: %w", file, err)
+		}
+		for key, value := range values {
+			vars[key] = value
+		}
+	}
+
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || !variableName.MatchString(key) {
+			return nil, fmt.Errorf("invalid variable '%s', expected KEY=VALUE", pair)
+		}
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
+// interpolator подставляет значения переменных в строковые значения YAML.
+// Поддерживаемые выражения:
+//   - ${NAME}, ${NAME:-default} - переменная из --var/--var-file,
+//     окружения или файла .env рядом с конфигурацией;
+//   - ${env:NAME}, ${env:NAME:-default} - только переменная окружения;
+//   - ${file:path} - содержимое файла относительно текущего YAML файла.
+//
+// $${ выводит ${ без подстановки.
+type interpolator struct {
+	vars   map[string]string
+	dotenv map[string]string
+}
+
+// newInterpolator создает интерполятор с переменными из SetVariables
+// и файла .env в директории dir
+func newInterpolator(dir string) (*interpolator, error) {
+	dotenv, err := godotenv.Read(filepath.Join(dir, ".env"))
+	if errors.Is(err, os.ErrNotExist) {
+		dotenv = map[string]string{}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read .env: %w", err)
+	}
+	return &interpolator{vars: variables, dotenv: dotenv}, nil
+}
+
+// interpolateNode подставляет переменные во все значения узла. Ключи словарей
+// не изменяются. Ошибки собираются по всему файлу.
+func (in *interpolator) interpolateNode(node *yaml.Node, file string) error {
+	var errs []error
+	walkValues(node, func(value *yaml.Node) {
+		if !strings.Contains(value.Value, "$") {
+			return
+		}
+		expanded, err := in.expand(value.Value, file)
+		if err != nil {
+			errs = append(errs, &InterpolationError{File: file, Line: value.Line, Column: value.Column, Message: err.Error()})
+			return
+		}
+		value.Value = expanded
+		// Тип значения без кавычек определяется после подстановки,
+		// чтобы ${MIN_SCALE} стал числом
+		if value.Style == 0 {
+			value.Tag = ""
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// walkValues вызывает fn для каждого скалярного значения узла, кроме ключей словарей
+func walkValues(node *yaml.Node, fn func(*yaml.Node)) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			walkValues(child, fn)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			walkValues(node.Content[i], fn)
+		}
+	case yaml.ScalarNode:
+		fn(node)
+	}
+}
+
+// expand подставляет все выражения ${...} в строке
+func (in *interpolator) expand(s, file string) (string, error) {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "$")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+
+		switch {
+		case strings.HasPrefix(s, "$${"):
+			b.WriteString("${")
+			s = s[3:]
+		case strings.HasPrefix(s, "${"):
+			end := strings.Index(s, "}")
+			if end < 0 {
+				return "", fmt.Errorf("unterminated expression '%s'", s)
+			}
+			value, err := in.resolve(s[2:end], file)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			s = s[end+1:]
+		default:
+			b.WriteString("$")
+			s = s[1:]
+		}
+	}
+}
+
+// resolve вычисляет одно выражение без ${ и }
+func (in *interpolator) resolve(expr, file string) (string, error) {
+	source := ""
+	if prefix, rest, ok := strings.Cut(expr, ":"); ok && !strings.HasPrefix(rest, "-") {
+		source, expr = prefix, rest
+	}
+
+	if source == "file" {
+		path := expr
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file for ${file:%s}: %w", expr, err)
+		}
+		return strings.TrimSuffix(string(data), "\n"), nil
+	}
+
+	name, fallback, hasDefault := strings.Cut(expr, ":-")
+	if !variableName.MatchString(name) {
+		return "", fmt.Errorf("invalid variable name '%s'", name)
+	}
+
+	var value string
+	var found bool
+	switch source {
+	case "":
+		value, found = in.lookup(name)
+	case "env":
+		value, found = in.lookupEnv(name)
+	default:
+		return "", fmt.Errorf("unknown variable source '%s' in ${%s:%s}", source, source, expr)
+	}
+
+	if found && value != "" {
+		return value, nil
+	}
+	if hasDefault {
+		return fallback, nil
+	}
+	if found {
+		return value, nil
+	}
+	return "", fmt.Errorf("variable '%s' is not defined", name)
+}
+
+// lookup ищет переменную в --var/--var-file, затем в окружении и .env
+func (in *interpolator) lookup(name string) (string, bool) {
+	if value, ok := in.vars[name]; ok {
+		return value, true
+	}
+	return in.lookupEnv(name)
+}
+
+// lookupEnv ищет переменную в окружении, затем в файле .env
+func (in *interpolator) lookupEnv(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	value, ok := in.dotenv[name]
+	return value, ok
+}
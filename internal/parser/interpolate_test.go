@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProcessYAMLFile_Interpolation(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("prompt.md", "You are a helpful assistant.\n")
+	write(".env", "REGISTRY=cr.cloud.ru\n")
+	write("agent.yaml", `name: assistant
+imageSource:
+  arImageUri: ${REGISTRY}/assistant:${IMAGE_TAG}
+options:
+  systemPrompt: ${file:prompt.md}
+  llm:
+    foundationModels:
+      modelName: ${env:AI_AGENTS_TEST_MODEL:-GigaChat}
+scaling:
+  minScale: ${MIN_SCALE}
+description: "Costs $$5, literal $${IMAGE_TAG}"
+`)
+	config := write("ai-agents.yaml", "agents:\n  - \"!include\": agent.yaml\n")
+
+	SetVariables(map[string]string{"IMAGE_TAG": "1.2.0", "MIN_SCALE": "2"})
+	defer SetVariables(map[string]string{})
+
+	result, err := ProcessYAMLFile(config)
+	if err != nil {
+		t.Fatalf("ProcessYAMLFile failed: %v", err)
+	}
+
+	agent := result["agents"].([]interface{})[0].(map[string]interface{})
+	if got := agent["imageSource"].(map[string]interface{})["arImageUri"]; got != "cr.cloud.ru/assistant:1.2.0" {
+		t.Errorf("Unexpected image URI: %v", got)
+	}
+	options := agent["options"].(map[string]interface{})
+	if got := options["systemPrompt"]; got != "You are a helpful assistant." {
+		t.Errorf("Unexpected system prompt: %q", got)
+	}
+	if got := options["llm"].(map[string]interface{})["foundationModels"].(map[string]interface{})["modelName"]; got != "GigaChat" {
+		t.Errorf("Expected default model, got %v", got)
+	}
+	if got := agent["scaling"].(map[string]interface{})["minScale"]; got != 2 {
+		t.Errorf("Expected minScale to be an integer, got %#v", got)
+	}
+	if got := agent["description"]; got != "Costs $$5, literal ${IMAGE_TAG}" {
+		t.Errorf("Unexpected description: %v", got)
+	}
+}
+
+func TestProcessYAMLFile_UndefinedVariable(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "ai-agents.yaml")
+	content := "agents:\n  - name: assistant\n    description: ${AI_AGENTS_TEST_UNDEFINED}\n"
+	if err := os.WriteFile(config, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ProcessYAMLFile(config)
+	var interpolationErr *InterpolationError
+	if !errors.As(err, &interpolationErr) {
+		t.Fatalf("Expected InterpolationError, got %v", err)
+	}
+	if interpolationErr.Line != 3 || !strings.HasSuffix(interpolationErr.File, "ai-agents.yaml") {
+		t.Errorf("Expected error at ai-agents.yaml:3, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "'AI_AGENTS_TEST_UNDEFINED' is not defined") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestLoadVariables(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "vars.env")
+	if err := os.WriteFile(file, []byte("IMAGE_TAG=1.0\nMODEL=GigaChat\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	vars, err := LoadVariables([]string{"IMAGE_TAG=2.0", "EMPTY="}, []string{file})
+	if err != nil {
+		t.Fatalf("LoadVariables failed: %v", err)
+	}
+	if vars["IMAGE_TAG"] != "2.0" || vars["MODEL"] != "GigaChat" || vars["EMPTY"] != "" {
+		t.Errorf("Unexpected variables: %v", vars)
+	}
+
+	if _, err := LoadVariables([]string{"NO_VALUE"}, nil); err == nil {
+		t.Error("Expected error for variable without value")
+	}
+}
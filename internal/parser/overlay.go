@@ -0,0 +1,389 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OverlayDir - директория с оверлеями окружений относительно файла конфигурации
+const OverlayDir = "overlays"
+
+const (
+	// patchKey - директива оверлея для элемента списка или словаря
+	patchKey = "$patch"
+	// patchDelete - удалить элемент из базовой конфигурации
+	patchDelete = "delete"
+	// patchReplace - заменить словарь целиком вместо слияния
+	patchReplace = "replace"
+)
+
+// overlayExtensions - расширения файлов оверлеев в порядке поиска
+var overlayExtensions = []string{".yaml", ".yml"}
+
+// resourceSections - секции конфигурации с именованными ресурсами
+var resourceSections = []string{"mcp-servers", "agents", "agent-systems"}
+
+// OverlayPath возвращает путь к оверлею окружения env для файла конфигурации:
+// overlays/<env>.yaml рядом с ним
+func OverlayPath(configFile, env string) (string, error) {
+	dir := filepath.Join(filepath.Dir(configFile), OverlayDir)
+	for _, ext := range overlayExtensions {
+		path := filepath.Join(dir, env+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("overlay for environment '%s' not found in %s", env, dir)
+}
+
+// FindOverlays возвращает окружения, для которых рядом с файлом конфигурации
+// есть оверлеи, в алфавитном порядке
+func FindOverlays(configFile string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(filepath.Dir(configFile), OverlayDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlays directory: %w", err)
+	}
+
+	var envs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		for _, ext := range overlayExtensions {
+			if strings.HasSuffix(entry.Name(), ext) {
+				envs = append(envs, strings.TrimSuffix(entry.Name(), ext))
+				break
+			}
+		}
+	}
+	sort.Strings(envs)
+	return envs, nil
+}
+
+// ProcessYAMLFileWithOverlay обрабатывает файл конфигурации с includes и,
+// если задано окружение, накладывает на него оверлей overlays/<env>.yaml
+// (см. ApplyOverlay). Оверлей тоже может содержать includes. Позиции значений
+// итоговой конфигурации указывают на базовый файл или на оверлей.
+func ProcessYAMLFileWithOverlay(configFile, env string) (*Document, error) {
+	base, err := ProcessYAMLDocument(configFile)
+	if err != nil || env == "" {
+		return base, err
+	}
+
+	overlayFile, err := OverlayPath(configFile, env)
+	if err != nil {
+		return nil, err
+	}
+
+	overlay, err := ProcessYAMLDocument(overlayFile)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ApplyOverlay(base.Config, overlay.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply overlay %s: %w", overlayFile, err)
+	}
+	return &Document{Config: result, Sources: overlaySources(base, overlay)}, nil
+}
+
+// overlaySources возвращает позиции значений результата наложения оверлея:
+// значения оверлея переопределяют позиции базовой конфигурации
+func overlaySources(base, overlay *Document) SourceMap {
+	src := make(SourceMap)
+	for path, pos := range base.Sources {
+		src[path] = pos
+	}
+	for path, pos := range overlay.Sources {
+		if top, _ := topKey(path); path != "" && top != "namePrefix" && top != "nameSuffix" {
+			src[path] = pos
+		}
+	}
+
+	prefix, _ := overlayString(overlay.Config, "namePrefix")
+	suffix, _ := overlayString(overlay.Config, "nameSuffix")
+	if prefix != "" || suffix != "" {
+		return renameSources(src, prefix, suffix)
+	}
+	return src
+}
+
+// ApplyOverlay накладывает оверлей на базовую конфигурацию по правилам
+// strategic merge:
+//   - словари сливаются рекурсивно, значение null удаляет ключ;
+//   - словарь с "$patch: replace" заменяет базовый целиком;
+//   - списки объектов с полем name сливаются по имени, элемент с
+//     "$patch: delete" удаляет одноименный элемент базы, новые имена добавляются;
+//   - остальные списки и значения заменяются.
+//
+// Ключи оверлея namePrefix и nameSuffix добавляются к именам всех ресурсов;
+// ссылки на переименованные ресурсы (mcpServers агентов, agents систем)
+// обновляются. Базовая конфигурация не изменяется.
+func ApplyOverlay(base, overlay map[string]interface{}) (map[string]interface{}, error) {
+	prefix, err := overlayString(overlay, "namePrefix")
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := overlayString(overlay, "nameSuffix")
+	if err != nil {
+		return nil, err
+	}
+
+	patch := make(map[string]interface{}, len(overlay))
+	for key, value := range overlay {
+		if key != "namePrefix" && key != "nameSuffix" {
+			patch[key] = value
+		}
+	}
+
+	merged, err := mergeValue(base, patch, "")
+	if err != nil {
+		return nil, err
+	}
+	result := merged.(map[string]interface{})
+
+	if prefix != "" || suffix != "" {
+		renameResources(result, prefix, suffix)
+	}
+	return result, nil
+}
+
+// overlayString возвращает строковый параметр оверлея
+func overlayString(overlay map[string]interface{}, key string) (string, error) {
+	value, ok := overlay[key]
+	if !ok || value == nil {
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("overlay '%s' must be a string, got %T", key, value)
+	}
+	return s, nil
+}
+
+// mergeValue сливает значение оверлея с базовым значением. path - путь
+// к значению для сообщений об ошибках.
+func mergeValue(base, patch interface{}, path string) (interface{}, error) {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		b, ok := base.(map[string]interface{})
+		if !ok || p[patchKey] == patchReplace {
+			return stripPatch(p), nil
+		}
+		if directive, ok := p[patchKey]; ok {
+			return nil, fmt.Errorf("%s: unsupported %s directive '%v'", displayPath(path), patchKey, directive)
+		}
+
+		result := copyMap(b)
+		for key, value := range p {
+			if value == nil {
+				delete(result, key)
+				continue
+			}
+			merged, err := mergeValue(b[key], value, joinPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			result[key] = merged
+		}
+		return result, nil
+
+	case []interface{}:
+		b, ok := base.([]interface{})
+		if !ok || !hasNamedItems(p) {
+			return stripPatches(p), nil
+		}
+		return mergeNamedList(b, p, path)
+
+	default:
+		return patch, nil
+	}
+}
+
+// mergeNamedList сливает списки ресурсов по полю name
+func mergeNamedList(base, patch []interface{}, path string) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(base)+len(patch))
+	for _, item := range base {
+		result = append(result, stripPatches(item))
+	}
+
+	for i, item := range patch {
+		name := itemName(item)
+		if name == "" {
+			return nil, fmt.Errorf("%s[%d]: item in a named list must have a name", displayPath(path), i)
+		}
+		itemPath := fmt.Sprintf("%s[%s]", displayPath(path), name)
+
+		index := -1
+		for j, existing := range result {
+			if itemName(existing) == name {
+				index = j
+				break
+			}
+		}
+
+		if m, ok := item.(map[string]interface{}); ok && m[patchKey] == patchDelete {
+			if index < 0 {
+				return nil, fmt.Errorf("%s: cannot delete item that is not in the base configuration", itemPath)
+			}
+			result = append(result[:index], result[index+1:]...)
+			continue
+		}
+
+		if index < 0 {
+			result = append(result, stripPatches(item))
+			continue
+		}
+
+		// Краткая запись строкой содержит только имя - берем объект из оверлея
+		if _, ok := result[index].(string); ok {
+			result[index] = stripPatches(item)
+			continue
+		}
+		if _, ok := item.(string); ok {
+			continue
+		}
+		merged, err := mergeValue(result[index], item, itemPath)
+		if err != nil {
+			return nil, err
+		}
+		result[index] = merged
+	}
+
+	return result, nil
+}
+
+// hasNamedItems проверяет, содержит ли список объекты с полем name
+func hasNamedItems(list []interface{}) bool {
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			if _, ok := m["name"].(string); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// itemName возвращает имя элемента списка: поле name объекта или саму строку
+func itemName(item interface{}) string {
+	switch v := item.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		name, _ := v["name"].(string)
+		return name
+	default:
+		return ""
+	}
+}
+
+// renameResources добавляет префикс и суффикс к именам ресурсов и обновляет
+// ссылки на них. Ссылки на ресурсы, не описанные в конфигурации, не меняются.
+func renameResources(config map[string]interface{}, prefix, suffix string) {
+	renamed := make(map[string]map[string]string)
+	for _, section := range resourceSections {
+		renamed[section] = make(map[string]string)
+		items, _ := config[section].([]interface{})
+		for _, raw := range items {
+			item, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if name, ok := item["name"].(string); ok && name != "" {
+				item["name"] = prefix + name + suffix
+				renamed[section][name] = item["name"].(string)
+			}
+		}
+	}
+
+	agents, _ := config["agents"].([]interface{})
+	for _, raw := range agents {
+		agent, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"mcpServers", "mcp_servers"} {
+			refs, _ := agent[key].([]interface{})
+			for i, ref := range refs {
+				if name, ok := ref.(string); ok && renamed["mcp-servers"][name] != "" {
+					refs[i] = renamed["mcp-servers"][name]
+				}
+			}
+		}
+	}
+
+	systems, _ := config["agent-systems"].([]interface{})
+	for _, raw := range systems {
+		system, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		refs, _ := system["agents"].([]interface{})
+		for i, ref := range refs {
+			switch v := ref.(type) {
+			case string:
+				if renamed["agents"][v] != "" {
+					refs[i] = renamed["agents"][v]
+				}
+			case map[string]interface{}:
+				if name, ok := v["name"].(string); ok && renamed["agents"][name] != "" {
+					v["name"] = renamed["agents"][name]
+				}
+			}
+		}
+	}
+}
+
+// stripPatches возвращает копию значения без директив $patch
+func stripPatches(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return stripPatch(v)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = stripPatches(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// stripPatch возвращает копию словаря без директив $patch
+func stripPatch(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if key == patchKey {
+			continue
+		}
+		result[key] = stripPatches(value)
+	}
+	return result
+}
+
+// copyMap возвращает глубокую копию словаря, чтобы слияние не меняло базу
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	return stripPatch(m)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "overlay"
+	}
+	return path
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func parseYAML(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var result map[string]interface{}
+	if err := yaml.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+	return result
+}
+
+func TestApplyOverlay(t *testing.T) {
+	base := parseYAML(t, `
+mcp-servers:
+  - name: weather
+    scaling: {minScale: 1, maxScale: 1}
+agents:
+  - name: assistant
+    mcpServers: [weather, external]
+    options:
+      systemPrompt: Be helpful
+      llm: {foundationModels: {modelName: GigaChat}}
+  - name: legacy
+agent-systems:
+  - name: team
+    agents: [assistant, {name: legacy}]
+`)
+	overlay := parseYAML(t, `
+namePrefix: prod-
+mcp-servers:
+  - name: weather
+    scaling: {maxScale: 5}
+agents:
+  - name: assistant
+    options:
+      llm: {foundationModels: {modelName: GigaChat-Max}}
+  - name: legacy
+    $patch: delete
+  - name: router
+    description: Added in prod
+`)
+
+	result, err := ApplyOverlay(base, overlay)
+	if err != nil {
+		t.Fatalf("ApplyOverlay failed: %v", err)
+	}
+
+	expected := parseYAML(t, `
+mcp-servers:
+  - name: prod-weather
+    scaling: {minScale: 1, maxScale: 5}
+agents:
+  - name: prod-assistant
+    mcpServers: [prod-weather, external]
+    options:
+      systemPrompt: Be helpful
+      llm: {foundationModels: {modelName: GigaChat-Max}}
+  - name: prod-router
+    description: Added in prod
+agent-systems:
+  - name: prod-team
+    agents: [prod-assistant, {name: legacy}]
+`)
+	if !reflect.DeepEqual(result, expected) {
+		got, _ := yaml.Marshal(result)
+		t.Errorf("Unexpected overlay result:\n%s", got)
+	}
+
+	if name := base["agents"].([]interface{})[0].(map[string]interface{})["name"]; name != "assistant" {
+		t.Errorf("Expected base configuration to stay unchanged, got agent name %v", name)
+	}
+}
+
+func TestApplyOverlay_Directives(t *testing.T) {
+	base := parseYAML(t, `
+agents:
+  - name: assistant
+    description: Base
+    options: {systemPrompt: Base, llm: {foundationModels: {modelName: GigaChat}}}
+`)
+
+	result, err := ApplyOverlay(base, parseYAML(t, `
+agents:
+  - name: assistant
+    description: null
+    options: {$patch: replace, systemPrompt: Replaced}
+`))
+	if err != nil {
+		t.Fatalf("ApplyOverlay failed: %v", err)
+	}
+	agent := result["agents"].([]interface{})[0].(map[string]interface{})
+	if _, ok := agent["description"]; ok {
+		t.Error("Expected null to remove description")
+	}
+	if !reflect.DeepEqual(agent["options"], map[string]interface{}{"systemPrompt": "Replaced"}) {
+		t.Errorf("Expected options to be replaced, got %v", agent["options"])
+	}
+
+	if _, err := ApplyOverlay(base, parseYAML(t, "agents: [{name: missing, $patch: delete}]")); err == nil {
+		t.Error("Expected error when deleting an item missing from the base")
+	}
+}
+
+func TestProcessYAMLFileWithOverlay(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("ai-agents.yaml", "agents:\n  - name: assistant\n")
+	write("overlays/prod.yaml", "nameSuffix: -prod\n")
+	write("overlays/dev.yml", "agents:\n  - name: assistant\n    description: Dev\n")
+
+	configFile := filepath.Join(dir, "ai-agents.yaml")
+	envs, err := FindOverlays(configFile)
+	if err != nil || !reflect.DeepEqual(envs, []string{"dev", "prod"}) {
+		t.Fatalf("Expected dev and prod overlays, got %v (%v)", envs, err)
+	}
+
+	result, err := ProcessYAMLFileWithOverlay(configFile, "prod")
+	if err != nil {
+		t.Fatalf("ProcessYAMLFileWithOverlay failed: %v", err)
+	}
+	if name := result.Config["agents"].([]interface{})[0].(map[string]interface{})["name"]; name != "assistant-prod" {
+		t.Errorf("Expected suffixed name, got %v", name)
+	}
+
+	if _, err := ProcessYAMLFileWithOverlay(configFile, "stage"); err == nil {
+		t.Error("Expected error for missing overlay")
+	}
+}
@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position - позиция значения в исходном YAML файле
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// IsValid проверяет, известна ли позиция
+func (p Position) IsValid() bool {
+	return p.File != "" && p.Line > 0
+}
+
+func (p Position) String() string {
+	if !p.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d:%d", relativePath(p.File), p.Line, p.Column)
+}
+
+// relativePath возвращает путь относительно рабочей директории, если файл
+// находится в ней
+func relativePath(file string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return file
+	}
+	rel, err := filepath.Rel(wd, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return file
+	}
+	return rel
+}
+
+// Excerpt возвращает строку исходного файла с отметкой ^ под значением,
+// как в сообщениях компилятора, или пустую строку, если файл недоступен
+func (p Position) Excerpt() string {
+	if !p.IsValid() {
+		return ""
+	}
+	file, err := os.Open(p.File)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if line != p.Line {
+			continue
+		}
+		text := scanner.Text()
+		// Табуляции сохраняются, чтобы отметка совпала со значением
+		var marker strings.Builder
+		for i, r := range []rune(text) {
+			if i >= p.Column-1 {
+				break
+			}
+			if r == '\t' {
+				marker.WriteRune('\t')
+			} else {
+				marker.WriteRune(' ')
+			}
+		}
+		number := strconv.Itoa(p.Line)
+		gutter := strings.Repeat(" ", len(number))
+		return fmt.Sprintf("%s | %s\n%s | %s^", number, text, gutter, marker.String())
+	}
+	return ""
+}
+
+// SourceMap - позиции значений итоговой конфигурации в исходных файлах по
+// пути к значению. Элементы списков с полем name адресуются по имени,
+// остальные - по индексу: agents[assistant].options.llm, prompts[0].
+// Пустой путь - корень документа.
+type SourceMap map[string]Position
+
+// Document - итоговая конфигурация после обработки includes, переменных и
+// оверлея вместе с позициями ее значений в исходных файлах
+type Document struct {
+	Config  map[string]interface{}
+	Sources SourceMap
+}
+
+// Lookup возвращает позицию значения конфигурации по пути из сегментов, в
+// котором элементы списков заданы индексами (agents, 0, options), как в
+// ошибках JSON схемы. Если позиция значения неизвестна, возвращается позиция
+// ближайшего родителя.
+func (s SourceMap) Lookup(config map[string]interface{}, path []string) (Position, bool) {
+	keys := []string{""}
+	var value interface{} = config
+	for _, segment := range path {
+		key := segment
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[segment]
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				value = nil
+				break
+			}
+			value = v[i]
+			key = itemSegment(value, i)
+		default:
+			value = nil
+		}
+		keys = append(keys, sourceKey(keys[len(keys)-1], key))
+	}
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		if pos, ok := s[keys[i]]; ok {
+			return pos, true
+		}
+	}
+	return Position{}, false
+}
+
+// Resource возвращает позицию определения ресурса в секции конфигурации
+func (s SourceMap) Resource(section, name string) (Position, bool) {
+	pos, ok := s[section+"["+name+"]"]
+	return pos, ok
+}
+
+// add добавляет позиции вложенного значения с префиксом пути
+func (s SourceMap) add(prefix string, sub SourceMap) {
+	for key, pos := range sub {
+		s[sourceKey(prefix, key)] = pos
+	}
+}
+
+// sourceKey соединяет путь и ключ: agents + [assistant] + options
+func sourceKey(prefix, key string) string {
+	switch {
+	case prefix == "":
+		return key
+	case key == "":
+		return prefix
+	case strings.HasPrefix(key, "["):
+		return prefix + key
+	default:
+		return prefix + "." + key
+	}
+}
+
+// topKey возвращает ключ верхнего уровня пути и остаток: agents, [a].options
+func topKey(key string) (string, string) {
+	if i := strings.IndexAny(key, ".["); i >= 0 {
+		return key[:i], strings.TrimPrefix(key[i:], ".")
+	}
+	return key, ""
+}
+
+// itemSegment возвращает сегмент пути элемента списка: [name] или [index]
+func itemSegment(item interface{}, index int) string {
+	if m, ok := item.(map[string]interface{}); ok {
+		if name, ok := m["name"].(string); ok && name != "" {
+			return "[" + name + "]"
+		}
+	}
+	return "[" + strconv.Itoa(index) + "]"
+}
+
+// indexNode записывает позиции значений узла YAML по путям с индексами
+// элементов списков: agents[0].options
+func indexNode(node *yaml.Node, file, path string, index SourceMap) {
+	if node.Kind == yaml.DocumentNode {
+		for _, child := range node.Content {
+			indexNode(child, file, path, index)
+		}
+		return
+	}
+
+	index[path] = Position{File: file, Line: node.Line, Column: node.Column}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			indexNode(node.Content[i+1], file, sourceKey(path, node.Content[i].Value), index)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			indexNode(child, file, sourceKey(path, "["+strconv.Itoa(i)+"]"), index)
+		}
+	}
+}
+
+// renameSources переносит позиции ресурсов, переименованных оверлеем
+// (namePrefix и nameSuffix)
+func renameSources(src SourceMap, prefix, suffix string) SourceMap {
+	result := make(SourceMap, len(src))
+	for key, pos := range src {
+		section, rest := topKey(key)
+		if isResourceSection(section) && strings.HasPrefix(rest, "[") {
+			if end := strings.Index(rest, "]"); end > 0 {
+				name := rest[1:end]
+				if _, err := strconv.Atoi(name); err != nil {
+					key = section + "[" + prefix + name + suffix + "]" + rest[end+1:]
+				}
+			}
+		}
+		result[key] = pos
+	}
+	return result
+}
+
+func isResourceSection(key string) bool {
+	for _, section := range resourceSections {
+		if key == section {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProcessYAMLFile_Sources(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ai-agents.yaml": `includes:
+  - agents/*.yaml
+agents:
+  - name: main
+    options: !include options.yaml
+`,
+		"options.yaml": "llm:\n  model: gpt\n",
+		"agents/support.yaml": `agents:
+  - name: support
+    scaling:
+      minScale: two
+`,
+	})
+
+	doc, err := ProcessYAMLDocument(filepath.Join(dir, "ai-agents.yaml"))
+	if err != nil {
+		t.Fatalf("ProcessYAMLDocument() error = %v", err)
+	}
+	config, src := doc.Config, doc.Sources
+
+	tests := []struct {
+		path []string
+		file string
+		line int
+		col  int
+	}{
+		{[]string{"agents", "0", "scaling", "minScale"}, "agents/support.yaml", 4, 17},
+		{[]string{"agents", "1", "name"}, "ai-agents.yaml", 4, 11},
+		{[]string{"agents", "1", "options", "llm", "model"}, "options.yaml", 2, 10},
+		// Позиция отсутствующего значения - позиция ближайшего родителя
+		{[]string{"agents", "0", "scaling", "maxScale"}, "agents/support.yaml", 4, 7},
+	}
+	for _, tt := range tests {
+		pos, ok := src.Lookup(config, tt.path)
+		if !ok {
+			t.Errorf("Lookup(%v) not found", tt.path)
+			continue
+		}
+		want := Position{File: filepath.Join(dir, tt.file), Line: tt.line, Column: tt.col}
+		if pos != want {
+			t.Errorf("Lookup(%v) = %v, want %v", tt.path, pos, want)
+		}
+	}
+
+	if pos, ok := src.Resource("agents", "support"); !ok || pos.Line != 2 {
+		t.Errorf("Resource(agents, support) = %v, %v", pos, ok)
+	}
+}
+
+func TestProcessYAMLFileWithOverlay_Sources(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ai-agents.yaml": "agents:\n  - name: main\n    description: base\n",
+		"overlays/prod.yaml": `namePrefix: prod-
+agents:
+  - name: main
+    description: prod
+`,
+	})
+
+	doc, err := ProcessYAMLFileWithOverlay(filepath.Join(dir, "ai-agents.yaml"), "prod")
+	if err != nil {
+		t.Fatalf("ProcessYAMLFileWithOverlay() error = %v", err)
+	}
+
+	pos, ok := doc.Sources.Lookup(doc.Config, []string{"agents", "0", "description"})
+	if !ok || pos.File != filepath.Join(dir, "overlays", "prod.yaml") || pos.Line != 4 {
+		t.Errorf("Lookup(description) = %v, want overlays/prod.yaml:4", pos)
+	}
+	if _, ok := doc.Sources.Resource("agents", "prod-main"); !ok {
+		t.Error("renamed resource position not found")
+	}
+}
+
+func TestPosition_Excerpt(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(file, []byte("name: a\nscaling:\n  minScale: two\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := Position{File: file, Line: 3, Column: 13}.Excerpt()
+	want := "3 |   minScale: two\n  |             ^"
+	if got != want {
+		t.Errorf("Excerpt() =\n%s\nwant\n%s", got, want)
+	}
+	if !strings.HasSuffix(Position{File: file, Line: 3, Column: 13}.String(), "agent.yaml:3:13") {
+		t.Errorf("String() = %s", Position{File: file, Line: 3, Column: 13})
+	}
+}
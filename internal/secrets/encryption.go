@@ -0,0 +1,161 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+const (
+	// EncryptedTag - тег YAML для зашифрованного значения: !encrypted <шифротекст>
+	EncryptedTag = "!encrypted"
+
+	// KeyEnv - переменная окружения с ключом age (AGE-SECRET-KEY-...)
+	KeyEnv = "AI_AGENTS_AGE_KEY"
+	// KeyFileEnv - переменная окружения с путем к файлу ключей age
+	KeyFileEnv = "AI_AGENTS_AGE_KEY_FILE"
+
+	// encryptedPrefix и encryptedSuffix обрамляют зашифрованное значение:
+	// ENC[age,data:<base64>]
+	encryptedPrefix = "ENC[age,data:"
+	encryptedSuffix = "]"
+)
+
+// IsEncrypted проверяет, является ли значение зашифрованным: ENC[age,data:...]
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+// Encrypt шифрует значение для получателей age и возвращает его в виде
+// ENC[age,data:<base64>]
+func Encrypt(plaintext string, recipients []age.Recipient) (string, error) {
+	if len(recipients) == 0 {
+		return "", errors.New("no age recipients to encrypt for")
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt value: %w", err)
+	}
+	if _, err := io.WriteString(w, plaintext); err != nil {
+		return "", fmt.Errorf("failed to encrypt value: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to encrypt value: %w", err)
+	}
+
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()) + encryptedSuffix, nil
+}
+
+// Decrypt расшифровывает значение ключами age. Принимаются значения
+// ENC[age,data:<base64>], а также base64 или ASCII armor шифротекста age
+// (содержимое скаляра с тегом !encrypted). Расшифрованное значение
+// запоминается для Redact и масок плана.
+func Decrypt(value string, identities []age.Identity) (string, error) {
+	var src io.Reader
+	switch trimmed := strings.TrimSpace(value); {
+	case IsEncrypted(trimmed):
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(trimmed, encryptedPrefix), encryptedSuffix))
+		if err != nil {
+			return "", fmt.Errorf("invalid encrypted value: %w", err)
+		}
+		src = bytes.NewReader(data)
+	case strings.HasPrefix(trimmed, armor.Header):
+		src = armor.NewReader(strings.NewReader(trimmed))
+	default:
+		data, err := base64.StdEncoding.DecodeString(trimmed)
+		if err != nil {
+			return "", fmt.Errorf("invalid encrypted value: %w", err)
+		}
+		src = bytes.NewReader(data)
+	}
+
+	r, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	mu.Lock()
+	decrypted[string(plaintext)] = value
+	mu.Unlock()
+	return string(plaintext), nil
+}
+
+// DefaultKeyFile возвращает путь к файлу ключей age по умолчанию
+func DefaultKeyFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ai-agents-cli", "age.key")
+}
+
+// LoadIdentities загружает ключи age. Если keyFile не задан, ключ берется
+// из AI_AGENTS_AGE_KEY, затем из файла AI_AGENTS_AGE_KEY_FILE, затем из
+// файла по умолчанию (~/.ai-agents-cli/age.key).
+func LoadIdentities(keyFile string) ([]age.Identity, error) {
+	if keyFile == "" {
+		if key := os.Getenv(KeyEnv); key != "" {
+			identities, err := age.ParseIdentities(strings.NewReader(key))
+			if err != nil {
+				return nil, fmt.Errorf("invalid age key in %s: %w", KeyEnv, err)
+			}
+			return identities, nil
+		}
+		keyFile = os.Getenv(KeyFileEnv)
+	}
+	if keyFile == "" {
+		keyFile = DefaultKeyFile()
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("age key not found: set %s or %s, or create %s", KeyEnv, KeyFileEnv, keyFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read age key file: %w", err)
+	}
+
+	identities, err := age.ParseIdentities(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid age key file %s: %w", keyFile, err)
+	}
+	return identities, nil
+}
+
+// ParseRecipients разбирает открытые ключи age (age1...)
+func ParseRecipients(values []string) ([]age.Recipient, error) {
+	recipients := make([]age.Recipient, 0, len(values))
+	for _, value := range values {
+		recipient, err := age.ParseX25519Recipient(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid age recipient '%s': %w", value, err)
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// RecipientsOf возвращает открытые ключи для ключей age X25519
+func RecipientsOf(identities []age.Identity) []age.Recipient {
+	var recipients []age.Recipient
+	for _, identity := range identities {
+		if x25519, ok := identity.(*age.X25519Identity); ok {
+			recipients = append(recipients, x25519.Recipient())
+		}
+	}
+	return recipients
+}
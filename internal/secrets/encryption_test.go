@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func newIdentity(t *testing.T) *age.X25519Identity {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	identity := newIdentity(t)
+
+	encrypted, err := Encrypt("sk-123", []age.Recipient{identity.Recipient()})
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, "sk-123") {
+		t.Fatalf("Unexpected encrypted value: %s", encrypted)
+	}
+
+	plaintext, err := Decrypt(encrypted, []age.Identity{identity})
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if plaintext != "sk-123" {
+		t.Errorf("Expected sk-123, got %q", plaintext)
+	}
+	if !IsSecret("sk-123") {
+		t.Error("Expected decrypted value to be tracked as secret")
+	}
+
+	if _, err := Decrypt(encrypted, []age.Identity{newIdentity(t)}); err == nil {
+		t.Error("Expected error for wrong key")
+	}
+}
+
+func TestLoadIdentities(t *testing.T) {
+	identity := newIdentity(t)
+
+	t.Setenv(KeyEnv, identity.String())
+	identities, err := LoadIdentities("")
+	if err != nil || len(identities) != 1 {
+		t.Fatalf("Expected key from %s, got %v, %v", KeyEnv, identities, err)
+	}
+
+	t.Setenv(KeyEnv, "")
+	t.Setenv(KeyFileEnv, filepath.Join(t.TempDir(), "missing.key"))
+	if _, err := LoadIdentities(""); err == nil {
+		t.Error("Expected error for missing key file")
+	}
+}
+
+func TestEncryptFile(t *testing.T) {
+	identity := newIdentity(t)
+	file := filepath.Join(t.TempDir(), "ai-agents.yaml")
+	content := `# Агенты проекта
+agents:
+  - name: assistant
+    options:
+      env:
+        rawEnvs:
+          API_KEY: sk-123 # ключ OpenAI
+          LEVEL: debug
+`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := EncryptFile(file, []string{"agents[0].options.env.rawEnvs.API_KEY"}, []age.Recipient{identity.Recipient()})
+	if err != nil || count != 1 {
+		t.Fatalf("EncryptFile failed: %d, %v", count, err)
+	}
+
+	data, _ := os.ReadFile(file)
+	encrypted := string(data)
+	for _, expected := range []string{"# Агенты проекта", "# ключ OpenAI", "API_KEY: ENC[age,data:", "LEVEL: debug"} {
+		if !strings.Contains(encrypted, expected) {
+			t.Errorf("Expected %q in encrypted file:\n%s", expected, encrypted)
+		}
+	}
+	if strings.Contains(encrypted, "sk-123") {
+		t.Errorf("Expected value to be encrypted:\n%s", encrypted)
+	}
+	if strings.Index(encrypted, "API_KEY") > strings.Index(encrypted, "LEVEL") {
+		t.Errorf("Expected key order to be preserved:\n%s", encrypted)
+	}
+
+	// Перешифрование для нового ключа
+	newKey := newIdentity(t)
+	if _, err := RotateFile(file, []age.Identity{identity}, []age.Recipient{newKey.Recipient()}); err != nil {
+		t.Fatalf("RotateFile failed: %v", err)
+	}
+	if _, err := DecryptFile(file, nil, []age.Identity{identity}); err == nil {
+		t.Error("Expected old key to stop working after rotation")
+	}
+
+	count, err = DecryptFile(file, nil, []age.Identity{newKey})
+	if err != nil || count != 1 {
+		t.Fatalf("DecryptFile failed: %d, %v", count, err)
+	}
+	data, _ = os.ReadFile(file)
+	if string(data) != content {
+		t.Errorf("Expected original file after decryption, got:\n%s", data)
+	}
+
+	if _, err := EncryptFile(file, []string{"agents[1].name"}, []age.Recipient{identity.Recipient()}); err == nil {
+		t.Error("Expected error for missing path")
+	}
+}
@@ -0,0 +1,279 @@
+// Package secrets разрешает ссылки на секреты в конфигурации:
+//   - secret://env/NAME - переменная окружения NAME;
+//   - secret://file/path - содержимое файла (относительный путь отсчитывается от
+//     директории файла конфигурации, см. WithBaseDir);
+//   - secret://exec/command args - вывод команды, например secret://exec/pass show openai.
+//
+// Ссылки разрешаются только при формировании запросов к API. Кроме того,
+// пакет шифрует и расшифровывает значения конфигурации ключами age
+// (см. Encrypt и Decrypt). Разрешенные и расшифрованные значения
+// запоминаются, чтобы Redact и маски плана могли скрыть их в выводе.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scheme - префикс ссылки на секрет
+const Scheme = "secret://"
+
+// redacted - замена значения секрета в отладочном выводе
+const redacted = "***"
+
+var (
+	mu sync.Mutex
+	// resolved - разрешенные значения по провайдеру и аргументу ссылки;
+	// команды exec выполняются один раз
+	resolved = map[string]string{}
+	// decrypted - зашифрованные значения конфигурации по расшифрованному значению
+	decrypted = map[string]string{}
+)
+
+// IsRef проверяет, является ли значение ссылкой на секрет
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// baseDirKey - ключ контекста с директорией файла конфигурации
+type baseDirKey struct{}
+
+// WithBaseDir возвращает контекст, в котором относительные пути secret://file
+// разрешаются относительно dir - директории файла конфигурации, как и !include
+func WithBaseDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, baseDirKey{}, dir)
+}
+
+// parseRef разбирает ссылку на провайдер и аргумент. Относительный путь
+// secret://file дополняется директорией конфигурации из ctx.
+func parseRef(ctx context.Context, ref string) (provider, arg string, err error) {
+	provider, arg, ok := strings.Cut(strings.TrimPrefix(ref, Scheme), "/")
+	if !IsRef(ref) || !ok || arg == "" {
+		return "", "", fmt.Errorf("invalid secret reference '%s', expected %s<env|file|exec>/<value>", ref, Scheme)
+	}
+
+	if dir, _ := ctx.Value(baseDirKey{}).(string); provider == "file" && dir != "" && !filepath.IsAbs(arg) {
+		arg = filepath.Join(dir, arg)
+	}
+	return provider, arg, nil
+}
+
+// Resolve возвращает значение секрета по ссылке
+func Resolve(ctx context.Context, ref string) (string, error) {
+	provider, arg, err := parseRef(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	key := provider + "/" + arg
+	mu.Lock()
+	value, ok := resolved[key]
+	mu.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	value, err = resolve(ctx, provider, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret %s: %w", ref, err)
+	}
+
+	mu.Lock()
+	resolved[key] = value
+	mu.Unlock()
+	return value, nil
+}
+
+// Resolved возвращает значение секрета, если ссылка уже была разрешена
+// в этом запуске. Сама ссылка не разрешается: команды не выполняются,
+// файлы не читаются.
+func Resolved(ctx context.Context, ref string) (string, bool) {
+	provider, arg, err := parseRef(ctx, ref)
+	if err != nil {
+		return "", false
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	value, ok := resolved[provider+"/"+arg]
+	return value, ok
+}
+
+func resolve(ctx context.Context, provider, arg string) (string, error) {
+	switch provider {
+	case "env":
+		value, ok := os.LookupEnv(arg)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", arg)
+		}
+		return value, nil
+
+	case "file":
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+
+	case "exec":
+		args := strings.Fields(arg)
+		if len(args) == 0 {
+			return "", fmt.Errorf("empty command")
+		}
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("%w: %s", err, msg)
+			}
+			return "", err
+		}
+		return strings.TrimRight(stdout.String(), "\r\n"), nil
+
+	default:
+		return "", fmt.Errorf("unknown secret provider '%s'", provider)
+	}
+}
+
+// ResolveMap возвращает копию словаря, в которой все строковые значения-ссылки
+// (в том числе во вложенных словарях и списках) заменены значениями секретов
+func ResolveMap(ctx context.Context, m map[string]interface{}) (map[string]interface{}, error) {
+	if m == nil {
+		return nil, nil
+	}
+	result, err := resolveValue(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]interface{}), nil
+}
+
+func resolveValue(ctx context.Context, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !IsRef(v) {
+			return v, nil
+		}
+		return Resolve(ctx, v)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolvedItem, err := resolveValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = resolvedItem
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			resolvedItem, err := resolveValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolvedItem
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// IsSecret проверяет, является ли значение разрешенным секретом или
+// расшифрованным значением конфигурации
+func IsSecret(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := decrypted[value]; ok {
+		return true
+	}
+	for _, secret := range resolved {
+		if secret == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Refs возвращает ссылки на секреты в словаре по пути к значению через точку,
+// например environmentOptions.rawEnvs.OPENAI_KEY. Для расшифрованных значений
+// возвращается исходное зашифрованное значение.
+func Refs(m map[string]interface{}) map[string]string {
+	refs := make(map[string]string)
+	collectRefs(m, "", refs)
+	if len(refs) == 0 {
+		return nil
+	}
+	return refs
+}
+
+func collectRefs(value interface{}, path string, refs map[string]string) {
+	switch v := value.(type) {
+	case string:
+		if IsRef(v) {
+			refs[path] = v
+			return
+		}
+		mu.Lock()
+		encrypted, ok := decrypted[v]
+		mu.Unlock()
+		if ok {
+			refs[path] = encrypted
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			if path != "" {
+				key = path + "." + key
+			}
+			collectRefs(item, key, refs)
+		}
+	}
+}
+
+// Mask возвращает замену значения секрета для вывода: короткий хеш значения.
+// Одинаковые значения дают одинаковую маску, поэтому маски можно сравнивать.
+func Mask(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "<secret sha256:" + hex.EncodeToString(sum[:])[:12] + ">"
+}
+
+// Redact заменяет в строке все разрешенные и расшифрованные значения секретов на ***
+func Redact(s string) string {
+	mu.Lock()
+	values := make([]string, 0, len(resolved)+len(decrypted))
+	for _, value := range resolved {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	for value := range decrypted {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	mu.Unlock()
+
+	// Сначала заменяются длинные значения, чтобы их части не остались в выводе
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		s = strings.ReplaceAll(s, value, redacted)
+	}
+	return s
+}
@@ -0,0 +1,113 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("AI_AGENTS_TEST_SECRET", "from-env")
+
+	file := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"secret://env/AI_AGENTS_TEST_SECRET": "from-env",
+		"secret://file/" + file:              "from-file",
+		"secret://exec/echo from-exec":       "from-exec",
+	}
+	for ref, expected := range tests {
+		value, err := Resolve(ctx, ref)
+		if err != nil {
+			t.Errorf("Resolve(%q) failed: %v", ref, err)
+			continue
+		}
+		if value != expected {
+			t.Errorf("Resolve(%q) = %q, expected %q", ref, value, expected)
+		}
+	}
+
+	for _, ref := range []string{"secret://env/AI_AGENTS_TEST_UNDEFINED", "secret://vault/key", "secret://env"} {
+		if _, err := Resolve(ctx, ref); err == nil {
+			t.Errorf("Expected error for %q", ref)
+		}
+	}
+}
+
+func TestResolve_BaseDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".secrets"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".secrets", "token"), []byte("from-config-dir\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Относительный путь отсчитывается от директории конфигурации, а не от рабочей
+	ctx := WithBaseDir(context.Background(), dir)
+	ref := "secret://file/.secrets/token"
+	if _, ok := Resolved(ctx, ref); ok {
+		t.Fatal("Expected reference not to be resolved yet")
+	}
+
+	value, err := Resolve(ctx, ref)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if value != "from-config-dir" {
+		t.Errorf("Expected value from config directory, got %q", value)
+	}
+
+	if cached, ok := Resolved(ctx, ref); !ok || cached != value {
+		t.Errorf("Expected resolved value to be cached, got %q, %v", cached, ok)
+	}
+	// Та же ссылка из другой директории - другой файл
+	if _, ok := Resolved(WithBaseDir(context.Background(), t.TempDir()), ref); ok {
+		t.Error("Expected reference from another directory not to be cached")
+	}
+}
+
+func TestResolveMap(t *testing.T) {
+	t.Setenv("AI_AGENTS_TEST_API_KEY", "sk-123")
+
+	m := map[string]interface{}{
+		"rawEnvs": map[string]interface{}{
+			"API_KEY": "secret://env/AI_AGENTS_TEST_API_KEY",
+			"LEVEL":   "debug",
+		},
+	}
+	resolved, err := ResolveMap(context.Background(), m)
+	if err != nil {
+		t.Fatalf("ResolveMap failed: %v", err)
+	}
+	if got := resolved["rawEnvs"].(map[string]interface{})["API_KEY"]; got != "sk-123" {
+		t.Errorf("Expected resolved secret, got %v", got)
+	}
+	if got := m["rawEnvs"].(map[string]interface{})["API_KEY"]; got != "secret://env/AI_AGENTS_TEST_API_KEY" {
+		t.Errorf("Expected source map to stay unchanged, got %v", got)
+	}
+
+	refs := Refs(m)
+	if len(refs) != 1 || refs["rawEnvs.API_KEY"] != "secret://env/AI_AGENTS_TEST_API_KEY" {
+		t.Errorf("Unexpected refs: %v", refs)
+	}
+
+	if got := Redact(`{"API_KEY": "sk-123"}`); strings.Contains(got, "sk-123") {
+		t.Errorf("Expected secret to be redacted, got %s", got)
+	}
+}
+
+func TestMask(t *testing.T) {
+	if Mask("a") != Mask("a") || Mask("a") == Mask("b") {
+		t.Error("Expected equal masks for equal values only")
+	}
+	if strings.Contains(Mask("sk-123"), "sk-123") {
+		t.Error("Mask must not contain the value")
+	}
+}
@@ -0,0 +1,207 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	"gopkg.in/yaml.v3"
+)
+
+// EncryptFile шифрует значения YAML файла по путям вида
+// agents[0].options.env.rawEnvs.API_KEY и записывает файл на место,
+// сохраняя комментарии и порядок ключей. Возвращает количество
+// зашифрованных значений.
+func EncryptFile(file string, paths []string, recipients []age.Recipient) (int, error) {
+	return editFile(file, func(doc *yaml.Node) (int, error) {
+		for _, path := range paths {
+			node, err := findPath(doc, path)
+			if err != nil {
+				return 0, err
+			}
+			if node.Kind != yaml.ScalarNode {
+				return 0, fmt.Errorf("%s: only scalar values can be encrypted", path)
+			}
+			if isEncryptedNode(node) {
+				return 0, fmt.Errorf("%s: value is already encrypted", path)
+			}
+
+			encrypted, err := Encrypt(node.Value, recipients)
+			if err != nil {
+				return 0, fmt.Errorf("%s: %w", path, err)
+			}
+			node.Value, node.Tag, node.Style = encrypted, "", 0
+		}
+		return len(paths), nil
+	})
+}
+
+// DecryptFile расшифровывает значения YAML файла по путям, а если пути
+// не заданы - все зашифрованные значения, и записывает файл на место
+func DecryptFile(file string, paths []string, identities []age.Identity) (int, error) {
+	return editFile(file, func(doc *yaml.Node) (int, error) {
+		nodes, err := encryptedNodes(doc, paths)
+		if err != nil {
+			return 0, err
+		}
+		for _, node := range nodes {
+			plaintext, err := Decrypt(node.Value, identities)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %w", node.Line, err)
+			}
+			node.Value, node.Tag, node.Style = plaintext, "!!str", 0
+		}
+		return len(nodes), nil
+	})
+}
+
+// RotateFile перешифровывает все зашифрованные значения YAML файла для новых
+// получателей и записывает файл на место
+func RotateFile(file string, identities []age.Identity, recipients []age.Recipient) (int, error) {
+	return editFile(file, func(doc *yaml.Node) (int, error) {
+		nodes, err := encryptedNodes(doc, nil)
+		if err != nil {
+			return 0, err
+		}
+		for _, node := range nodes {
+			plaintext, err := Decrypt(node.Value, identities)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %w", node.Line, err)
+			}
+			encrypted, err := Encrypt(plaintext, recipients)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %w", node.Line, err)
+			}
+			node.Value, node.Tag, node.Style = encrypted, "", 0
+		}
+		return len(nodes), nil
+	})
+}
+
+// editFile читает YAML файл, изменяет его дерево и записывает обратно,
+// если изменено хотя бы одно значение
+func editFile(file string, edit func(doc *yaml.Node) (int, error)) (int, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file: %w", err)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return 0, fmt.Errorf("failed to parse YAML file %s: %w", file, err)
+	}
+	if doc.Kind == 0 {
+		return 0, fmt.Errorf("YAML file %s is empty", file)
+	}
+
+	count, err := edit(&doc)
+	if err != nil || count == 0 {
+		return count, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return 0, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return 0, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	if err := os.WriteFile(file, buf.Bytes(), info.Mode().Perm()); err != nil {
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+	return count, nil
+}
+
+// encryptedNodes возвращает зашифрованные значения по путям, а если пути
+// не заданы - все зашифрованные значения документа
+func encryptedNodes(doc *yaml.Node, paths []string) ([]*yaml.Node, error) {
+	if len(paths) == 0 {
+		var nodes []*yaml.Node
+		collectEncrypted(doc, &nodes)
+		return nodes, nil
+	}
+
+	nodes := make([]*yaml.Node, 0, len(paths))
+	for _, path := range paths {
+		node, err := findPath(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !isEncryptedNode(node) {
+			return nil, fmt.Errorf("%s: value is not encrypted", path)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func collectEncrypted(node *yaml.Node, nodes *[]*yaml.Node) {
+	if isEncryptedNode(node) {
+		*nodes = append(*nodes, node)
+		return
+	}
+	for _, child := range node.Content {
+		collectEncrypted(child, nodes)
+	}
+}
+
+// isEncryptedNode проверяет, содержит ли узел зашифрованное значение
+func isEncryptedNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && (node.Tag == EncryptedTag || IsEncrypted(node.Value))
+}
+
+// findPath находит узел по пути вида agents[0].options.env.rawEnvs.API_KEY
+func findPath(doc *yaml.Node, path string) (*yaml.Node, error) {
+	node := doc
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if key != "" {
+			child := mappingValue(node, key)
+			if child == nil {
+				return nil, fmt.Errorf("%s: key '%s' not found", path, key)
+			}
+			node = child
+		}
+
+		for rest != "" {
+			index, tail, ok := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(index)
+			if !ok || err != nil {
+				return nil, fmt.Errorf("%s: invalid index in '%s'", path, segment)
+			}
+			if node.Kind != yaml.SequenceNode || i < 0 || i >= len(node.Content) {
+				return nil, fmt.Errorf("%s: index %d out of range", path, i)
+			}
+			node = node.Content[i]
+			rest = strings.TrimPrefix(tail, "[")
+		}
+	}
+	return node, nil
+}
+
+// mappingValue возвращает значение ключа словаря YAML
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Package state хранит локальный файл состояния развертывания: соответствие
+// ресурсов конфигурации их ID в проекте и хеш последней примененной спецификации.
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultPath - путь к файлу состояния по умолчанию
+const DefaultPath = ".ai-agents/state.json"
+
+// PathForEnv возвращает путь к файлу состояния окружения по умолчанию.
+// Окружения развертываются в разные проекты, поэтому у каждого свой файл.
+func PathForEnv(env string) string {
+	if env == "" {
+		return DefaultPath
+	}
+	return filepath.Join(filepath.Dir(DefaultPath), "state."+env+".json")
+}
+
+// currentVersion - версия формата файла состояния
+const currentVersion = 1
+
+// Resource - запись о ресурсе, развернутом из конфигурации
+type Resource struct {
+	// Kind - тип ресурса: mcp-server, agent или agent-system
+	Kind string `json:"kind"`
+	// Key - стабильный ключ ресурса в конфигурации (поле key или имя)
+	Key string `json:"key"`
+	// Name - имя ресурса при последнем развертывании
+	Name string `json:"name"`
+	ID   string `json:"id"`
+	// SpecHash - хеш последней примененной спецификации из конфигурации
+	SpecHash string `json:"specHash,omitempty"`
+	// Secrets - ссылки на секреты (secret://...) по пути к значению в ресурсе.
+	// Сами значения секретов в файл состояния не записываются.
+	Secrets map[string]string `json:"secrets,omitempty"`
+	// SecretHashes - маски (secrets.Mask) значений секретов, примененных при
+	// последнем развертывании, по тем же путям, что и Secrets. План сравнивает
+	// с ними значения в проекте, не разрешая ссылки.
+	SecretHashes map[string]string `json:"secretHashes,omitempty"`
+	AppliedAt    time.Time         `json:"appliedAt"`
+}
+
+// State - содержимое файла состояния
+type State struct {
+	Version   int        `json:"version"`
+	Resources []Resource `json:"resources"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// Load читает файл состояния. Если файла нет, возвращает пустое состояние,
+// которое будет записано по этому пути при сохранении.
+func Load(path string) (*State, error) {
+	s := &State{Version: currentVersion, path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if s.Version > currentVersion {
+		return nil, fmt.Errorf("state file %s has version %d, this CLI supports up to %d", path, s.Version, currentVersion)
+	}
+	s.Version = currentVersion
+	return s, nil
+}
+
+// Path возвращает путь к файлу состояния
+func (s *State) Path() string {
+	return s.path
+}
+
+// Save записывает состояние в файл. Запись выполняется через временный
+// файл, чтобы прерванный процесс не оставил поврежденное состояние.
+func (s *State) Save() error {
+	s.mu.Lock()
+	sortResources(s.Resources)
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// Get возвращает запись о ресурсе по типу и ключу
+func (s *State) Get(kind, key string) (Resource, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.index(kind, key); i >= 0 {
+		return s.Resources[i], true
+	}
+	return Resource{}, false
+}
+
+// Set добавляет или заменяет запись о ресурсе
+func (s *State) Set(resource Resource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.index(resource.Kind, resource.Key); i >= 0 {
+		s.Resources[i] = resource
+		return
+	}
+	s.Resources = append(s.Resources, resource)
+}
+
+// Remove удаляет запись о ресурсе и сообщает, была ли она найдена
+func (s *State) Remove(kind, key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(kind, key)
+	if i < 0 {
+		return false
+	}
+	s.Resources = append(s.Resources[:i], s.Resources[i+1:]...)
+	return true
+}
+
+// List возвращает копию всех записей, отсортированную по типу и ключу
+func (s *State) List() []Resource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resources := append([]Resource(nil), s.Resources...)
+	sortResources(resources)
+	return resources
+}
+
+// sortResources сортирует записи по типу и ключу
+func sortResources(resources []Resource) {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return resources[i].Kind < resources[j].Kind
+		}
+		return resources[i].Key < resources[j].Key
+	})
+}
+
+func (s *State) index(kind, key string) int {
+	for i, resource := range s.Resources {
+		if resource.Kind == kind && resource.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// HashSpec возвращает хеш спецификации ресурса. Спецификация сериализуется
+// в JSON, поэтому порядок ключей словарей на хеш не влияет.
+func HashSpec(spec interface{}) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,92 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ai-agents", "state.json")
+
+	st, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(st.List()) != 0 {
+		t.Errorf("Expected empty state, got %v", st.List())
+	}
+	if st.Path() != path {
+		t.Errorf("Expected path %s, got %s", path, st.Path())
+	}
+}
+
+func TestState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ai-agents", "state.json")
+	applied := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	st, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	st.Set(Resource{Kind: "agent", Key: "router", Name: "router", ID: "a2", AppliedAt: applied})
+	st.Set(Resource{Kind: "agent", Key: "assistant", Name: "assistant", ID: "a1", AppliedAt: applied})
+	st.Set(Resource{Kind: "agent", Key: "assistant", Name: "assistant-v2", ID: "a1", AppliedAt: applied})
+	if err := st.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	resources := loaded.List()
+	if len(resources) != 2 || resources[0].Key != "assistant" || resources[1].Key != "router" {
+		t.Fatalf("Unexpected resources: %+v", resources)
+	}
+	if resources[0].Name != "assistant-v2" || !resources[0].AppliedAt.Equal(applied) {
+		t.Errorf("Expected replaced entry, got %+v", resources[0])
+	}
+
+	if !loaded.Remove("agent", "router") {
+		t.Error("Expected router to be removed")
+	}
+	if loaded.Remove("agent", "router") {
+		t.Error("Expected second remove to report missing entry")
+	}
+	if _, ok := loaded.Get("agent", "router"); ok {
+		t.Error("Expected router to be absent after remove")
+	}
+}
+
+func TestLoad_NewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "resources": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "version 99") {
+		t.Errorf("Expected version error, got %v", err)
+	}
+}
+
+func TestHashSpec(t *testing.T) {
+	first, err := HashSpec(map[string]interface{}{"name": "a", "options": map[string]interface{}{"x": 1, "y": 2}})
+	if err != nil {
+		t.Fatalf("HashSpec failed: %v", err)
+	}
+	second, _ := HashSpec(map[string]interface{}{"options": map[string]interface{}{"y": 2, "x": 1}, "name": "a"})
+	other, _ := HashSpec(map[string]interface{}{"name": "b"})
+
+	if first != second {
+		t.Errorf("Expected equal hashes for equal specs, got %s and %s", first, second)
+	}
+	if first == other {
+		t.Error("Expected different hashes for different specs")
+	}
+	if !strings.HasPrefix(first, "sha256:") {
+		t.Errorf("Expected sha256 prefix, got %s", first)
+	}
+}
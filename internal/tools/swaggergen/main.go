@@ -0,0 +1,50 @@
+// Команда swaggergen генерирует модели, перечисления и операции API клиента
+// (internal/api) из спецификации service.swagger.json.
+//
+// Использование:
+//
+//	go run ./internal/tools/swaggergen -spec service.swagger.json -out internal/api
+//
+// В пакете api генерация запускается через go generate.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+)
+
+func main() {
+	specPath := flag.String("spec", "service.swagger.json", "путь к спецификации Swagger 2.0")
+	outDir := flag.String("out", "internal/api", "директория пакета, в которую записываются сгенерированные файлы")
+	pkg := flag.String("package", "api", "имя пакета сгенерированных файлов")
+	flag.Parse()
+
+	if err := run(*specPath, *outDir, *pkg); err != nil {
+		log.Fatal("Failed to generate API client", "error", err)
+	}
+}
+
+func run(specPath, outDir, pkg string) error {
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	files, err := generate(spec, pkg, filepath.Base(specPath))
+	if err != nil {
+		return err
+	}
+
+	for name, data := range files {
+		path := filepath.Join(outDir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		log.Info("Generated", "file", path)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGeneratedFilesUpToDate проверяет, что файлы пакета api совпадают с
+// результатом генерации из текущей спецификации
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	spec, err := loadSpec("../../../service.swagger.json")
+	if err != nil {
+		t.Fatalf("loadSpec() error = %v", err)
+	}
+
+	files, err := generate(spec, "api", "service.swagger.json")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join("../../api", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate ./internal/api", name)
+		}
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	tests := map[string]string{
+		"agentAgent":                            "Agent",
+		"agent_systemAgentSystem":               "AgentSystem",
+		"commonPublicUrlCors":                   "PublicURLCORS",
+		"AgentManagementServiceCreateAgentBody": "CreateAgentRequest",
+		"entitiesagentAgentSystemPreview":       "AgentSystemRef",
+	}
+	for definition, want := range tests {
+		if got := typeName(definition); got != want {
+			t.Errorf("typeName(%q) = %q, want %q", definition, got, want)
+		}
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := map[string]string{
+		"agentId":     "agentID",
+		"mcpServerId": "mcpServerID",
+		"limit":       "limit",
+	}
+	for name, want := range tests {
+		if got := paramName(name); got != want {
+			t.Errorf("paramName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestEnumPrefix(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"AGENT_STATUS_RUNNING", "AGENT_STATUS_FAILED"}, "AGENT_STATUS_"},
+		{[]string{"AGENT_STATUS_RUNNING", "AGENT_STATUS_LLM_UNAVAILABLE"}, "AGENT_STATUS_"},
+		// Значение целиком не отбрасывается как префикс
+		{[]string{"NONE", "NONE_OTHER"}, ""},
+		{[]string{"ONLY"}, ""},
+	}
+	for _, tt := range tests {
+		if got := enumPrefix(tt.values); got != tt.want {
+			t.Errorf("enumPrefix(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// generator генерирует Go код для одной спецификации
+type generator struct {
+	spec *Spec
+	pkg  string
+	// source - имя файла спецификации для заголовка сгенерированных файлов
+	source string
+}
+
+// generate возвращает содержимое сгенерированных файлов по именам
+func generate(spec *Spec, pkg, source string) (map[string][]byte, error) {
+	g := &generator{spec: spec, pkg: pkg, source: source}
+
+	files := make(map[string][]byte)
+	for name, gen := range map[string]func(*bytes.Buffer) error{
+		"models_gen.go":     g.models,
+		"operations_gen.go": g.operations,
+	} {
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "// Code generated by swaggergen from %s. DO NOT EDIT.\n\n", g.source)
+		fmt.Fprintf(&buf, "package %s\n\n", g.pkg)
+		if err := gen(&buf); err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", name, err)
+		}
+
+		data, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %w\n%s", name, err, buf.String())
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+// definitionNames возвращает имена определений в порядке Go имен
+func (g *generator) definitionNames() []string {
+	names := make([]string, 0, len(g.spec.Definitions))
+	for name := range g.spec.Definitions {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return typeName(names[i]) < typeName(names[j])
+	})
+	return names
+}
+
+// models генерирует типы для определений спецификации
+func (g *generator) models(buf *bytes.Buffer) error {
+	seen := make(map[string]string)
+	for _, name := range g.definitionNames() {
+		goName := typeName(name)
+		if other, ok := seen[goName]; ok {
+			return fmt.Errorf("definitions %s and %s have the same Go name %s, add one of them to typeNames", other, name, goName)
+		}
+		seen[goName] = name
+
+		schema := g.spec.Definitions[name]
+		switch {
+		case schema.isEnum():
+			g.enum(buf, name, goName, schema)
+		case schema.isMap():
+			writeDoc(buf, "", goName, schema.doc(), name)
+			fmt.Fprintf(buf, "type %s map[string]interface{}\n\n", goName)
+		default:
+			if err := g.object(buf, name, goName, schema); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// enum генерирует строковый тип перечисления, константы и список значений
+func (g *generator) enum(buf *bytes.Buffer, name, goName string, schema *Schema) {
+	writeDoc(buf, "", goName, schema.doc(), name)
+	fmt.Fprintf(buf, "type %s string\n\n", goName)
+
+	prefix := enumPrefix(schema.Enum)
+	buf.WriteString("const (\n")
+	for _, value := range schema.Enum {
+		fmt.Fprintf(buf, "\t%s%s %s = %q\n", goName, constName(strings.TrimPrefix(value, prefix)), goName, value)
+	}
+	buf.WriteString(")\n\n")
+
+	fmt.Fprintf(buf, "// %sValues - все значения %s в порядке спецификации\n", goName, goName)
+	fmt.Fprintf(buf, "var %sValues = []%s{\n", goName, goName)
+	for _, value := range schema.Enum {
+		fmt.Fprintf(buf, "\t%s%s,\n", goName, constName(strings.TrimPrefix(value, prefix)))
+	}
+	buf.WriteString("}\n\n")
+}
+
+// object генерирует структуру для определения с полями
+func (g *generator) object(buf *bytes.Buffer, name, goName string, schema *Schema) error {
+	writeDoc(buf, "", goName, schema.doc(), name)
+	fmt.Fprintf(buf, "type %s struct {\n", goName)
+	for _, property := range schema.propertyNames() {
+		field := schema.Properties[property]
+		fieldType, err := g.goType(field)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, property, err)
+		}
+
+		if doc := field.doc(); doc != "" {
+			fmt.Fprintf(buf, "\t// %s\n", doc)
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:\"%s,%s\"`\n", fieldName(property), fieldType, property, g.omit(field))
+	}
+	buf.WriteString("}\n\n")
+	return nil
+}
+
+// goType возвращает Go тип для схемы свойства или параметра
+func (g *generator) goType(schema *Schema) (string, error) {
+	if schema.Ref != "" {
+		if _, ok := g.spec.Definitions[schema.refName()]; !ok {
+			return "", fmt.Errorf("unknown definition %s", schema.Ref)
+		}
+		return typeName(schema.refName()), nil
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			return "CustomTime", nil
+		}
+		if len(schema.Enum) > 0 {
+			if enum := g.enumByValues(schema.Enum); enum != "" {
+				return enum, nil
+			}
+		}
+		return "string", nil
+	case "integer":
+		// Целые числа генерируются как int, как в остальных моделях CLI
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if schema.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := g.goType(schema.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if schema.AdditionalProperties != nil {
+			value, err := g.goType(schema.AdditionalProperties)
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + value, nil
+		}
+		return "map[string]interface{}", nil
+	}
+	return "", fmt.Errorf("unsupported schema type %q", schema.Type)
+}
+
+// omit возвращает опцию JSON тега: структуры пропускаются по нулевому
+// значению (omitzero), остальные значения - по пустому (omitempty)
+func (g *generator) omit(schema *Schema) string {
+	if schema.Type == "string" && schema.Format == "date-time" {
+		return "omitzero"
+	}
+	if schema.Ref != "" {
+		definition := g.spec.Definitions[schema.refName()]
+		if !definition.isEnum() && !definition.isMap() {
+			return "omitzero"
+		}
+	}
+	return "omitempty"
+}
+
+// enumByValues возвращает перечисление из определений с теми же значениями.
+// Параметры запросов описывают перечисления списком значений без ссылки.
+func (g *generator) enumByValues(values []string) string {
+	want := strings.Join(values, ",")
+	for _, name := range g.definitionNames() {
+		if definition := g.spec.Definitions[name]; definition.isEnum() && strings.Join(definition.Enum, ",") == want {
+			return typeName(name)
+		}
+	}
+	return ""
+}
+
+// writeDoc пишет комментарий к типу или функции с ссылкой на спецификацию
+func writeDoc(buf *bytes.Buffer, indent, name, doc, ref string) {
+	if doc != "" {
+		fmt.Fprintf(buf, "%s// %s - %s (%s)\n", indent, name, doc, ref)
+		return
+	}
+	fmt.Fprintf(buf, "%s// %s - %s\n", indent, name, ref)
+}
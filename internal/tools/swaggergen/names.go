@@ -0,0 +1,148 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// typeNames - имена типов, которые не выводятся из имени определения
+// однозначно: одинаковые имена сущностей в разных protobuf пакетах
+var typeNames = map[string]string{
+	// Система агентов, в которую входит агент (agentAgent.usedInAgentSystems)
+	"entitiesagentAgentSystemPreview": "AgentSystemRef",
+	// MCP сервер, подключенный к агенту (agentAgent.mcpServers)
+	"agentAgentsMCPServer": "AgentMCPServer",
+	"rpcStatus":            "RPCStatus",
+	"protobufAny":          "ProtobufAny",
+}
+
+// bodyName - тело запроса операции: AgentManagementServiceCreateAgentBody
+var bodyName = regexp.MustCompile(`^[A-Za-z]+Service([A-Z][A-Za-z]+)Body$`)
+
+// packagePrefix - имя protobuf пакета в начале имени определения: agent_system, contracts
+var packagePrefix = regexp.MustCompile(`^[a-z_]+`)
+
+// typeName возвращает имя Go типа для определения. Имя protobuf пакета
+// отбрасывается (agentAgent -> Agent), тело запроса операции называется
+// по операции (AgentManagementServiceCreateAgentBody -> CreateAgentRequest),
+// сокращения пишутся заглавными (commonPublicUrlCors -> PublicURLCORS).
+func typeName(definition string) string {
+	if name, ok := typeNames[definition]; ok {
+		return name
+	}
+	if m := bodyName.FindStringSubmatch(definition); m != nil {
+		return m[1] + "Request"
+	}
+	return fieldName(packagePrefix.ReplaceAllString(definition, ""))
+}
+
+// initialisms - слова, которые в Go именах пишутся заглавными буквами
+var initialisms = map[string]string{
+	"Id":   "ID",
+	"Ids":  "IDs",
+	"Url":  "URL",
+	"Uri":  "URI",
+	"Mcp":  "MCP",
+	"Llm":  "LLM",
+	"Rps":  "RPS",
+	"Cpu":  "CPU",
+	"Ram":  "RAM",
+	"Ml":   "ML",
+	"Ar":   "AR",
+	"Cors": "CORS",
+}
+
+// fieldName возвращает имя Go поля для JSON имени: mcpServerId -> MCPServerID
+func fieldName(jsonName string) string {
+	var words []string
+	var word []rune
+	for _, r := range jsonName {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			// @type и подобные имена
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return joinWords(words)
+}
+
+// constName возвращает суффикс имени константы перечисления:
+// ON_RESOURCE_ALLOCATION -> OnResourceAllocation
+func constName(value string) string {
+	return joinWords(strings.Split(strings.ToLower(value), "_"))
+}
+
+// joinWords соединяет слова в Go имя с учетом initialisms
+func joinWords(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		runes := []rune(word)
+		word = string(unicode.ToUpper(runes[0])) + string(runes[1:])
+		// Слова из заглавных букв (MCPServer) уже записаны как initialism
+		if upper, ok := initialisms[word]; ok {
+			word = upper
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// paramName возвращает имя параметра функции: agentId -> agentID,
+// mcpServerId -> mcpServerID
+func paramName(jsonName string) string {
+	runes := []rune(fieldName(jsonName))
+	// Заглавные буквы в начале имени, кроме первой буквы следующего слова
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	if n > 1 && n < len(runes) {
+		n--
+	}
+	for i := 0; i < n || i == 0; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// enumPrefix возвращает общий префикс значений перечисления из целых слов:
+// AGENT_STATUS_ для AGENT_STATUS_RUNNING и AGENT_STATUS_FAILED
+func enumPrefix(values []string) string {
+	if len(values) < 2 {
+		return ""
+	}
+	var prefix []string
+	for i, value := range values {
+		// Значение целиком не может быть префиксом
+		words := strings.Split(value, "_")
+		words = words[:len(words)-1]
+		if i == 0 {
+			prefix = words
+			continue
+		}
+		n := 0
+		for n < len(prefix) && n < len(words) && prefix[n] == words[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	if len(prefix) == 0 {
+		return ""
+	}
+	return strings.Join(prefix, "_") + "_"
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// projectParam - параметр пути с проектом, который берется из клиента
+const projectParam = "projectId"
+
+// operation - операция спецификации с вычисленными Go именами
+type operation struct {
+	*Operation
+	method  string
+	path    string
+	service string
+	name    string
+}
+
+// pathParam - параметр в пути запроса: {agentId}
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// collectOperations возвращает операции, сгруппированные по сервисам
+func (g *generator) collectOperations() (map[string][]*operation, error) {
+	services := make(map[string][]*operation)
+	for path, methods := range g.spec.Paths {
+		for method, op := range methods {
+			service, name, ok := strings.Cut(op.OperationID, "_")
+			if !ok {
+				return nil, fmt.Errorf("operation %s %s: operationId %q has no service prefix", method, path, op.OperationID)
+			}
+			services[service] = append(services[service], &operation{
+				Operation: op,
+				method:    strings.ToUpper(method),
+				path:      path,
+				service:   service,
+				name:      name,
+			})
+		}
+	}
+	for _, ops := range services {
+		sort.Slice(ops, func(i, j int) bool { return ops[i].name < ops[j].name })
+	}
+	return services, nil
+}
+
+// operations генерирует сервисы с методами операций спецификации
+func (g *generator) operations(buf *bytes.Buffer) error {
+	services, err := g.collectOperations()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var code bytes.Buffer
+	for _, service := range names {
+		fmt.Fprintf(&code, "// %s - операции %s\n", service, service)
+		fmt.Fprintf(&code, "type %s struct {\n\tclient *Client\n}\n\n", service)
+		fmt.Fprintf(&code, "// New%s создает сервис операций %s\n", service, service)
+		fmt.Fprintf(&code, "func New%s(client *Client) *%s {\n\treturn &%s{client: client}\n}\n\n", service, service, service)
+
+		for _, op := range services[service] {
+			if err := g.operation(&code, op); err != nil {
+				return fmt.Errorf("operation %s: %w", op.OperationID, err)
+			}
+		}
+	}
+
+	// Импортируем только пакеты, которые использует сгенерированный код
+	buf.WriteString("import (\n")
+	for _, pkg := range []string{"context", "fmt", "net/http", "net/url", "strconv"} {
+		if bytes.Contains(code.Bytes(), []byte(pkg[strings.LastIndex(pkg, "/")+1:]+".")) {
+			fmt.Fprintf(buf, "%q\n", pkg)
+		}
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(code.Bytes())
+	return nil
+}
+
+// operation генерирует метод операции и структуру ее query параметров
+func (g *generator) operation(buf *bytes.Buffer, op *operation) error {
+	var args []string
+	var pathArgs []string
+	var query []*Parameter
+	var body *Parameter
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			if param.Name == projectParam {
+				continue
+			}
+			args = append(args, paramName(param.Name)+" string")
+		case "query":
+			query = append(query, param)
+		case "body":
+			body = param
+		default:
+			return fmt.Errorf("unsupported parameter %s in %s", param.Name, param.In)
+		}
+	}
+
+	// Аргументы пути в порядке появления в пути запроса
+	format := pathParam.ReplaceAllStringFunc(op.path, func(m string) string {
+		name := m[1 : len(m)-1]
+		if name == projectParam {
+			pathArgs = append(pathArgs, "url.PathEscape(s.client.projectID)")
+		} else {
+			pathArgs = append(pathArgs, "url.PathEscape("+paramName(name)+")")
+		}
+		return "%s"
+	})
+
+	paramsType := op.name + "Params"
+	if len(query) > 0 {
+		if err := g.params(buf, paramsType, op.name, query); err != nil {
+			return err
+		}
+		args = append(args, "params *"+paramsType)
+	}
+
+	var bodyType string
+	if body != nil {
+		if body.Schema == nil || body.Schema.Ref == "" {
+			return fmt.Errorf("body parameter without schema reference")
+		}
+		bodyType = typeName(body.Schema.refName())
+		args = append(args, "body *"+bodyType)
+	}
+
+	response, ok := op.Responses["200"]
+	if !ok || response.Schema == nil || response.Schema.Ref == "" {
+		return fmt.Errorf("no 200 response with schema reference")
+	}
+	resultType := typeName(response.Schema.refName())
+
+	writeDoc(buf, "", op.name, docLine(op.Summary, op.Description), op.method+" "+op.path)
+	fmt.Fprintf(buf, "func (s *%s) %s(ctx context.Context", op.service, op.name)
+	for _, arg := range args {
+		buf.WriteString(", " + arg)
+	}
+	fmt.Fprintf(buf, ") (*%s, error) {\n", resultType)
+
+	if len(pathArgs) > 0 {
+		fmt.Fprintf(buf, "path := fmt.Sprintf(%q, %s)\n", format, strings.Join(pathArgs, ", "))
+	} else {
+		fmt.Fprintf(buf, "path := %q\n", format)
+	}
+	if body != nil {
+		fmt.Fprintf(buf, "if body == nil {\nbody = &%s{}\n}\n", bodyType)
+	}
+
+	fmt.Fprintf(buf, "\nvar result %s\n", resultType)
+	fmt.Fprintf(buf, "err := s.client.Do(ctx, RequestOptions{\nMethod: %s,\nPath: path,\n", methodConst(op.method))
+	if len(query) > 0 {
+		buf.WriteString("Query: params.values(),\n")
+	}
+	if body != nil {
+		buf.WriteString("Body: body,\n")
+	}
+	if op.method == http.MethodPatch {
+		// PATCH в этом API заменяет ресурс или переключает его состояние
+		// целиком, поэтому запрос можно безопасно повторить
+		buf.WriteString("Retry: true,\n")
+	}
+	buf.WriteString("}, &result)\nif err != nil {\nreturn nil, err\n}\nreturn &result, nil\n}\n\n")
+	return nil
+}
+
+// params генерирует структуру query параметров операции и ее кодирование
+func (g *generator) params(buf *bytes.Buffer, typ, opName string, query []*Parameter) error {
+	type field struct {
+		param *Parameter
+		name  string
+		typ   string
+	}
+	fields := make([]field, 0, len(query))
+	for _, param := range query {
+		typ, err := g.goType(&Schema{Type: param.Type, Format: param.Format, Items: param.Items, Enum: param.Enum})
+		if err != nil {
+			return fmt.Errorf("query parameter %s: %w", param.Name, err)
+		}
+		fields = append(fields, field{param: param, name: fieldName(param.Name), typ: typ})
+	}
+
+	fmt.Fprintf(buf, "// %s - query параметры %s\n", typ, opName)
+	fmt.Fprintf(buf, "type %s struct {\n", typ)
+	for _, f := range fields {
+		if doc := docLine(f.param.Description); doc != "" {
+			fmt.Fprintf(buf, "\t// %s\n", doc)
+		}
+		fmt.Fprintf(buf, "\t%s %s\n", f.name, f.typ)
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "// values возвращает заданные параметры. Списки передаются\n// повторением параметра: statuses=A&statuses=B\n")
+	fmt.Fprintf(buf, "func (p *%s) values() url.Values {\n", typ)
+	buf.WriteString("values := url.Values{}\nif p == nil {\nreturn values\n}\n")
+	for _, f := range fields {
+		name := f.param.Name
+		switch {
+		case f.typ == "string":
+			fmt.Fprintf(buf, "if p.%s != \"\" {\nvalues.Set(%q, p.%s)\n}\n", f.name, name, f.name)
+		case f.typ == "int":
+			fmt.Fprintf(buf, "if p.%s != 0 {\nvalues.Set(%q, strconv.Itoa(p.%s))\n}\n", f.name, name, f.name)
+		case f.typ == "bool":
+			fmt.Fprintf(buf, "if p.%s {\nvalues.Set(%q, \"true\")\n}\n", f.name, name)
+		case f.typ == "[]string":
+			fmt.Fprintf(buf, "for _, v := range p.%s {\nvalues.Add(%q, v)\n}\n", f.name, name)
+		case strings.HasPrefix(f.typ, "[]") && g.isEnumType(f.typ[2:]):
+			fmt.Fprintf(buf, "for _, v := range p.%s {\nvalues.Add(%q, string(v))\n}\n", f.name, name)
+		case strings.HasPrefix(f.typ, "[]int"):
+			fmt.Fprintf(buf, "for _, v := range p.%s {\nvalues.Add(%q, strconv.Itoa(v))\n}\n", f.name, name)
+		case g.isEnumType(f.typ):
+			fmt.Fprintf(buf, "if p.%s != \"\" {\nvalues.Set(%q, string(p.%s))\n}\n", f.name, name, f.name)
+		default:
+			return fmt.Errorf("query parameter %s: unsupported type %s", name, f.typ)
+		}
+	}
+	buf.WriteString("return values\n}\n\n")
+	return nil
+}
+
+// isEnumType проверяет, что Go тип - сгенерированное перечисление
+func (g *generator) isEnumType(goName string) bool {
+	for name, definition := range g.spec.Definitions {
+		if definition.isEnum() && typeName(name) == goName {
+			return true
+		}
+	}
+	return false
+}
+
+// methodConst возвращает константу net/http для HTTP метода
+func methodConst(method string) string {
+	return "http.Method" + method[:1] + strings.ToLower(method[1:])
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Spec - часть спецификации Swagger 2.0, которую использует генератор
+type Spec struct {
+	Paths       map[string]map[string]*Operation `json:"paths"`
+	Definitions map[string]*Schema               `json:"definitions"`
+}
+
+// Operation - операция API
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Parameters  []*Parameter         `json:"parameters"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter - параметр операции: path, query или body
+type Parameter struct {
+	Name        string   `json:"name"`
+	In          string   `json:"in"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	Type        string   `json:"type"`
+	Format      string   `json:"format"`
+	Items       *Schema  `json:"items"`
+	Enum        []string `json:"enum"`
+	Schema      *Schema  `json:"schema"`
+}
+
+// Response - ответ операции
+type Response struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema - схема определения, свойства или элемента списка
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Title                string             `json:"title"`
+	Description          string             `json:"description"`
+	Enum                 []string           `json:"enum"`
+	Items                *Schema            `json:"items"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+}
+
+// refName возвращает имя определения из ссылки #/definitions/name
+func (s *Schema) refName() string {
+	return strings.TrimPrefix(s.Ref, "#/definitions/")
+}
+
+// isEnum проверяет, что определение - перечисление
+func (s *Schema) isEnum() bool {
+	return len(s.Enum) > 0
+}
+
+// isMap проверяет, что определение - словарь без фиксированного набора полей
+func (s *Schema) isMap() bool {
+	return s.AdditionalProperties != nil
+}
+
+// doc возвращает первую содержательную строку title или description
+func (s *Schema) doc() string {
+	return docLine(s.Title, s.Description)
+}
+
+// propertyNames возвращает имена свойств в порядке JSON имен
+func (s *Schema) propertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadSpec читает спецификацию из файла
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec %s: %w", path, err)
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec %s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// docLine возвращает первую строку текста, похожую на описание: без
+// аннотаций protovalidate и эмодзи в начале
+func docLine(texts ...string) string {
+	for _, text := range texts {
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimSpace(strings.ReplaceAll(line, "`", ""))
+			if line == "" || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "]") || strings.HasPrefix(line, "//") {
+				continue
+			}
+			line = strings.TrimLeftFunc(line, func(r rune) bool {
+				return !isLetterOrDigit(r)
+			})
+			if line != "" {
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+func isLetterOrDigit(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= 'а' && r <= 'я' || r >= 'А' && r <= 'Я' || r == 'ё' || r == 'Ё'
+}
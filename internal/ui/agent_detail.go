@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -76,8 +77,8 @@ func formatGeneralInfo(agent *api.Agent) string {
 	leftInfo.WriteString(fmt.Sprintf("🆔 ID: %s\n", agent.ID))
 	leftInfo.WriteString(fmt.Sprintf("📝 Название: %s\n", agent.Name))
 	leftInfo.WriteString(fmt.Sprintf("📄 Описание: %s\n", getDescription(agent.Description)))
-	leftInfo.WriteString(fmt.Sprintf("📊 Статус: %s\n", FormatStatus(agent.Status)))
-	leftInfo.WriteString(fmt.Sprintf("🏷️ Тип: %s\n", FormatAgentType(agent.AgentType)))
+	leftInfo.WriteString(fmt.Sprintf("📊 Статус: %s\n", FormatStatus(string(agent.Status))))
+	leftInfo.WriteString(fmt.Sprintf("🏷️ Тип: %s\n", FormatAgentType(string(agent.AgentType))))
 
 	// Добавляем информацию о Project ID
 	if agent.ProjectID != "" {
@@ -86,7 +87,7 @@ func formatGeneralInfo(agent *api.Agent) string {
 
 	// Добавляем информацию о типе инстанса
 	if agent.InstanceType.ID != "" {
-		leftInfo.WriteString(fmt.Sprintf("💻 Инстанс: %s (%s)\n", agent.InstanceType.Name, agent.InstanceType.SKUCode))
+		leftInfo.WriteString(fmt.Sprintf("💻 Инстанс: %s (%d mCPU, %d MiB)\n", agent.InstanceType.Name, agent.InstanceType.MCPU, agent.InstanceType.MibRAM))
 	}
 
 	// Добавляем информацию о MCP серверах
@@ -142,21 +143,17 @@ func formatLLMSettings(agent *api.Agent) string {
 
 	info.WriteString("🧠 Настройки языковой модели:\n\n")
 
-	// LLM настройки из Options
-	if llmOpts, ok := agent.Options["llm"].(map[string]interface{}); ok {
-		if foundationModels, ok := llmOpts["foundationModels"].(map[string]interface{}); ok {
-			if modelName, ok := foundationModels["modelName"].(string); ok {
-				info.WriteString(fmt.Sprintf("📝 Модель: %s\n", modelName))
-			}
-			if gcInstanceId, ok := foundationModels["gcInstanceId"].(string); ok && gcInstanceId != "" {
-				info.WriteString(fmt.Sprintf("🆔 GC Instance ID: %s\n", gcInstanceId))
-			}
-		}
+	llm := agent.Options.LLM
+	if llm.FoundationModels.ModelName != "" {
+		info.WriteString(fmt.Sprintf("📝 Модель: %s\n", llm.FoundationModels.ModelName))
+	}
+	if llm.MLInference.ModelRunID != "" {
+		info.WriteString(fmt.Sprintf("🆔 ML Inference Model Run ID: %s\n", llm.MLInference.ModelRunID))
 	}
 
 	// System prompt
-	if systemPrompt, ok := agent.Options["systemPrompt"].(string); ok && systemPrompt != "" {
-		info.WriteString(fmt.Sprintf("💬 System Prompt: %s\n", systemPrompt))
+	if agent.Options.SystemPrompt != "" {
+		info.WriteString(fmt.Sprintf("💬 System Prompt: %s\n", agent.Options.SystemPrompt))
 	}
 
 	return info.String()
@@ -168,20 +165,11 @@ func formatScalingSettings(agent *api.Agent) string {
 
 	info.WriteString("📈 Настройки масштабирования:\n\n")
 
-	if scaling, ok := agent.Options["scaling"].(map[string]interface{}); ok {
-		if minScale, ok := scaling["minScale"].(float64); ok {
-			info.WriteString(fmt.Sprintf("📉 Min Scale: %.0f\n", minScale))
-		}
-		if maxScale, ok := scaling["maxScale"].(float64); ok {
-			info.WriteString(fmt.Sprintf("📈 Max Scale: %.0f\n", maxScale))
-		}
-		if isKeepAlive, ok := scaling["isKeepAlive"].(bool); ok {
-			info.WriteString(fmt.Sprintf("💤 Keep Alive: %t\n", isKeepAlive))
-		}
-		if isScaleUpAllSystem, ok := scaling["isScaleUpAllSystem"].(bool); ok {
-			info.WriteString(fmt.Sprintf("🔄 Scale Up All System: %t\n", isScaleUpAllSystem))
-		}
-	}
+	scaling := agent.Options.Scaling
+	info.WriteString(fmt.Sprintf("📉 Min Scale: %d\n", scaling.MinScale))
+	info.WriteString(fmt.Sprintf("📈 Max Scale: %d\n", scaling.MaxScale))
+	info.WriteString(fmt.Sprintf("💤 Keep Alive: %t\n", scaling.IsKeepAlive))
+	info.WriteString(fmt.Sprintf("🔄 Scale Up All System: %t\n", scaling.IsScaleUpAllSystem))
 
 	return info.String()
 }
@@ -192,16 +180,13 @@ func formatAuthSettings(agent *api.Agent) string {
 
 	info.WriteString("🔐 Настройки аутентификации:\n\n")
 
-	if authOptions, ok := agent.Options["authOptions"].(map[string]interface{}); ok {
-		if isEnabled, ok := authOptions["isEnabled"].(bool); ok {
-			info.WriteString(fmt.Sprintf("✅ Включена: %t\n", isEnabled))
-		}
-		if authType, ok := authOptions["type"].(string); ok {
-			info.WriteString(fmt.Sprintf("🔑 Тип: %s\n", authType))
-		}
-		if serviceAccountId, ok := authOptions["serviceAccountId"].(string); ok {
-			info.WriteString(fmt.Sprintf("👤 Service Account ID: %s\n", serviceAccountId))
-		}
+	auth := agent.IntegrationOptions.AuthOptions
+	info.WriteString(fmt.Sprintf("✅ Включена: %t\n", auth.IsEnabled))
+	if auth.Type != "" {
+		info.WriteString(fmt.Sprintf("🔑 Тип: %s\n", auth.Type))
+	}
+	if auth.ServiceAccountID != "" {
+		info.WriteString(fmt.Sprintf("👤 Service Account ID: %s\n", auth.ServiceAccountID))
 	}
 
 	return info.String()
@@ -213,13 +198,10 @@ func formatLoggingSettings(agent *api.Agent) string {
 
 	info.WriteString("📊 Настройки логирования:\n\n")
 
-	if logging, ok := agent.Options["logging"].(map[string]interface{}); ok {
-		if isEnabled, ok := logging["isEnabledLogging"].(bool); ok {
-			info.WriteString(fmt.Sprintf("✅ Включено: %t\n", isEnabled))
-		}
-		if logGroupId, ok := logging["logGroupId"].(string); ok {
-			info.WriteString(fmt.Sprintf("📁 Log Group ID: %s\n", logGroupId))
-		}
+	logging := agent.IntegrationOptions.Logging
+	info.WriteString(fmt.Sprintf("✅ Включено: %t\n", logging.IsEnabledLogging))
+	if logging.LogGroupID != "" {
+		info.WriteString(fmt.Sprintf("📁 Log Group ID: %s\n", logging.LogGroupID))
 	}
 
 	return info.String()
@@ -231,10 +213,10 @@ func formatAutoUpdateSettings(agent *api.Agent) string {
 
 	info.WriteString("🔄 Настройки автообновления:\n\n")
 
-	if autoUpdate, ok := agent.Options["autoUpdateOptions"].(map[string]interface{}); ok {
-		if isEnabled, ok := autoUpdate["isEnabled"].(bool); ok {
-			info.WriteString(fmt.Sprintf("✅ Включено: %t\n", isEnabled))
-		}
+	autoUpdate := agent.IntegrationOptions.AutoUpdateOptions
+	info.WriteString(fmt.Sprintf("✅ Включено: %t\n", autoUpdate.IsEnabled))
+	if autoUpdate.ImageSource.Tag != "" {
+		info.WriteString(fmt.Sprintf("🏷️ Тег образа: %s\n", autoUpdate.ImageSource.Tag))
 	}
 
 	return info.String()
@@ -246,8 +228,11 @@ func formatImageSource(agent *api.Agent) string {
 
 	info.WriteString("🐳 Источник образа:\n\n")
 
-	if marketplaceAgentId, ok := agent.Options["marketplaceAgentId"].(string); ok {
-		info.WriteString(fmt.Sprintf("🏪 Marketplace Agent ID: %s\n", marketplaceAgentId))
+	if agent.ImageSource.MarketplaceAgentID != "" {
+		info.WriteString(fmt.Sprintf("🏪 Marketplace Agent ID: %s\n", agent.ImageSource.MarketplaceAgentID))
+	}
+	if agent.ImageSource.ARImageURI != "" {
+		info.WriteString(fmt.Sprintf("📦 Образ: %s\n", agent.ImageSource.ARImageURI))
 	}
 
 	return info.String()
@@ -259,7 +244,7 @@ func formatOptions(agent *api.Agent) string {
 
 	info.WriteString("⚙️ Все опции:\n\n")
 
-	for key, value := range agent.Options {
+	for key, value := range optionEntries(agent.Options) {
 		info.WriteString(fmt.Sprintf("🔧 %s: %v\n", key, value))
 	}
 
@@ -274,6 +259,19 @@ func getDescription(desc string) string {
 	return desc
 }
 
+// optionEntries возвращает заданные поля блока опций API по JSON именам
+func optionEntries(options interface{}) map[string]interface{} {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil
+	}
+	var entries map[string]interface{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	return entries
+}
+
 // FormatAgentStatus форматирует статус агента
 func FormatAgentStatus(status string) string {
 	switch status {
@@ -1,7 +1,9 @@
 package ui
 
 import (
+	stderrors "errors"
 	"fmt"
+	"net/http"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/cloud-ru/evo-ai-agents-cli/internal/api"
@@ -38,19 +40,36 @@ func ShowAuthenticationError(err *api.AuthenticationError) string {
 		Italic(true).
 		Margin(0, 0, 1, 0)
 
+	title := "🔐 Ошибка аутентификации"
+	reasons := []string{
+		"Неверные учетные данные",
+		"Истек срок действия токена",
+		"Неправильно настроен PROJECT_ID",
+	}
+	if err.StatusCode == http.StatusForbidden {
+		// Токен действителен, но у сервисного аккаунта нет прав в проекте
+		title = "🚫 Доступ запрещен"
+		reasons = []string{
+			"Сервисному аккаунту не назначена роль с доступом к AI Agents в проекте",
+			"PROJECT_ID указывает на проект, к которому у сервисного аккаунта нет доступа",
+			"IAM_KEY_ID принадлежит другому сервисному аккаунту",
+		}
+	}
+
+	var reasonLines string
+	for _, reason := range reasons {
+		reasonLines += "\n" + messageStyle.Render("• "+reason)
+	}
+
 	// Формируем сообщение об ошибке
 	result := errorStyle.Render(
-		titleStyle.Render("🔐 Ошибка аутентификации") + "\n\n" +
+		titleStyle.Render(title) + "\n\n" +
 			messageStyle.Render(fmt.Sprintf("Статус: %d", err.StatusCode)) + "\n" +
 			messageStyle.Render(err.Message) + "\n\n" +
 			detailsStyle.Render(err.Details) + "\n\n" +
 			messageStyle.Render("Для решения проблемы ознакомьтесь с документацией:") + "\n" +
 			linkStyle.Render("https://cloud.ru/docs/administration/ug/topics/api-ref__authentication") + "\n\n" +
-			messageStyle.Render("Возможные причины:") + "\n" +
-			messageStyle.Render("• Неверные учетные данные") + "\n" +
-			messageStyle.Render("• Истек срок действия токена") + "\n" +
-			messageStyle.Render("• Недостаточно прав доступа") + "\n" +
-			messageStyle.Render("• Неправильно настроен PROJECT_ID"),
+			messageStyle.Render("Возможные причины:") + reasonLines,
 	)
 
 	return result
@@ -85,7 +104,8 @@ func ShowGenericError(err error) string {
 
 // CheckAndDisplayError проверяет тип ошибки и отображает соответствующее сообщение
 func CheckAndDisplayError(err error) string {
-	if authErr, ok := err.(*api.AuthenticationError); ok {
+	var authErr *api.AuthenticationError
+	if stderrors.As(err, &authErr) {
 		return ShowAuthenticationError(authErr)
 	}
 	
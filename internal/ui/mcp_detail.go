@@ -76,13 +76,15 @@ func formatMCPGeneralInfo(mcp *api.MCPServer) string {
 	leftInfo.WriteString(fmt.Sprintf("🆔 ID: %s\n", mcp.ID))
 	leftInfo.WriteString(fmt.Sprintf("📝 Название: %s\n", mcp.Name))
 	leftInfo.WriteString(fmt.Sprintf("📄 Описание: %s\n", getDescription(mcp.Description)))
-	leftInfo.WriteString(fmt.Sprintf("📊 Статус: %s\n", FormatMCPServerStatus(mcp.Status)))
+	leftInfo.WriteString(fmt.Sprintf("📊 Статус: %s\n", FormatMCPServerStatus(string(mcp.Status))))
 
-	// Project ID не доступен в структуре MCPServer
+	if mcp.ProjectID != "" {
+		leftInfo.WriteString(fmt.Sprintf("🏢 Проект: %s\n", mcp.ProjectID))
+	}
 
 	// Добавляем информацию о типе инстанса
 	if mcp.InstanceType.ID != "" {
-		leftInfo.WriteString(fmt.Sprintf("💻 Инстанс: %s (%s)\n", mcp.InstanceType.Name, mcp.InstanceType.SKUCode))
+		leftInfo.WriteString(fmt.Sprintf("💻 Инстанс: %s (%d mCPU, %d MiB)\n", mcp.InstanceType.Name, mcp.InstanceType.MCPU, mcp.InstanceType.MibRAM))
 	}
 
 	// Правая колонка - временные метки и пользователи
@@ -122,19 +124,11 @@ func formatMCPImageSource(mcp *api.MCPServer) string {
 
 	info.WriteString("🐳 Источник образа:\n\n")
 
-	if mcp.ImageSource != nil {
-		if registry, ok := mcp.ImageSource["registry"].(string); ok {
-			info.WriteString(fmt.Sprintf("📦 Registry: %s\n", registry))
-		}
-		if repository, ok := mcp.ImageSource["repository"].(string); ok {
-			info.WriteString(fmt.Sprintf("📁 Repository: %s\n", repository))
-		}
-		if tag, ok := mcp.ImageSource["tag"].(string); ok {
-			info.WriteString(fmt.Sprintf("🏷️ Tag: %s\n", tag))
-		}
-		if digest, ok := mcp.ImageSource["digest"].(string); ok {
-			info.WriteString(fmt.Sprintf("🔐 Digest: %s\n", digest))
-		}
+	if mcp.ImageSource.ARImageURI != "" {
+		info.WriteString(fmt.Sprintf("📦 Образ: %s\n", mcp.ImageSource.ARImageURI))
+	}
+	if mcp.ImageSource.MarketplaceMCPServerID != "" {
+		info.WriteString(fmt.Sprintf("🏪 Marketplace MCP Server ID: %s\n", mcp.ImageSource.MarketplaceMCPServerID))
 	}
 
 	return info.String()
@@ -146,8 +140,12 @@ func formatMCPPorts(mcp *api.MCPServer) string {
 
 	info.WriteString("🔌 Порты:\n\n")
 
-	// ExposedPorts не доступен в структуре MCPServer
-	info.WriteString("Информация о портах не доступна\n")
+	if len(mcp.ExposedPorts) == 0 {
+		info.WriteString("Порты не открыты\n")
+	}
+	for _, port := range mcp.ExposedPorts {
+		info.WriteString(fmt.Sprintf("• %d\n", port))
+	}
 
 	return info.String()
 }
@@ -158,8 +156,9 @@ func formatMCPScaling(mcp *api.MCPServer) string {
 
 	info.WriteString("📈 Настройки масштабирования:\n\n")
 
-	// Scaling не доступен в структуре MCPServer
-	info.WriteString("Настройки масштабирования не доступны\n")
+	info.WriteString(fmt.Sprintf("📉 Min Scale: %d\n", mcp.Scaling.MinScale))
+	info.WriteString(fmt.Sprintf("📈 Max Scale: %d\n", mcp.Scaling.MaxScale))
+	info.WriteString(fmt.Sprintf("💤 Keep Alive: %t\n", mcp.Scaling.IsKeepAlive))
 
 	return info.String()
 }
@@ -170,8 +169,16 @@ func formatMCPEnvironment(mcp *api.MCPServer) string {
 
 	info.WriteString("🌿 Переменные окружения:\n\n")
 
-	// EnvironmentOptions не доступен в структуре MCPServer
-	info.WriteString("Переменные окружения не доступны\n")
+	env := mcp.EnvironmentOptions
+	if len(env.RawEnvs) == 0 && len(env.SecretEnvs) == 0 {
+		info.WriteString("Переменные окружения не заданы\n")
+	}
+	for key, value := range env.RawEnvs {
+		info.WriteString(fmt.Sprintf("• %s=%s\n", key, value))
+	}
+	for key, secret := range env.SecretEnvs {
+		info.WriteString(fmt.Sprintf("🔐 %s: секрет %s (версия %d)\n", key, secret.ID, secret.Version))
+	}
 
 	return info.String()
 }
@@ -182,8 +189,13 @@ func formatMCPIntegration(mcp *api.MCPServer) string {
 
 	info.WriteString("🔗 Настройки интеграции:\n\n")
 
-	// IntegrationOptions не доступен в структуре MCPServer
-	info.WriteString("Настройки интеграции не доступны\n")
+	integration := mcp.IntegrationOptions
+	info.WriteString(fmt.Sprintf("🔐 Аутентификация: %t\n", integration.AuthOptions.IsEnabled))
+	if integration.AuthOptions.Type != "" {
+		info.WriteString(fmt.Sprintf("🔑 Тип: %s\n", integration.AuthOptions.Type))
+	}
+	info.WriteString(fmt.Sprintf("📊 Логирование: %t\n", integration.Logging.IsEnabledLogging))
+	info.WriteString(fmt.Sprintf("🔄 Автообновление: %t\n", integration.AutoUpdateOptions.IsEnabled))
 
 	return info.String()
 }
@@ -194,13 +206,17 @@ func formatMCPOptions(mcp *api.MCPServer) string {
 
 	info.WriteString("⚙️ Все опции:\n\n")
 
-	if mcp.Options != nil {
-		for key, value := range mcp.Options {
-			info.WriteString(fmt.Sprintf("🔧 %s: %v\n", key, value))
-		}
-	} else {
+	options := optionEntries(struct {
+		EnvironmentOptions api.EnvironmentOptions `json:"environmentOptions,omitzero"`
+		Scaling            api.Scaling            `json:"scaling,omitzero"`
+		IntegrationOptions api.IntegrationOptions `json:"integrationOptions,omitzero"`
+	}{mcp.EnvironmentOptions, mcp.Scaling, mcp.IntegrationOptions})
+	if len(options) == 0 {
 		info.WriteString("Нет дополнительных опций\n")
 	}
+	for key, value := range options {
+		info.WriteString(fmt.Sprintf("🔧 %s: %v\n", key, value))
+	}
 
 	return info.String()
 }
@@ -217,10 +233,10 @@ func formatMCPTools(mcp *api.MCPServer) string {
 			if tool.Description != "" {
 				info.WriteString(fmt.Sprintf("   📝 %s\n", tool.Description))
 			}
-			if tool.InputSchema != nil {
-				info.WriteString("   📋 Схема входных данных:\n")
-				for key, value := range tool.InputSchema {
-					info.WriteString(fmt.Sprintf("     • %s: %v\n", key, value))
+			if len(tool.Args) > 0 {
+				info.WriteString("   📋 Аргументы:\n")
+				for _, arg := range tool.Args {
+					info.WriteString(fmt.Sprintf("     • %s (%s): %s\n", arg.Name, arg.Type, arg.Description))
 				}
 			}
 			info.WriteString("\n")
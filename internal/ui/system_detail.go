@@ -76,7 +76,7 @@ func formatSystemGeneralInfo(system *api.AgentSystem) string {
 	leftInfo.WriteString(fmt.Sprintf("🆔 ID: %s\n", system.ID))
 	leftInfo.WriteString(fmt.Sprintf("📝 Название: %s\n", system.Name))
 	leftInfo.WriteString(fmt.Sprintf("📄 Описание: %s\n", getDescription(system.Description)))
-	leftInfo.WriteString(fmt.Sprintf("📊 Статус: %s\n", FormatSystemStatus(system.Status)))
+	leftInfo.WriteString(fmt.Sprintf("📊 Статус: %s\n", FormatSystemStatus(string(system.Status))))
 
 	// Добавляем информацию о Project ID
 	if system.ProjectID != "" {
@@ -85,7 +85,7 @@ func formatSystemGeneralInfo(system *api.AgentSystem) string {
 
 	// Добавляем информацию о типе инстанса
 	if system.InstanceType.ID != "" {
-		leftInfo.WriteString(fmt.Sprintf("💻 Инстанс: %s (%s)\n", system.InstanceType.Name, system.InstanceType.SKUCode))
+		leftInfo.WriteString(fmt.Sprintf("💻 Инстанс: %s (%d mCPU, %d MiB)\n", system.InstanceType.Name, system.InstanceType.MCPU, system.InstanceType.MibRAM))
 	}
 
 	// Правая колонка - временные метки и пользователи
@@ -128,8 +128,8 @@ func formatSystemAgents(system *api.AgentSystem) string {
 	if len(system.Agents) > 0 {
 		for i, agent := range system.Agents {
 			info.WriteString(fmt.Sprintf("🤖 %d. %s\n", i+1, agent.Name))
-			info.WriteString(fmt.Sprintf("   🆔 ID: %s\n", agent.ID))
-			info.WriteString(fmt.Sprintf("   📊 Статус: %s\n", FormatAgentStatus(agent.Status)))
+			info.WriteString(fmt.Sprintf("   🆔 ID: %s\n", agent.AgentID))
+			info.WriteString(fmt.Sprintf("   📊 Статус: %s\n", FormatAgentStatus(string(agent.Status))))
 			info.WriteString("\n")
 		}
 	} else {
@@ -145,8 +145,8 @@ func formatSystemOrchestrator(system *api.AgentSystem) string {
 
 	info.WriteString("🧠 Настройки оркестратора:\n\n")
 
-	if system.OrchestratorOptions != nil {
-		for key, value := range system.OrchestratorOptions {
+	if options := optionEntries(system.OrchestratorOptions); len(options) > 0 {
+		for key, value := range options {
 			info.WriteString(fmt.Sprintf("⚙️ %s: %v\n", key, value))
 		}
 	} else {
@@ -162,8 +162,8 @@ func formatSystemOptions(system *api.AgentSystem) string {
 
 	info.WriteString("⚙️ Опции системы:\n\n")
 
-	if system.Options != nil {
-		for key, value := range system.Options {
+	if options := optionEntries(system.Options); len(options) > 0 {
+		for key, value := range options {
 			info.WriteString(fmt.Sprintf("🔧 %s: %v\n", key, value))
 		}
 	} else {
@@ -179,8 +179,8 @@ func formatSystemIntegration(system *api.AgentSystem) string {
 
 	info.WriteString("🔗 Настройки интеграции:\n\n")
 
-	if system.IntegrationOptions != nil {
-		for key, value := range system.IntegrationOptions {
+	if options := optionEntries(system.IntegrationOptions); len(options) > 0 {
+		for key, value := range options {
 			info.WriteString(fmt.Sprintf("⚙️ %s: %v\n", key, value))
 		}
 	} else {
@@ -196,7 +196,7 @@ func formatSystemStatistics(system *api.AgentSystem) string {
 
 	info.WriteString("📊 Статистика системы:\n\n")
 	info.WriteString(fmt.Sprintf("🤖 Количество агентов: %d\n", len(system.Agents)))
-	info.WriteString(fmt.Sprintf("📅 Время работы: %s\n", system.UpdatedAt.Sub(system.CreatedAt).String()))
+	info.WriteString(fmt.Sprintf("📅 Время работы: %s\n", system.UpdatedAt.Sub(system.CreatedAt.Time).String()))
 
 	return info.String()
 }
@@ -221,8 +221,11 @@ func formatSystemScaling(system *api.AgentSystem) string {
 
 	info.WriteString("📈 Настройки масштабирования:\n\n")
 
-	// Здесь можно добавить логику для парсинга настроек масштабирования из Options
-	info.WriteString("Настройки масштабирования будут добавлены в будущих версиях\n")
+	scaling := system.OrchestratorOptions.Scaling
+	info.WriteString(fmt.Sprintf("📉 Min Scale: %d\n", scaling.MinScale))
+	info.WriteString(fmt.Sprintf("📈 Max Scale: %d\n", scaling.MaxScale))
+	info.WriteString(fmt.Sprintf("💤 Keep Alive: %t\n", scaling.IsKeepAlive))
+	info.WriteString(fmt.Sprintf("🔄 Scale Up All System: %t\n", scaling.IsScaleUpAllSystem))
 
 	return info.String()
 }
@@ -104,8 +104,8 @@ func (p *TableProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						ID:          selectedRow[0],
 						Name:        selectedRow[1],
 						Description: selectedRow[2],
-						Status:      selectedRow[3],
-						AgentType:   selectedRow[4],
+						Status:      api.AgentStatus(selectedRow[3]),
+						AgentType:   api.AgentType(selectedRow[4]),
 						// Остальные поля можно заполнить по умолчанию
 					}
 
@@ -192,19 +192,51 @@ func ShowMCPServersTable(servers []api.MCPServer, title string) error {
 	return program.Run()
 }
 
-// ShowAgentsListFromAPI показывает список агентов из API
-func ShowAgentsListFromAPI(ctx context.Context, limit, offset int) error {
+// ShowAgentsListFromAPI показывает список агентов из API. Фильтры filter
+// применяются на сервере, filter.Limit задает размер страницы таблицы.
+// Если all равен true, загружаются все страницы и показываются одной таблицей,
+// filter.Limit и filter.Offset при этом не учитываются.
+func ShowAgentsListFromAPI(ctx context.Context, filter api.SearchAgentParams, all bool) error {
 	container := di.GetContainer()
 	apiClient, err := container.GetAPI()
 	if err != nil {
 		return fmt.Errorf("Ошибка получения API клиента: %v", err)
 	}
 
+	// Создаем колонки таблицы
+	columns := []table.Column{
+		{Title: "ID", Width: 36},
+		{Title: "Название", Width: 25},
+		{Title: "Описание", Width: 40},
+		{Title: "Статус", Width: 20},
+		{Title: "Тип", Width: 25},
+		{Title: "Создан", Width: 16},
+		{Title: "Обновлен", Width: 16},
+	}
+
+	if all {
+		// Все страницы обходятся с начала страницами api.DefaultPageSize
+		params := filter
+		params.Limit, params.Offset = 0, 0
+		var rows []table.Row
+		for agent, err := range apiClient.Agents.All(ctx, &params) {
+			if err != nil {
+				return fmt.Errorf("failed to list agents: %w", err)
+			}
+			rows = append(rows, agentRow(agent))
+		}
+
+		tableModel := NewTableModel(fmt.Sprintf("🤖 Агенты (всего: %d)", len(rows)), columns, rows)
+		return NewTableProgram(tableModel).Run()
+	}
+
 	// Создаем функцию загрузки данных
 	dataLoader := func(ctx context.Context, limit, offset int) ([]table.Row, int, error) {
 		log.Debug("Запрос списка агентов", "limit", limit, "offset", offset)
 
-		agents, err := apiClient.Agents.List(ctx, limit, offset)
+		params := filter
+		params.Limit, params.Offset = limit, offset
+		agents, err := apiClient.Agents.SearchAgent(ctx, &params)
 		if err != nil {
 			log.Error("Ошибка получения списка агентов", "error", err)
 			return nil, 0, fmt.Errorf("failed to list agents: %w", err)
@@ -215,61 +247,84 @@ func ShowAgentsListFromAPI(ctx context.Context, limit, offset int) error {
 		// Преобразуем агентов в строки таблицы
 		var rows []table.Row
 		for _, agent := range agents.Data {
-			// Получаем тип агента с переводом
-			agentType := FormatAgentType(agent.AgentType)
-
-			// Получаем описание или ставим прочерк
-			description := agent.Description
-			if description == "" {
-				description = "—"
-			}
-
-			rows = append(rows, table.Row{
-				agent.ID,
-				agent.Name,
-				description,
-				FormatStatus(agent.Status),
-				agentType,
-				agent.CreatedAt.Time.Format("02.01.2006 15:04"),
-				agent.UpdatedAt.Time.Format("02.01.2006 15:04"),
-			})
+			rows = append(rows, agentRow(agent))
 		}
 
 		return rows, agents.Total, nil
 	}
 
-	// Создаем колонки таблицы
-	columns := []table.Column{
-		{Title: "ID", Width: 36},
-		{Title: "Название", Width: 25},
-		{Title: "Описание", Width: 40},
-		{Title: "Статус", Width: 20},
-		{Title: "Тип", Width: 25},
-		{Title: "Создан", Width: 16},
-		{Title: "Обновлен", Width: 16},
-	}
-
 	// Создаем модель таблицы с серверной пагинацией
-	tableModel := NewServerPaginatedTableModel(ctx, "🤖 Агенты", columns, limit, dataLoader)
+	tableModel := NewServerPaginatedTableModel(ctx, "🤖 Агенты", columns, filter.Limit, dataLoader)
 
 	// Создаем программу таблицы
 	program := NewTableProgram(tableModel)
 	return program.Run()
 }
 
-// ShowMCPServersListFromAPI показывает список MCP серверов из API
-func ShowMCPServersListFromAPI(ctx context.Context, limit, offset int) error {
+// agentRow преобразует агента в строку таблицы списка агентов
+func agentRow(agent api.AgentPreview) table.Row {
+	// Получаем описание или ставим прочерк
+	description := agent.Description
+	if description == "" {
+		description = "—"
+	}
+
+	return table.Row{
+		agent.ID,
+		agent.Name,
+		description,
+		FormatStatus(string(agent.Status)),
+		FormatAgentType(string(agent.AgentType)),
+		agent.CreatedAt.Time.Format("02.01.2006 15:04"),
+		agent.UpdatedAt.Time.Format("02.01.2006 15:04"),
+	}
+}
+
+// ShowMCPServersListFromAPI показывает список MCP серверов из API. Фильтры
+// filter применяются на сервере, filter.Limit задает размер страницы таблицы.
+// Если all равен true, загружаются все страницы и показываются одной таблицей,
+// filter.Limit и filter.Offset при этом не учитываются.
+func ShowMCPServersListFromAPI(ctx context.Context, filter api.SearchMCPServerParams, all bool) error {
 	container := di.GetContainer()
 	apiClient, err := container.GetAPI()
 	if err != nil {
 		return fmt.Errorf("Ошибка получения API клиента: %v", err)
 	}
 
+	// Создаем колонки таблицы
+	columns := []table.Column{
+		{Title: "ID", Width: 36},
+		{Title: "Название", Width: 25},
+		{Title: "Образ", Width: 40},
+		{Title: "Статус", Width: 20},
+		{Title: "Инструменты", Width: 12},
+		{Title: "Создан", Width: 16},
+		{Title: "Обновлен", Width: 16},
+	}
+
+	if all {
+		// Все страницы обходятся с начала страницами api.DefaultPageSize
+		params := filter
+		params.Limit, params.Offset = 0, 0
+		var rows []table.Row
+		for server, err := range apiClient.MCPServers.All(ctx, &params) {
+			if err != nil {
+				return fmt.Errorf("failed to list MCP servers: %w", err)
+			}
+			rows = append(rows, mcpServerRow(server))
+		}
+
+		tableModel := NewTableModel(fmt.Sprintf("🔧 MCP Серверы (всего: %d)", len(rows)), columns, rows)
+		return NewTableProgram(tableModel).Run()
+	}
+
 	// Создаем функцию загрузки данных
 	dataLoader := func(ctx context.Context, limit, offset int) ([]table.Row, int, error) {
 		log.Debug("Запрос списка MCP серверов", "limit", limit, "offset", offset)
 
-		servers, err := apiClient.MCPServers.List(ctx, limit, offset)
+		params := filter
+		params.Limit, params.Offset = limit, offset
+		servers, err := apiClient.MCPServers.SearchMCPServer(ctx, &params)
 		if err != nil {
 			log.Error("Ошибка получения списка MCP серверов", "error", err)
 			return nil, 0, fmt.Errorf("failed to list MCP servers: %w", err)
@@ -280,58 +335,83 @@ func ShowMCPServersListFromAPI(ctx context.Context, limit, offset int) error {
 		// Преобразуем серверы в строки таблицы
 		var rows []table.Row
 		for _, server := range servers.Data {
-			// Получаем описание или ставим прочерк
-			description := server.Description
-			if description == "" {
-				description = "—"
-			}
-
-			rows = append(rows, table.Row{
-				server.ID,
-				server.Name,
-				description,
-				FormatStatus(server.Status),
-				fmt.Sprintf("%d", len(server.Tools)),
-				server.CreatedAt.Time.Format("02.01.2006 15:04"),
-				server.UpdatedAt.Time.Format("02.01.2006 15:04"),
-			})
+			rows = append(rows, mcpServerRow(server))
 		}
 
 		return rows, servers.Total, nil
 	}
 
-	// Создаем колонки таблицы
-	columns := []table.Column{
-		{Title: "ID", Width: 36},
-		{Title: "Название", Width: 25},
-		{Title: "Описание", Width: 40},
-		{Title: "Статус", Width: 20},
-		{Title: "Инструменты", Width: 12},
-		{Title: "Создан", Width: 16},
-		{Title: "Обновлен", Width: 16},
-	}
-
 	// Создаем модель таблицы с серверной пагинацией
-	tableModel := NewServerPaginatedTableModel(ctx, "🔧 MCP Серверы", columns, limit, dataLoader)
+	tableModel := NewServerPaginatedTableModel(ctx, "🔧 MCP Серверы", columns, filter.Limit, dataLoader)
 
 	// Создаем программу таблицы
 	program := NewTableProgram(tableModel)
 	return program.Run()
 }
 
-// ShowAgentSystemsListFromAPI показывает список систем агентов из API
-func ShowAgentSystemsListFromAPI(ctx context.Context, limit, offset int) error {
+// mcpServerRow преобразует MCP сервер в строку таблицы списка MCP серверов
+func mcpServerRow(server api.MCPServerPreview) table.Row {
+	// Список возвращает превью без описания, показываем образ
+	image := server.ImageSource.ARImageURI
+	if image == "" {
+		image = "—"
+	}
+
+	return table.Row{
+		server.ID,
+		server.Name,
+		image,
+		FormatStatus(string(server.Status)),
+		fmt.Sprintf("%d", len(server.Tools)),
+		server.CreatedAt.Time.Format("02.01.2006 15:04"),
+		server.UpdatedAt.Time.Format("02.01.2006 15:04"),
+	}
+}
+
+// ShowAgentSystemsListFromAPI показывает список систем агентов из API. Фильтры
+// filter применяются на сервере, filter.Limit задает размер страницы таблицы.
+// Если all равен true, загружаются все страницы и показываются одной таблицей,
+// filter.Limit и filter.Offset при этом не учитываются.
+func ShowAgentSystemsListFromAPI(ctx context.Context, filter api.SearchAgentSystemParams, all bool) error {
 	container := di.GetContainer()
 	apiClient, err := container.GetAPI()
 	if err != nil {
 		return fmt.Errorf("Ошибка получения API клиента: %v", err)
 	}
 
+	// Создаем колонки таблицы
+	columns := []table.Column{
+		{Title: "ID", Width: 40},
+		{Title: "Название", Width: 50},
+		{Title: "Статус", Width: 25},
+		{Title: "Тип инстанса", Width: 16},
+		{Title: "Создана", Width: 16},
+		{Title: "Обновлена", Width: 16},
+	}
+
+	if all {
+		// Все страницы обходятся с начала страницами api.DefaultPageSize
+		params := filter
+		params.Limit, params.Offset = 0, 0
+		var rows []table.Row
+		for system, err := range apiClient.AgentSystems.All(ctx, &params) {
+			if err != nil {
+				return fmt.Errorf("failed to list agent systems: %w", err)
+			}
+			rows = append(rows, agentSystemRow(system))
+		}
+
+		tableModel := NewTableModel(fmt.Sprintf("🏢 Системы агентов (всего: %d)", len(rows)), columns, rows)
+		return NewTableProgram(tableModel).Run()
+	}
+
 	// Создаем функцию загрузки данных
 	dataLoader := func(ctx context.Context, limit, offset int) ([]table.Row, int, error) {
 		log.Debug("Запрос списка систем агентов", "limit", limit, "offset", offset)
 
-		systems, err := apiClient.AgentSystems.List(ctx, limit, offset)
+		params := filter
+		params.Limit, params.Offset = limit, offset
+		systems, err := apiClient.AgentSystems.SearchAgentSystem(ctx, &params)
 		if err != nil {
 			log.Error("Ошибка получения списка систем агентов", "error", err)
 			return nil, 0, fmt.Errorf("failed to list agent systems: %w", err)
@@ -342,37 +422,32 @@ func ShowAgentSystemsListFromAPI(ctx context.Context, limit, offset int) error {
 		// Преобразуем системы в строки таблицы
 		var rows []table.Row
 		for _, system := range systems.Data {
-			rows = append(rows, table.Row{
-				system.ID,
-				system.Name,
-				FormatStatus(system.Status),
-				fmt.Sprintf("%d", len(system.Agents)),
-				system.CreatedAt.Format("02.01.2006 15:04"),
-				system.UpdatedAt.Format("02.01.2006 15:04"),
-			})
+			rows = append(rows, agentSystemRow(system))
 		}
 
 		return rows, systems.Total, nil
 	}
 
-	// Создаем колонки таблицы
-	columns := []table.Column{
-		{Title: "ID", Width: 40},
-		{Title: "Название", Width: 50},
-		{Title: "Статус", Width: 25},
-		{Title: "Агентов", Width: 10},
-		{Title: "Создана", Width: 16},
-		{Title: "Обновлена", Width: 16},
-	}
-
 	// Создаем модель таблицы с серверной пагинацией
-	tableModel := NewServerPaginatedTableModel(ctx, "🏢 Системы агентов", columns, limit, dataLoader)
+	tableModel := NewServerPaginatedTableModel(ctx, "🏢 Системы агентов", columns, filter.Limit, dataLoader)
 
 	// Создаем программу таблицы
 	program := NewTableProgram(tableModel)
 	return program.Run()
 }
 
+// agentSystemRow преобразует систему агентов в строку таблицы списка систем
+func agentSystemRow(system api.AgentSystemPreview) table.Row {
+	return table.Row{
+		system.ID,
+		system.Name,
+		FormatStatus(string(system.Status)),
+		system.InstanceType.Name,
+		system.CreatedAt.Format("02.01.2006 15:04"),
+		system.UpdatedAt.Format("02.01.2006 15:04"),
+	}
+}
+
 // CheckTerminalSize проверяет размер терминала
 func CheckTerminalSize() error {
 	// Проверяем, что терминал достаточно большой для таблицы
@@ -496,16 +571,16 @@ func RenderAgentDetails(agent *api.Agent, ctx context.Context, container *di.Con
 	}
 
 	// Тип агента с переводом
-	agentType := FormatAgentType(agent.AgentType)
+	agentType := FormatAgentType(string(agent.AgentType))
 	result += fmt.Sprintf("%s: %s\n", labelStyle.Render("Тип"), valueStyle.Render(agentType))
 
 	// Статус с полной информацией
-	status := FormatStatus(agent.Status)
+	status := FormatStatus(string(agent.Status))
 	result += fmt.Sprintf("%s: %s\n", labelStyle.Render("Статус"), status)
 
 	// Причина статуса
 	if agent.StatusReason.ReasonType != "" {
-		result += fmt.Sprintf("%s: %s\n", labelStyle.Render("Причина статуса"), valueStyle.Render(agent.StatusReason.ReasonType))
+		result += fmt.Sprintf("%s: %s\n", labelStyle.Render("Причина статуса"), valueStyle.Render(string(agent.StatusReason.ReasonType)))
 		if agent.StatusReason.Message != "" {
 			result += fmt.Sprintf("%s: %s\n", labelStyle.Render("Сообщение"), valueStyle.Render(agent.StatusReason.Message))
 		}
@@ -535,11 +610,9 @@ func RenderAgentDetails(agent *api.Agent, ctx context.Context, container *di.Con
 	if len(agent.MCPServers) > 0 {
 		result += fmt.Sprintf("\n%s\n", tabStyle.Render("📡 Подключенные серверы:"))
 		for i, mcp := range agent.MCPServers {
-			result += fmt.Sprintf("  %d. %s (%s) - %s\n", i+1, mcp.Name, mcp.ID, mcp.Status)
-			if len(mcp.Source) > 0 {
-				for key, value := range mcp.Source {
-					result += fmt.Sprintf("     %s: %v\n", labelStyle.Render("Источник"), valueStyle.Render(fmt.Sprintf("%s: %v", key, value)))
-				}
+			result += fmt.Sprintf("  %d. %s (%s) - %s\n", i+1, mcp.Name, mcp.MCPServerID, mcp.Status)
+			for key, value := range optionEntries(mcp.Source) {
+				result += fmt.Sprintf("     %s: %v\n", labelStyle.Render("Источник"), valueStyle.Render(fmt.Sprintf("%s: %v", key, value)))
 			}
 			if len(mcp.Tools) > 0 {
 				result += fmt.Sprintf("     %s (%d):\n", labelStyle.Render("Инструменты"), len(mcp.Tools))
@@ -561,26 +634,16 @@ func RenderAgentDetails(agent *api.Agent, ctx context.Context, container *di.Con
 		result += fmt.Sprintf("\n%s\n", tabStyle.Render("❌ MCP серверы не подключены"))
 	}
 
-	// MCP серверы (старые)
-	if len(agent.MCPs) > 0 {
-		result += fmt.Sprintf("\n%s\n", tabStyle.Render("📡 Старые MCP серверы:"))
-		for i, mcp := range agent.MCPs {
-			result += fmt.Sprintf("  %d. %s\n", i+1, mcp)
-		}
-	}
-
 	// ===== ДОПОЛНИТЕЛЬНЫЕ ОПЦИИ =====
 	result += fmt.Sprintf("\n%s\n", sectionStyle.Render("⚙️ ДОПОЛНИТЕЛЬНЫЕ ОПЦИИ"))
 
 	// Статистика
 	result += fmt.Sprintf("\n%s\n", tabStyle.Render("📊 Статистика:"))
-	mcpCount := len(agent.MCPServers)
-	if len(agent.MCPs) > 0 {
-		mcpCount = len(agent.MCPs)
-	}
-	result += fmt.Sprintf("  %s: %d\n", labelStyle.Render("MCP серверов"), mcpCount)
-	result += fmt.Sprintf("  %s: %d\n", labelStyle.Render("Опций"), len(agent.Options))
-	result += fmt.Sprintf("  %s: %d\n", labelStyle.Render("Интеграций"), len(agent.IntegrationOptions))
+	options := optionEntries(agent.Options)
+	integrationOptions := optionEntries(agent.IntegrationOptions)
+	result += fmt.Sprintf("  %s: %d\n", labelStyle.Render("MCP серверов"), len(agent.MCPServers))
+	result += fmt.Sprintf("  %s: %d\n", labelStyle.Render("Опций"), len(options))
+	result += fmt.Sprintf("  %s: %d\n", labelStyle.Render("Интеграций"), len(integrationOptions))
 	if len(agent.UsedInAgentSystems) > 0 {
 		result += fmt.Sprintf("  %s: %d\n", labelStyle.Render("Используется в системах"), len(agent.UsedInAgentSystems))
 	}
@@ -590,24 +653,17 @@ func RenderAgentDetails(agent *api.Agent, ctx context.Context, container *di.Con
 		result += fmt.Sprintf("\n%s\n", tabStyle.Render("💻 Тип инстанса:"))
 		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("ID"), valueStyle.Render(agent.InstanceType.ID))
 		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Название"), valueStyle.Render(agent.InstanceType.Name))
-		if agent.InstanceType.SKUCode != "" {
-			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("SKU код"), valueStyle.Render(agent.InstanceType.SKUCode))
-		}
-		if agent.InstanceType.ResourceCode != "" {
-			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Код ресурса"), valueStyle.Render(agent.InstanceType.ResourceCode))
-		}
-		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Активен"), valueStyle.Render(fmt.Sprintf("%t", agent.InstanceType.IsActive)))
 		if agent.InstanceType.MCPU > 0 {
 			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("CPU"), valueStyle.Render(fmt.Sprintf("%d мCPU", agent.InstanceType.MCPU)))
 		}
 		if agent.InstanceType.MibRAM > 0 {
 			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("RAM"), valueStyle.Render(fmt.Sprintf("%d МБ", agent.InstanceType.MibRAM)))
 		}
-		if agent.InstanceType.CreatedAt != "" {
-			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Создан"), valueStyle.Render(agent.InstanceType.CreatedAt))
+		if !agent.InstanceType.CreatedAt.IsZero() {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Создан"), valueStyle.Render(agent.InstanceType.CreatedAt.Format("02.01.2006 15:04:05")))
 		}
-		if agent.InstanceType.UpdatedAt != "" {
-			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Обновлен"), valueStyle.Render(agent.InstanceType.UpdatedAt))
+		if !agent.InstanceType.UpdatedAt.IsZero() {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Обновлен"), valueStyle.Render(agent.InstanceType.UpdatedAt.Format("02.01.2006 15:04:05")))
 		}
 		if agent.InstanceType.CreatedBy != "" {
 			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Создал"), valueStyle.Render(agent.InstanceType.CreatedBy))
@@ -618,111 +674,80 @@ func RenderAgentDetails(agent *api.Agent, ctx context.Context, container *di.Con
 	}
 
 	// System Prompt из Options
-	if systemPrompt, ok := agent.Options["systemPrompt"]; ok {
+	if agent.Options.SystemPrompt != "" {
 		result += fmt.Sprintf("\n%s\n", tabStyle.Render("📝 System Prompt:"))
-		result += fmt.Sprintf("  %s\n", valueStyle.Render(fmt.Sprintf("%v", systemPrompt)))
+		result += fmt.Sprintf("  %s\n", valueStyle.Render(agent.Options.SystemPrompt))
 	}
 
 	// LLM настройки из Options
-	if llm, ok := agent.Options["llm"]; ok {
-		if llmMap, ok := llm.(map[string]interface{}); ok {
-			result += fmt.Sprintf("\n%s\n", tabStyle.Render("🧠 LLM настройки:"))
-			if foundationModels, ok := llmMap["foundationModels"]; ok {
-				if fmMap, ok := foundationModels.(map[string]interface{}); ok {
-					if modelName, ok := fmMap["modelName"]; ok {
-						result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Модель"), valueStyle.Render(fmt.Sprintf("%v", modelName)))
-					}
-					if gcInstanceId, ok := fmMap["gcInstanceId"]; ok {
-						result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("GC Instance ID"), valueStyle.Render(fmt.Sprintf("%v", gcInstanceId)))
-					}
-				}
-			}
+	if llm := agent.Options.LLM; llm != (api.LLMOptions{}) {
+		result += fmt.Sprintf("\n%s\n", tabStyle.Render("🧠 LLM настройки:"))
+		if llm.FoundationModels.ModelName != "" {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Модель"), valueStyle.Render(llm.FoundationModels.ModelName))
+		}
+		if llm.MLInference.ModelRunID != "" {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Model Run ID"), valueStyle.Render(llm.MLInference.ModelRunID))
 		}
 	}
 
 	// Scaling настройки из Options
-	if scaling, ok := agent.Options["scaling"]; ok {
-		if scalingMap, ok := scaling.(map[string]interface{}); ok {
-			result += fmt.Sprintf("\n%s\n", tabStyle.Render("📈 Scaling настройки:"))
-			if minScale, ok := scalingMap["minScale"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Min Scale"), valueStyle.Render(fmt.Sprintf("%v", minScale)))
-			}
-			if maxScale, ok := scalingMap["maxScale"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Max Scale"), valueStyle.Render(fmt.Sprintf("%v", maxScale)))
-			}
-			if isKeepAlive, ok := scalingMap["isKeepAlive"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Keep Alive"), valueStyle.Render(fmt.Sprintf("%v", isKeepAlive)))
-			}
-			if keepAliveDuration, ok := scalingMap["keepAliveDuration"]; ok {
-				if durationMap, ok := keepAliveDuration.(map[string]interface{}); ok {
-					hours := durationMap["hours"]
-					minutes := durationMap["minutes"]
-					seconds := durationMap["seconds"]
-					result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Keep Alive Duration"), valueStyle.Render(fmt.Sprintf("%vh %vm %vs", hours, minutes, seconds)))
-				}
-			}
+	if scaling := agent.Options.Scaling; len(optionEntries(scaling)) > 0 {
+		result += fmt.Sprintf("\n%s\n", tabStyle.Render("📈 Scaling настройки:"))
+		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Min Scale"), valueStyle.Render(fmt.Sprintf("%d", scaling.MinScale)))
+		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Max Scale"), valueStyle.Render(fmt.Sprintf("%d", scaling.MaxScale)))
+		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Keep Alive"), valueStyle.Render(fmt.Sprintf("%t", scaling.IsKeepAlive)))
+		if duration := scaling.KeepAliveDuration; duration != (api.Duration{}) {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Keep Alive Duration"), valueStyle.Render(fmt.Sprintf("%dh %dm %ds", duration.Hours, duration.Minutes, duration.Seconds)))
 		}
 	}
 
 	// Аутентификация из IntegrationOptions
-	if authOptions, ok := agent.IntegrationOptions["authOptions"]; ok {
-		if authMap, ok := authOptions.(map[string]interface{}); ok {
-			result += fmt.Sprintf("\n%s\n", tabStyle.Render("🔐 Аутентификация:"))
-			if isEnabled, ok := authMap["isEnabled"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Включена"), valueStyle.Render(fmt.Sprintf("%v", isEnabled)))
-			}
-			if authType, ok := authMap["type"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Тип"), valueStyle.Render(fmt.Sprintf("%v", authType)))
-			}
-			if serviceAccountId, ok := authMap["serviceAccountId"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Service Account ID"), valueStyle.Render(fmt.Sprintf("%v", serviceAccountId)))
-			}
+	if auth := agent.IntegrationOptions.AuthOptions; len(optionEntries(auth)) > 0 {
+		result += fmt.Sprintf("\n%s\n", tabStyle.Render("🔐 Аутентификация:"))
+		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Включена"), valueStyle.Render(fmt.Sprintf("%t", auth.IsEnabled)))
+		if auth.Type != "" {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Тип"), valueStyle.Render(string(auth.Type)))
+		}
+		if auth.ServiceAccountID != "" {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Service Account ID"), valueStyle.Render(auth.ServiceAccountID))
 		}
 	}
 
 	// Логирование из IntegrationOptions
-	if logging, ok := agent.IntegrationOptions["logging"]; ok {
-		if loggingMap, ok := logging.(map[string]interface{}); ok {
-			result += fmt.Sprintf("\n%s\n", tabStyle.Render("📊 Логирование:"))
-			if isEnabled, ok := loggingMap["isEnabledLogging"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Включено"), valueStyle.Render(fmt.Sprintf("%v", isEnabled)))
-			}
-			if logGroupId, ok := loggingMap["logGroupId"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Log Group ID"), valueStyle.Render(fmt.Sprintf("%v", logGroupId)))
-			}
+	if logging := agent.IntegrationOptions.Logging; logging != (api.LoggingOptions{}) {
+		result += fmt.Sprintf("\n%s\n", tabStyle.Render("📊 Логирование:"))
+		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Включено"), valueStyle.Render(fmt.Sprintf("%t", logging.IsEnabledLogging)))
+		if logging.LogGroupID != "" {
+			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Log Group ID"), valueStyle.Render(logging.LogGroupID))
 		}
 	}
 
 	// Автообновление из IntegrationOptions
-	if autoUpdate, ok := agent.IntegrationOptions["autoUpdateOptions"]; ok {
-		if autoUpdateMap, ok := autoUpdate.(map[string]interface{}); ok {
-			result += fmt.Sprintf("\n%s\n", tabStyle.Render("🔄 Автообновление:"))
-			if isEnabled, ok := autoUpdateMap["isEnabled"]; ok {
-				result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Включено"), valueStyle.Render(fmt.Sprintf("%v", isEnabled)))
-			}
-		}
+	if autoUpdate := agent.IntegrationOptions.AutoUpdateOptions; len(optionEntries(autoUpdate)) > 0 {
+		result += fmt.Sprintf("\n%s\n", tabStyle.Render("🔄 Автообновление:"))
+		result += fmt.Sprintf("  %s: %s\n", labelStyle.Render("Включено"), valueStyle.Render(fmt.Sprintf("%t", autoUpdate.IsEnabled)))
 	}
 
 	// Источник образа
-	if agent.ImageSource != nil {
+	if imageSource := optionEntries(agent.ImageSource); len(imageSource) > 0 {
 		result += fmt.Sprintf("\n%s\n", tabStyle.Render("🐳 Источник образа:"))
-		for key, value := range agent.ImageSource {
+		for key, value := range imageSource {
 			result += fmt.Sprintf("  %s: %s\n", labelStyle.Render(key), valueStyle.Render(fmt.Sprintf("%v", value)))
 		}
 	}
 
 	// Интеграционные настройки
-	if len(agent.IntegrationOptions) > 0 {
+	if len(integrationOptions) > 0 {
 		result += fmt.Sprintf("\n%s\n", tabStyle.Render("🔗 Интеграционные настройки:"))
-		for key, value := range agent.IntegrationOptions {
+		for key, value := range integrationOptions {
 			result += fmt.Sprintf("  %s: %v\n", labelStyle.Render(key), valueStyle.Render(fmt.Sprintf("%v", value)))
 		}
 	}
 
 	// Опции
-	if len(agent.Options) > 0 {
+	if len(options) > 0 {
 		result += fmt.Sprintf("\n%s\n", tabStyle.Render("⚙️ Опции:"))
-		for key, value := range agent.Options {
+		for key, value := range options {
 			result += fmt.Sprintf("  %s: %v\n", labelStyle.Render(key), valueStyle.Render(fmt.Sprintf("%v", value)))
 		}
 	}
@@ -845,11 +870,11 @@ func CreateAgentsTable(agents []api.Agent, title string) *TableModel {
 
 	var rows []table.Row
 	for _, agent := range agents {
-		agentType := FormatAgentType(agent.AgentType)
+		agentType := FormatAgentType(string(agent.AgentType))
 		rows = append(rows, table.Row{
 			agent.ID,
 			agent.Name,
-			FormatStatus(agent.Status),
+			FormatStatus(string(agent.Status)),
 			agentType,
 			"ID: " + agent.CreatedBy,
 			"ID: " + agent.UpdatedBy,
@@ -877,7 +902,7 @@ func CreateMCPServersTable(servers []api.MCPServer, title string) *TableModel {
 		rows = append(rows, table.Row{
 			server.ID,
 			server.Name,
-			FormatStatus(server.Status),
+			FormatStatus(string(server.Status)),
 			server.Description,
 			server.CreatedAt.Format("02.01.2006 15:04"),
 			server.UpdatedAt.Format("02.01.2006 15:04"),
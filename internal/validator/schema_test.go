@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate_EmbeddedSchema(t *testing.T) {
+	// Валидация не зависит от рабочей директории
+	t.Chdir(t.TempDir())
+
+	config := map[string]interface{}{
+		"agents": []interface{}{
+			map[string]interface{}{"name": "assistant"},
+		},
+	}
+	if err := Validate(config); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestValidate_SchemaFile(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(`{"type": "object", "required": ["agents"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	SetSchemaFile(schemaFile)
+	defer SetSchemaFile("")
+
+	if err := Validate(map[string]interface{}{}); err == nil {
+		t.Error("Validate() should use the schema file")
+	}
+}
@@ -0,0 +1,337 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+)
+
+// Severity - важность нарушения семантического правила
+type Severity string
+
+const (
+	// SeverityError - конфигурация не может быть развернута
+	SeverityError Severity = "error"
+	// SeverityWarning - конфигурация может быть развернута, но, вероятно, содержит ошибку
+	SeverityWarning Severity = "warning"
+)
+
+// Rule описывает семантическое правило проверки конфигурации. ID правила
+// не меняется между версиями.
+type Rule struct {
+	ID          string
+	Name        string
+	Severity    Severity
+	Description string
+}
+
+// Семантические правила, которые проверяет CheckSemantics
+var (
+	RuleDuplicateName = Rule{"AIA001", "duplicate-name", SeverityError,
+		"Имена ресурсов одного типа уникальны, в том числе между включаемыми файлами и оверлеями"}
+	RuleDuplicateKey = Rule{"AIA002", "duplicate-key", SeverityError,
+		"Ключи ресурсов одного типа в файле состояния (key или name) уникальны"}
+	RuleUnknownMCPServer = Rule{"AIA003", "unknown-mcp-server", SeverityError,
+		"MCP сервер агента описан в конфигурации или существует в проекте (--online)"}
+	RuleUnknownAgent = Rule{"AIA004", "unknown-agent", SeverityError,
+		"Агент системы описан в конфигурации или существует в проекте (--online)"}
+	RuleUnverifiedReference = Rule{"AIA005", "unverified-reference", SeverityWarning,
+		"Ссылка на ресурс, которого нет в конфигурации, без --online не проверяется"}
+	RuleTooManyEnvs = Rule{"AIA006", "too-many-envs", SeverityError,
+		"rawEnvs и secretEnvs вместе содержат не больше 20 переменных"}
+	RuleOrchestratorLLM = Rule{"AIA007", "orchestrator-llm", SeverityWarning,
+		"Система из нескольких агентов задает LLM оркестратора (orchestratorOptions.llm)"}
+	RuleOrchestratorScaling = Rule{"AIA008", "orchestrator-scaling", SeverityWarning,
+		"При orchestratorOptions.scaling.isScaleUpAllSystem масштабирование агентов системы не задается"}
+	RuleScalingRange = Rule{"AIA009", "scaling-range", SeverityError,
+		"minScale не больше maxScale"}
+	RuleDuplicateSystemAgent = Rule{"AIA010", "duplicate-system-agent", SeverityError,
+		"Агент входит в систему один раз"}
+	RuleUnsupportedOption = Rule{"AIA011", "unsupported-option", SeverityError,
+		"Конфигурация не содержит опций, которые API не принимает: options MCP сервера и неизвестные ключи options агента"}
+)
+
+// Rules - все семантические правила в порядке ID
+var Rules = []Rule{
+	RuleDuplicateName,
+	RuleDuplicateKey,
+	RuleUnknownMCPServer,
+	RuleUnknownAgent,
+	RuleUnverifiedReference,
+	RuleTooManyEnvs,
+	RuleOrchestratorLLM,
+	RuleOrchestratorScaling,
+	RuleScalingRange,
+	RuleDuplicateSystemAgent,
+	RuleUnsupportedOption,
+}
+
+// maxEnvs - максимальное количество переменных окружения, которое принимает API.
+// Схема ограничивает только rawEnvs, правило проверяет сумму с secretEnvs.
+const maxEnvs = 20
+
+// uuidPattern проверяет, что ссылка на ресурс задана идентификатором, а не именем
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Finding - нарушение семантического правила
+type Finding struct {
+	Rule    Rule
+	Message string
+	// Position - позиция значения в исходном файле, если она известна
+	Position parser.Position
+}
+
+func (f Finding) String() string {
+	message := fmt.Sprintf("%s %s [%s]: %s", f.Rule.Severity, f.Rule.ID, f.Rule.Name, f.Message)
+	if f.Position.IsValid() {
+		return fmt.Sprintf("%s: %s", f.Position, message)
+	}
+	return message
+}
+
+// HasErrors проверяет, есть ли среди нарушений ошибки
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Rule.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// SemanticError - нарушения семантических правил с важностью error
+type SemanticError struct {
+	Findings []Finding
+}
+
+func (e *SemanticError) Error() string {
+	var b strings.Builder
+	b.WriteString("configuration violates semantic rules:")
+	for _, finding := range e.Findings {
+		b.WriteString("\n  - ")
+		b.WriteString(finding.String())
+	}
+	return b.String()
+}
+
+// FindingsError возвращает *SemanticError с ошибками из findings или nil,
+// если ошибок среди нарушений нет
+func FindingsError(findings []Finding) error {
+	var errs []Finding
+	for _, finding := range findings {
+		if finding.Rule.Severity == SeverityError {
+			errs = append(errs, finding)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &SemanticError{Findings: errs}
+}
+
+// Project - ресурсы проекта для проверки ссылок, которых нет в конфигурации.
+// Ссылка - имя или ID ресурса.
+type Project interface {
+	HasMCPServer(ref string) bool
+	HasAgent(ref string) bool
+}
+
+// semanticChecker накапливает нарушения правил для одной конфигурации
+type semanticChecker struct {
+	config   map[string]interface{}
+	sources  parser.SourceMap
+	project  Project
+	findings []Finding
+}
+
+// CheckSemantics проверяет ссылки между ресурсами и согласованность
+// конфигурации, которые не выражаются JSON схемой (см. Rules). Если project
+// не nil, ссылки на ресурсы вне конфигурации проверяются в проекте.
+// Конфигурация должна соответствовать схеме. Нарушения указывают на позиции
+// значений в исходных файлах документа.
+func CheckSemantics(doc *parser.Document, project Project) ([]Finding, error) {
+	m, _, err := manifest.FromMap(doc.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &semanticChecker{config: doc.Config, sources: doc.Sources, project: project}
+	c.checkNames(m)
+	c.checkMCPServers(m)
+	c.checkAgents(m)
+	c.checkSystems(m)
+	return c.findings, nil
+}
+
+// report добавляет нарушение правила для значения по пути в конфигурации
+func (c *semanticChecker) report(rule Rule, path []string, format string, args ...interface{}) {
+	position, _ := c.sources.Lookup(c.config, path)
+	c.findings = append(c.findings, Finding{
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+		Position: position,
+	})
+}
+
+func (c *semanticChecker) checkNames(m *manifest.Manifest) {
+	type resource struct{ name, key string }
+	sections := []struct {
+		section   string
+		kind      string
+		resources []resource
+	}{
+		{section: "mcp-servers", kind: "MCP server"},
+		{section: "agents", kind: "agent"},
+		{section: "agent-systems", kind: "agent system"},
+	}
+	for _, server := range m.MCPServers {
+		sections[0].resources = append(sections[0].resources, resource{server.Name, server.StateKey()})
+	}
+	for _, agent := range m.Agents {
+		sections[1].resources = append(sections[1].resources, resource{agent.Name, agent.StateKey()})
+	}
+	for _, system := range m.AgentSystems {
+		sections[2].resources = append(sections[2].resources, resource{system.Name, system.StateKey()})
+	}
+
+	for _, s := range sections {
+		names := make(map[string]int)
+		keys := make(map[string]int)
+		for i, r := range s.resources {
+			path := []string{s.section, strconv.Itoa(i)}
+			if first, ok := names[r.name]; ok {
+				c.report(RuleDuplicateName, append(path, "name"), "%s '%s' is defined more than once (items %d and %d)", s.kind, r.name, first+1, i+1)
+			} else {
+				names[r.name] = i
+			}
+			if first, ok := keys[r.key]; ok && s.resources[first].name != r.name {
+				c.report(RuleDuplicateKey, path, "%s '%s' has the same state key '%s' as '%s'", s.kind, r.name, r.key, s.resources[first].name)
+			} else if !ok {
+				keys[r.key] = i
+			}
+		}
+	}
+}
+
+func (c *semanticChecker) checkMCPServers(m *manifest.Manifest) {
+	for i, server := range m.MCPServers {
+		path := []string{"mcp-servers", strconv.Itoa(i)}
+		if len(server.Options) > 0 {
+			c.report(RuleUnsupportedOption, append(path, "options"), "MCP server '%s' sets options, which the API does not accept", server.Name)
+		}
+		c.checkEnvs(server.EnvironmentOptions, append(path, "environmentOptions", "rawEnvs"), "MCP server '%s'", server.Name)
+		c.checkScaling(server.Scaling, append(path, "scaling"), "MCP server '%s'", server.Name)
+	}
+}
+
+func (c *semanticChecker) checkAgents(m *manifest.Manifest) {
+	servers := make(map[string]bool)
+	for _, server := range m.MCPServers {
+		servers[server.Name] = true
+	}
+
+	for i, agent := range m.Agents {
+		path := []string{"agents", strconv.Itoa(i)}
+		for j, ref := range agent.MCPServers {
+			if servers[ref] {
+				continue
+			}
+			refPath := append(path, "mcpServers", strconv.Itoa(j))
+			c.checkReference(RuleUnknownMCPServer, refPath, ref, "MCP server", "agent '%s'", agent.Name)
+		}
+
+		if agent.Options != nil {
+			keys := make([]string, 0, len(agent.Options.Extra))
+			for key := range agent.Options.Extra {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				c.report(RuleUnsupportedOption, append(path, "options", key), "agent '%s' sets options.%s, which the API does not accept", agent.Name, key)
+			}
+			c.checkEnvs(agent.Options.Env, append(path, "options", "env", "rawEnvs"), "agent '%s'", agent.Name)
+			c.checkScaling(agent.Options.Scaling, append(path, "options", "scaling"), "agent '%s'", agent.Name)
+		}
+	}
+}
+
+func (c *semanticChecker) checkSystems(m *manifest.Manifest) {
+	agents := make(map[string]bool)
+	for _, agent := range m.Agents {
+		agents[agent.Name] = true
+	}
+
+	for i, system := range m.AgentSystems {
+		path := []string{"agent-systems", strconv.Itoa(i)}
+		orchestrator := system.OrchestratorOptions
+
+		listed := make(map[string]bool)
+		for j, agent := range system.Agents {
+			agentPath := append(path, "agents", strconv.Itoa(j))
+			if listed[agent.Name] {
+				c.report(RuleDuplicateSystemAgent, agentPath, "agent system '%s' lists agent '%s' more than once", system.Name, agent.Name)
+			}
+			listed[agent.Name] = true
+
+			if !agents[agent.Name] {
+				c.checkReference(RuleUnknownAgent, agentPath, agent.Name, "agent", "agent system '%s'", system.Name)
+			}
+
+			c.checkScaling(agent.Scaling, append(agentPath, "scaling"), "agent '%s' in agent system '%s'", agent.Name, system.Name)
+			if agent.Scaling != nil && orchestrator != nil && orchestrator.Scaling != nil && orchestrator.Scaling.IsScaleUpAllSystem {
+				c.report(RuleOrchestratorScaling, append(agentPath, "scaling"),
+					"agent system '%s' scales up all agents with the orchestrator, scaling of agent '%s' is ignored", system.Name, agent.Name)
+			}
+		}
+
+		if len(system.Agents) > 1 && (orchestrator == nil || orchestrator.LLM == nil) {
+			c.report(RuleOrchestratorLLM, path, "agent system '%s' has %d agents but no orchestratorOptions.llm to route between them", system.Name, len(system.Agents))
+		}
+		if orchestrator != nil {
+			c.checkEnvs(orchestrator.Env, append(path, "orchestratorOptions", "env", "rawEnvs"), "orchestrator of agent system '%s'", system.Name)
+			c.checkScaling(orchestrator.Scaling, append(path, "orchestratorOptions", "scaling"), "orchestrator of agent system '%s'", system.Name)
+		}
+	}
+}
+
+// checkReference проверяет ссылку на ресурс, которого нет в конфигурации.
+// UUID без проекта не проверяется: ресурс может существовать в проекте.
+func (c *semanticChecker) checkReference(rule Rule, path []string, ref, kind, owner string, args ...interface{}) {
+	owner = fmt.Sprintf(owner, args...)
+	switch {
+	case c.project != nil:
+		exists := c.project.HasMCPServer
+		if rule == RuleUnknownAgent {
+			exists = c.project.HasAgent
+		}
+		if !exists(ref) {
+			c.report(rule, path, "%s references %s '%s' that is not defined in the configuration or the project", owner, kind, ref)
+		}
+	case !uuidPattern.MatchString(ref):
+		c.report(RuleUnverifiedReference, path, "%s references %s '%s' that is not defined in the configuration; use --online to check the project", owner, kind, ref)
+	}
+}
+
+func (c *semanticChecker) checkEnvs(env *manifest.EnvironmentOptions, path []string, owner string, args ...interface{}) {
+	if env == nil {
+		return
+	}
+	if total := len(env.RawEnvs) + len(env.SecretEnvs); total > maxEnvs {
+		c.report(RuleTooManyEnvs, path, "%s has %d environment variables (%d rawEnvs, %d secretEnvs), at most %d are allowed",
+			fmt.Sprintf(owner, args...), total, len(env.RawEnvs), len(env.SecretEnvs), maxEnvs)
+	}
+}
+
+func (c *semanticChecker) checkScaling(scaling *manifest.Scaling, path []string, owner string, args ...interface{}) {
+	if scaling == nil || scaling.MinScale == nil || scaling.MaxScale == nil {
+		return
+	}
+	if *scaling.MinScale > *scaling.MaxScale {
+		c.report(RuleScalingRange, append(path, "minScale"), "%s has minScale %d greater than maxScale %d", fmt.Sprintf(owner, args...), *scaling.MinScale, *scaling.MaxScale)
+	}
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+)
+
+type fakeProject struct {
+	mcpServers map[string]bool
+	agents     map[string]bool
+}
+
+func (p fakeProject) HasMCPServer(ref string) bool { return p.mcpServers[ref] }
+func (p fakeProject) HasAgent(ref string) bool     { return p.agents[ref] }
+
+func ruleIDs(findings []Finding) map[string]int {
+	ids := make(map[string]int)
+	for _, finding := range findings {
+		ids[finding.Rule.ID]++
+	}
+	return ids
+}
+
+func semanticConfig() map[string]interface{} {
+	// По отдельности rawEnvs и secretEnvs проходят проверку схемы, вместе - нет
+	rawEnvs := make(map[string]interface{})
+	for _, key := range []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L"} {
+		rawEnvs[key] = "value"
+	}
+	secretEnvs := make(map[string]interface{})
+	for _, key := range []string{"M", "N", "O", "P", "Q", "R", "S", "T", "U"} {
+		secretEnvs[key] = map[string]interface{}{"id": "11111111-2222-3333-4444-555555555555", "version": 1}
+	}
+	return map[string]interface{}{
+		"mcp-servers": []interface{}{
+			map[string]interface{}{
+				"name":               "search",
+				"options":            map[string]interface{}{"timeout": 30},
+				"environmentOptions": map[string]interface{}{"rawEnvs": rawEnvs, "secretEnvs": secretEnvs},
+			},
+		},
+		"agents": []interface{}{
+			map[string]interface{}{"name": "assistant", "mcpServers": []interface{}{"search", "weather"}},
+			map[string]interface{}{"name": "writer", "options": map[string]interface{}{
+				"scaling":     map[string]interface{}{"minScale": 3, "maxScale": 1},
+				"temperature": 0.2,
+			}},
+			map[string]interface{}{"name": "writer"},
+		},
+		"agent-systems": []interface{}{
+			map[string]interface{}{
+				"name":   "support",
+				"agents": []interface{}{"assistant", "assistant", "reviewer", map[string]interface{}{"name": "writer", "scaling": map[string]interface{}{"minScale": 1}}},
+				"orchestratorOptions": map[string]interface{}{
+					"scaling": map[string]interface{}{"isScaleUpAllSystem": true},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckSemantics_Offline(t *testing.T) {
+	findings, err := CheckSemantics(&parser.Document{Config: semanticConfig()}, nil)
+	if err != nil {
+		t.Fatalf("CheckSemantics() error = %v", err)
+	}
+
+	want := map[string]int{
+		RuleDuplicateName.ID:        1,
+		RuleUnverifiedReference.ID:  2, // weather, reviewer
+		RuleTooManyEnvs.ID:          1,
+		RuleOrchestratorLLM.ID:      1,
+		RuleOrchestratorScaling.ID:  1,
+		RuleScalingRange.ID:         1,
+		RuleDuplicateSystemAgent.ID: 1,
+		RuleUnsupportedOption.ID:    2, // options of search, options.temperature of writer
+	}
+	got := ruleIDs(findings)
+	for id, count := range want {
+		if got[id] != count {
+			t.Errorf("%s findings = %d, want %d (%v)", id, got[id], count, findings)
+		}
+	}
+	if got[RuleUnknownMCPServer.ID] != 0 || got[RuleUnknownAgent.ID] != 0 {
+		t.Errorf("references must not be errors without a project: %v", findings)
+	}
+	if !HasErrors(findings) {
+		t.Error("HasErrors() = false")
+	}
+}
+
+func TestCheckSemantics_Online(t *testing.T) {
+	project := fakeProject{
+		mcpServers: map[string]bool{"weather": true},
+		agents:     map[string]bool{},
+	}
+
+	findings, err := CheckSemantics(&parser.Document{Config: semanticConfig()}, project)
+	if err != nil {
+		t.Fatalf("CheckSemantics() error = %v", err)
+	}
+
+	got := ruleIDs(findings)
+	if got[RuleUnknownMCPServer.ID] != 0 {
+		t.Errorf("weather exists in the project: %v", findings)
+	}
+	if got[RuleUnknownAgent.ID] != 1 {
+		t.Errorf("%s findings = %d, want 1 for reviewer", RuleUnknownAgent.ID, got[RuleUnknownAgent.ID])
+	}
+	if got[RuleUnverifiedReference.ID] != 0 {
+		t.Errorf("references are verified online: %v", findings)
+	}
+}
+
+func TestCheckSemantics_Valid(t *testing.T) {
+	config := map[string]interface{}{
+		"agents": []interface{}{
+			map[string]interface{}{"name": "assistant", "mcpServers": []interface{}{"0b6f8c2e-6f1a-4c55-9b1e-6d1f2a3b4c5d"}},
+		},
+		"agent-systems": []interface{}{
+			map[string]interface{}{"name": "support", "agents": []interface{}{"assistant"}},
+		},
+	}
+
+	findings, err := CheckSemantics(&parser.Document{Config: config}, nil)
+	if err != nil {
+		t.Fatalf("CheckSemantics() error = %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("findings = %v, want none", findings)
+	}
+}
+
+func TestRules_UniqueIDs(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, rule := range Rules {
+		if seen[rule.ID] || seen[rule.Name] {
+			t.Errorf("duplicate rule %s (%s)", rule.ID, rule.Name)
+		}
+		seen[rule.ID] = true
+		seen[rule.Name] = true
+	}
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+)
+
+func TestValidateDocument_SourcePositions(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"schema.json": `{
+  "type": "object",
+  "properties": {
+    "agents": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "scaling": {"type": "object", "properties": {"minScale": {"type": "integer"}}}
+        }
+      }
+    }
+  }
+}`,
+		"ai-agents.yaml":      "includes:\n  - agents/*.yaml\n",
+		"agents/support.yaml": "agents:\n  - name: support\n    scaling:\n      minScale: two\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	doc, err := parser.ProcessYAMLDocument(filepath.Join(dir, "ai-agents.yaml"))
+	if err != nil {
+		t.Fatalf("ProcessYAMLDocument() error = %v", err)
+	}
+
+	SetSchemaFile(filepath.Join(dir, "schema.json"))
+	defer SetSchemaFile("")
+
+	err = ValidateDocument(doc)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateDocument() error = %v, want *ValidationError", err)
+	}
+	if len(validationErr.Issues) != 1 {
+		t.Fatalf("issues = %v, want 1", validationErr.Issues)
+	}
+
+	issue := validationErr.Issues[0]
+	want := parser.Position{File: filepath.Join(dir, "agents", "support.yaml"), Line: 4, Column: 17}
+	if issue.Position != want {
+		t.Errorf("Position = %v, want %v", issue.Position, want)
+	}
+	if !strings.Contains(err.Error(), "support.yaml:4:17: agents.0.scaling.minScale") {
+		t.Errorf("error %q should point to the source position", err)
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/manifest"
+	"github.com/cloud-ru/evo-ai-agents-cli/internal/parser"
+	"github.com/cloud-ru/evo-ai-agents-cli/schemas"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -123,13 +127,78 @@ func (v *ConfigValidator) PrintErrors(result *ValidationResult) {
 	}
 }
 
-// ValidateConfig валидирует конфигурацию по JSON схеме
+// ValidationIssue представляет нарушение JSON схемы
+type ValidationIssue struct {
+	Field       string
+	Description string
+	// Position - позиция значения в исходном файле, если она известна
+	Position parser.Position
+}
+
+func (i ValidationIssue) String() string {
+	if i.Position.IsValid() {
+		return fmt.Sprintf("%s: %s: %s", i.Position, i.Field, i.Description)
+	}
+	return fmt.Sprintf("%s: %s", i.Field, i.Description)
+}
+
+// ValidationError представляет ошибку валидации конфигурации по JSON схеме
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	var errors string
+	for _, issue := range e.Issues {
+		errors += fmt.Sprintf("- %s\n", issue)
+	}
+	return fmt.Sprintf("validation failed:\n%s", errors)
+}
+
+// schemaFile - файл схемы, заменяющий встроенную схему (флаг --schema)
+var schemaFile string
+
+// SetSchemaFile задает файл схемы для Validate вместо встроенной схемы.
+// Пустой путь возвращает встроенную схему.
+func SetSchemaFile(path string) {
+	schemaFile = path
+}
+
+// Validate валидирует конфигурацию по встроенной JSON схеме или по файлу,
+// заданному SetSchemaFile
+func Validate(config map[string]interface{}) error {
+	return ValidateDocument(&parser.Document{Config: config})
+}
+
+// ValidateDocument валидирует итоговую конфигурацию парсера так же, как
+// Validate. Нарушения схемы указывают на позиции значений в исходных файлах.
+func ValidateDocument(doc *parser.Document) error {
+	if schemaFile == "" {
+		return validateConfig(doc.Config, doc.Sources, schemas.Schema)
+	}
+	schemaData, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return fmt.Errorf("failed to read schema file %s: %w", schemaFile, err)
+	}
+	return validateConfig(doc.Config, doc.Sources, schemaData)
+}
+
+// ValidateConfig валидирует конфигурацию по JSON схеме из файла. Нарушения
+// схемы возвращаются как *ValidationError.
 func ValidateConfig(config map[string]interface{}, schemaPath string) error {
 	// Читаем схему
 	schemaData, err := ioutil.ReadFile(schemaPath)
 	if err != nil {
 		return fmt.Errorf("failed to read schema file %s: %w", schemaPath, err)
 	}
+	return validateConfig(config, nil, schemaData)
+}
+
+// validateConfig валидирует конфигурацию по JSON схеме. Нарушения схемы
+// получают позиции значений из sources, если они известны.
+func validateConfig(config map[string]interface{}, sources parser.SourceMap, schemaData []byte) error {
+	// Переносим устаревшие ключи на места, которые ожидает схема
+	manifest.Normalize(config)
 
 	// Парсим схему
 	var schema map[string]interface{}
@@ -154,11 +223,22 @@ func ValidateConfig(config map[string]interface{}, schemaPath string) error {
 	}
 
 	if !result.Valid() {
-		var errors string
+		validationErr := &ValidationError{}
 		for _, desc := range result.Errors() {
-			errors += fmt.Sprintf("- %s\n", desc)
+			issue := ValidationIssue{Field: desc.Field(), Description: desc.Description()}
+			var path []string
+			if issue.Field != gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+				path = strings.Split(issue.Field, ".")
+			}
+			issue.Position, _ = sources.Lookup(config, path)
+			validationErr.Issues = append(validationErr.Issues, issue)
 		}
-		return fmt.Errorf("validation failed:\n%s", errors)
+		return validationErr
+	}
+
+	// Проверяем, что конфигурация соответствует модели, которую используют деплойеры
+	if _, _, err := manifest.FromMap(config); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,32 @@
+// Package schemas содержит JSON схему конфигурации AI Agents CLI, встроенную
+// в бинарный файл, чтобы валидация не зависела от рабочей директории.
+package schemas
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Schema - JSON схема конфигурации (schema.json)
+//
+//go:embed schema.json
+var Schema []byte
+
+// Version возвращает версию схемы из поля version
+func Version() string {
+	var header struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(Schema, &header); err != nil || header.Version == "" {
+		return "unknown"
+	}
+	return header.Version
+}
+
+// Digest возвращает SHA-256 встроенной схемы
+func Digest() string {
+	sum := sha256.Sum256(Schema)
+	return hex.EncodeToString(sum[:])
+}
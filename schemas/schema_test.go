@@ -0,0 +1,19 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatalf("embedded schema is not valid JSON: %v", err)
+	}
+	if Version() == "unknown" {
+		t.Error("embedded schema has no version")
+	}
+	if len(Digest()) != 64 {
+		t.Errorf("Digest() = %s, want SHA-256 hex", Digest())
+	}
+}